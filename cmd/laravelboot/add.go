@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/ui"
+	"laravelboot/internal/utils"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newAddCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <feature>...",
+		Short: "Add one or more features to an existing project",
+		Long: `Add one or more features to the project in the current directory (or --path).
+
+Auth & database:
+  auth
+
+Platform (or "platform" for all of them):
+  ` + wrapList(config.PlatformFeatures[:len(config.PlatformFeatures)-1]) + `
+
+Infrastructure (or "infra"):
+  ` + wrapList(config.InfraFeatures[:len(config.InfraFeatures)-1]) + `
+
+Enterprise (or "enterprise"):
+  ` + wrapList(config.EnterpriseFeatures[:len(config.EnterpriseFeatures)-1]) + `
+
+Everything:
+  all
+
+Features registered by plugins are accepted too (see "laravelboot plugin list").`,
+		Example: `  laravelboot add auth
+  laravelboot add roles media search
+  laravelboot add docker --path ./api --dry-run`,
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return featureNames(opts), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.dryRun {
+				go utils.CheckForUpdate(VERSION)
+			}
+			cwd, err := opts.projectDir()
+			if err != nil {
+				return err
+			}
+			conf, pluginMgr, err := loadProject(opts, true)
+			if err != nil {
+				return err
+			}
+
+			policy := opts.policy("add "+strings.Join(args, " "), true)
+			if missing := laravel.MissingDependencies(cwd, args); len(missing) > 0 {
+				ui.Printf("➕ Adding required features first: %s\n", strings.Join(missing, ", "))
+				args = append(missing, args...)
+			}
+			var unknown []string
+			for _, target := range args {
+				if addTarget(target, cwd, conf, pluginMgr, policy, opts.dryRun) == nil {
+					unknown = append(unknown, target)
+				}
+			}
+			if len(unknown) > 0 {
+				return fmt.Errorf("unknown feature(s): %s (see 'laravelboot add --help')", strings.Join(unknown, ", "))
+			}
+
+			// Several targets share one composer resolution.
+			if len(args) > 1 && !opts.dryRun {
+				if err := laravel.NewOrchestrator(cwd, opts.dryRun).Require(laravel.ExpandFeatures(args)); err != nil {
+					ui.Warnf("⚠️ Warning: batched composer require failed, each feature will install its own packages: %v\n", err)
+				}
+			}
+
+			defer opts.finishReport(policy.Report, cwd)
+			for i, target := range args {
+				run := addTarget(target, cwd, conf, pluginMgr, policy, opts.dryRun)
+				if err := policy.Report.Track(target, func() error { return pluginMgr.RunStep(target, conf, cwd, run) }); err != nil {
+					if policy.FailFast {
+						for _, rest := range args[i+1:] {
+							policy.Report.Skip(rest, "after an earlier failure (--fail-fast)")
+						}
+						return fmt.Errorf("%s: %v", target, err)
+					}
+					ui.Warnf("⚠️ Warning: %s failed: %v\n", target, err)
+					continue
+				}
+				if err := pluginMgr.Emit(plugins.Event{Name: plugins.EventAdd, Step: target, ProjectPath: cwd, Config: conf}); err != nil {
+					return err
+				}
+			}
+			return policy.Report.Err()
+		},
+	}
+}
+
+// addTarget returns the function that installs target, or nil when no
+// built-in or plugin feature has that name.
+func addTarget(target, cwd string, conf *config.Config, pluginMgr *plugins.PluginManager, policy laravel.Policy, dryRun bool) func() error {
+	switch {
+	case target == "auth":
+		manager := laravel.NewAuthManager(cwd, dryRun)
+		manager.Options = conf.Options
+		return manager.AddAuth
+	case target == "all":
+		manager := laravel.NewFullStackManager(cwd, dryRun)
+		manager.Options = conf.Options
+		manager.Policy = policy
+		return manager.AddAll
+	case contains(config.PlatformFeatures, target):
+		manager := laravel.NewPlatformManager(cwd, dryRun)
+		manager.Options = conf.Options
+		manager.Policy = policy
+		return func() error { return manager.RunStep(target) }
+	case contains(config.InfraFeatures, target):
+		manager := laravel.NewInfraManager(cwd, dryRun)
+		manager.Options = conf.Options
+		manager.Policy = policy
+		return func() error { return manager.RunStep(target) }
+	case contains(config.EnterpriseFeatures, target):
+		manager := laravel.NewEnterpriseManager(cwd, dryRun)
+		manager.Options = conf.Options
+		manager.Policy = policy
+		return func() error { return manager.RunStep(target) }
+	case pluginMgr.HasFeature(target):
+		return func() error { return pluginMgr.RunFeature(target, conf, cwd) }
+	}
+	return nil
+}
+
+// loadProject reads the config layered over the defaults, as new does,
+// and loads the plugins it names. Without a config file it uses the
+// defaults, unless the file is required.
+func loadProject(opts *globalOptions, optional bool) (*config.Config, *plugins.PluginManager, error) {
+	conf, err := config.LoadConfig(opts.configFile())
+	if os.IsNotExist(err) && optional {
+		conf = config.DefaultConfig()
+	} else if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("no %s found (run 'laravelboot init' first)", opts.configFile())
+	} else if err != nil {
+		return nil, nil, err
+	} else {
+		conf = config.Merge(config.DefaultConfig(), conf)
+	}
+
+	pluginMgr, err := plugins.LoadPluginManager(conf, opts.configDir(), opts.dryRun)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := conf.Validate(pluginMgr.FeatureNames()...); err != nil {
+		return nil, nil, err
+	}
+	return conf, pluginMgr, nil
+}
+
+// featureNames lists every name "add" accepts, for shell completion.
+func featureNames(opts *globalOptions) []string {
+	names := []string{"auth\tAuthentication and database", "all\tThe complete stack"}
+	names = append(names, config.PlatformFeatures...)
+	names = append(names, config.InfraFeatures...)
+	names = append(names, config.EnterpriseFeatures...)
+
+	conf, _ := config.LoadConfig(opts.configFile())
+	if pluginMgr, err := plugins.LoadPluginManager(conf, opts.configDir(), true); err == nil {
+		for _, f := range pluginMgr.FeatureSpecs() {
+			names = append(names, f.Name+"\t"+f.Description)
+		}
+	}
+	return names
+}
+
+func wrapList(items []string) string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		if line != "" && len(line)+len(item) > 70 {
+			lines = append(lines, strings.TrimSuffix(line, " "))
+			line = ""
+		}
+		line += item + ", "
+	}
+	lines = append(lines, strings.TrimSuffix(line, ", "))
+	return strings.Join(lines, "\n  ")
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
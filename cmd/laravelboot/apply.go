@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/ui"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newApplyCmd(opts *globalOptions) *cobra.Command {
+	var planOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Converge an existing project on its config file",
+		Long: `Compare the features listed in .laravelboot.yaml with those installed in the
+project, then add what is missing and remove what is no longer listed.`,
+		Example: `  laravelboot apply --plan
+  laravelboot apply --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := opts.projectDir()
+			if err != nil {
+				return err
+			}
+			conf, pluginMgr, err := loadProject(opts, false)
+			if err != nil {
+				return err
+			}
+
+			reconciler := laravel.NewReconciler(cwd, conf, pluginMgr, opts.dryRun)
+			changes, err := reconciler.Plan()
+			if err != nil {
+				return err
+			}
+			changes.Print()
+			if planOnly || changes.Empty() {
+				return nil
+			}
+
+			if !opts.yes && !opts.dryRun {
+				if ui.JSON() {
+					return fmt.Errorf("cannot ask for confirmation with --output=json; pass --yes")
+				}
+				if !confirm("\nApply these changes?") {
+					ui.Println("Apply cancelled.")
+					return nil
+				}
+			}
+
+			reconciler.Policy = opts.policy("apply", true)
+			err = reconciler.Apply(changes)
+			opts.finishReport(reconciler.Policy.Report, cwd)
+			if err != nil {
+				return err
+			}
+			if opts.dryRun {
+				ui.Println("\n[Dry Run] No changes were made")
+				return nil
+			}
+			ui.Printf("\n✨ Project now matches %s\n", opts.configFile())
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&planOnly, "plan", false, "only show the changes")
+	return cmd
+}
+
+func confirm(question string) bool {
+	ui.Prompt(question + " (y/N): ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(answer)) == "y"
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+func newDoctorCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check PHP, its extensions and the tools a project needs",
+		Long: `Check that this machine can build and run the project described by the config
+file (or the defaults): the PHP version for the target Laravel release, the
+PHP extensions its database and features need, the composer version,
+optional tools such as docker and node, free disk space and writable
+directories. Every problem comes with a fix for this system's package
+manager. 'new' runs the same checks before it starts.`,
+		Example: `  laravelboot doctor
+  laravelboot doctor --config saas.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := opts.projectDir()
+			if err != nil {
+				return err
+			}
+			conf, _, err := loadProject(opts, true)
+			if err != nil {
+				return err
+			}
+
+			ui.Println("🩺 Checking the environment...")
+			checks := laravel.NewDoctor(cwd, conf).Run()
+			laravel.PrintChecks(checks, false)
+			if err := laravel.ChecksErr(checks); err != nil {
+				return err
+			}
+			ui.Println("\n✨ Ready to build Laravel APIs")
+			return nil
+		},
+	}
+}
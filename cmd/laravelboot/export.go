@@ -0,0 +1,72 @@
+package main
+
+import (
+	"laravelboot/internal/export"
+	"laravelboot/internal/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export project artifacts",
+	}
+
+	var format, out, baseURL string
+	collection := &cobra.Command{
+		Use:   "collection",
+		Short: "Export the API routes as a Postman, Insomnia or Bruno collection",
+		Example: `  laravelboot export collection
+  laravelboot export collection --format bruno --base-url https://api.example.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := opts.projectDir()
+			if err != nil {
+				return err
+			}
+			exporter := export.NewCollectionExporter(cwd, format, out, opts.dryRun)
+			if baseURL != "" {
+				exporter.BaseURL = baseURL
+			}
+			return exporter.Export()
+		},
+	}
+	collection.Flags().StringVar(&format, "format", "postman", "postman, insomnia or bruno")
+	collection.Flags().StringVar(&out, "out", "", "directory to write the collection to (default: collections/)")
+	collection.Flags().StringVar(&baseURL, "base-url", "", "base URL for the environment (default: APP_URL from .env, else http://localhost:8000)")
+	collection.RegisterFlagCompletionFunc("format", fixedCompletion([]string{"postman", "insomnia", "bruno"}))
+
+	cmd.AddCommand(collection)
+	return cmd
+}
+
+func newSDKCmd(opts *globalOptions) *cobra.Command {
+	var out, goPackage string
+	var languages []string
+
+	cmd := &cobra.Command{
+		Use:   "sdk",
+		Short: "Generate typed TypeScript and Go API clients",
+		Example: `  laravelboot sdk
+  laravelboot sdk --lang go --go-package acmeapi --out ../clients`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := opts.projectDir()
+			if err != nil {
+				return err
+			}
+			generator := sdk.NewGenerator(cwd, out, opts.dryRun)
+			generator.Languages = languages
+			if goPackage != "" {
+				generator.GoPackage = goPackage
+			}
+			return generator.Generate()
+		},
+	}
+	cmd.Flags().StringVar(&out, "out", "", "directory to write the clients to (default: sdk/)")
+	cmd.Flags().StringSliceVar(&languages, "lang", []string{"typescript", "go"}, "languages to generate")
+	cmd.Flags().StringVar(&goPackage, "go-package", "", "Go package name (default: client)")
+	cmd.RegisterFlagCompletionFunc("lang", fixedCompletion([]string{"typescript", "go"}))
+	return cmd
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/interactive"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/presets"
+	"laravelboot/internal/ui"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newInitCmd(opts *globalOptions) *cobra.Command {
+	var (
+		fromPreset   string
+		out          string
+		perMinute    int
+		phpstanLevel int
+		overrides    config.Config
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create or edit .laravelboot.yaml",
+		Long: `Create or edit .laravelboot.yaml.
+
+On a terminal, init opens a full-screen wizard: pick a preset, set the project
+settings, choose features by category (dependencies are selected for you), set
+the options those features read and review a summary before saving.
+
+With --yes, or when no terminal is attached, init never prompts. It writes the
+config built from the defaults, the existing file (or --from-preset), then
+LARAVELBOOT_* environment variables, then flags. Every flag below has an
+environment variable named after it, e.g. LARAVELBOOT_DATABASE=postgres or
+LARAVELBOOT_FEATURES=roles,media.`,
+		Example: `  laravelboot init
+  laravelboot init --yes --from-preset saas --project-name shop --database postgres
+  LARAVELBOOT_FEATURES=roles,media laravelboot init --yes --out ci/.laravelboot.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flagsFromEnv(cmd.LocalFlags()); err != nil {
+				return err
+			}
+			if out == "" {
+				out = opts.configFile()
+			}
+
+			base, existing, err := initBase(opts.configFile(), fromPreset)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("rate-limit") {
+				overrides.Options.RateLimit.PerMinute = perMinute
+			}
+			if cmd.Flags().Changed("phpstan-level") {
+				overrides.Options.Quality.PHPStanLevel = &phpstanLevel
+			}
+			conf := config.Merge(base, &overrides)
+
+			pluginMgr, err := plugins.LoadPluginManager(conf, opts.configDir(), opts.dryRun)
+			if err != nil {
+				return err
+			}
+
+			if interactive.IsTerminal() && !opts.yes && !ui.JSON() {
+				conf, err = interactive.RunInit(conf, pluginMgr.FeatureSpecs())
+				if err == interactive.ErrCancelled {
+					ui.Println("👋 Nothing saved.")
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			} else {
+				// Select dependencies the way the wizard does. Fixed ones
+				// like auth come from their own setting, not the lists.
+				for _, list := range []*[]string{&conf.Features, &conf.Infra, &conf.Enterprise} {
+					if *list != nil {
+						*list = laravel.Configurable(laravel.WithDependencies(*list))
+					}
+				}
+				if err := conf.Validate(pluginMgr.FeatureNames()...); err != nil {
+					return err
+				}
+				if _, err := os.Stat(out); err == nil && !existing && !opts.yes {
+					return fmt.Errorf("%s already exists; pass --yes to overwrite it", out)
+				}
+			}
+
+			if opts.dryRun {
+				ui.Printf("[Dry Run] Would save configuration to %s\n", out)
+				return nil
+			}
+			if err := conf.Save(out); err != nil {
+				return fmt.Errorf("saving config: %v", err)
+			}
+			ui.Printf("✨ Configuration saved to %s\n", out)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&fromPreset, "from-preset", "", "start from this preset instead of the existing config")
+	flags.StringVarP(&out, "out", "o", "", "where to save the config (default: --config)")
+	flags.StringVar(&overrides.ProjectName, "project-name", "", "project name")
+	flags.StringVar(&overrides.Database, "database", "", "database: mysql, postgres, sqlite or mongo")
+	flags.StringVar(&overrides.Auth, "auth", "", "auth driver: sanctum or passport")
+	flags.StringVar(&overrides.Architecture, "architecture", "", "architecture: domain-based or standard")
+	flags.StringSliceVar(&overrides.Features, "features", nil, "platform features")
+	flags.StringSliceVar(&overrides.Infra, "infra", nil, "infra features")
+	flags.StringSliceVar(&overrides.Enterprise, "enterprise", nil, "enterprise features")
+	flags.StringSliceVar(&overrides.Plugins, "plugins", nil, "plugin names or paths")
+	flags.StringVar(&overrides.Options.Docker.PHPVersion, "php", "", "PHP version for the Docker images: 8.2, 8.3 or 8.4")
+	flags.StringVar(&overrides.Options.Responses.Format, "response-format", "", "API response envelope: custom, jsonapi or problem")
+	flags.StringVar(&overrides.Options.Pagination.Strategy, "pagination", "", "Default pagination strategy: offset, simple or cursor")
+	flags.StringVar(&overrides.Options.Messaging.Driver, "messaging-driver", "", "broker for messaging: rabbitmq, kafka or redis")
+	flags.StringSliceVar(&overrides.Options.WebhookReceiver.Sources, "webhook-sources", nil, "webhook-receiver sources, one route each")
+	flags.StringSliceVar(&overrides.Options.FeatureFlags.Flags, "feature-flags", nil, "feature flag names, one class each")
+	flags.StringVar(&overrides.Options.Idempotency.Store, "idempotency-store", "", "cache store for idempotency: redis, database, memcached, dynamodb, file or array")
+	flags.IntVar(&perMinute, "rate-limit", 0, "API requests per minute for rate-limit")
+	flags.IntVar(&phpstanLevel, "phpstan-level", 0, "PHPStan level (0-9) for quality")
+
+	cmd.RegisterFlagCompletionFunc("from-preset", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return presetNames(), cobra.ShellCompDirectiveDefault
+	})
+	cmd.RegisterFlagCompletionFunc("database", fixedCompletion(config.Databases))
+	cmd.RegisterFlagCompletionFunc("auth", fixedCompletion(config.AuthDrivers))
+	cmd.RegisterFlagCompletionFunc("architecture", fixedCompletion(config.Architectures))
+	cmd.RegisterFlagCompletionFunc("features", fixedCompletion(config.PlatformFeatures))
+	cmd.RegisterFlagCompletionFunc("infra", fixedCompletion(config.InfraFeatures))
+	cmd.RegisterFlagCompletionFunc("enterprise", fixedCompletion(config.EnterpriseFeatures))
+	cmd.RegisterFlagCompletionFunc("php", fixedCompletion(config.PHPVersions))
+	cmd.RegisterFlagCompletionFunc("response-format", fixedCompletion(config.ResponseFormats))
+	cmd.RegisterFlagCompletionFunc("pagination", fixedCompletion(config.PaginationStrategies))
+	cmd.RegisterFlagCompletionFunc("messaging-driver", fixedCompletion(config.MessagingDrivers))
+	cmd.RegisterFlagCompletionFunc("idempotency-store", fixedCompletion(config.IdempotencyStores))
+
+	return cmd
+}
+
+// initBase is the config init starts from: the defaults, overlaid with
+// the preset when one is given, otherwise with the existing config file.
+// existing reports whether the config file was used.
+func initBase(configFile, preset string) (*config.Config, bool, error) {
+	if preset != "" {
+		p, err := presets.Load(preset)
+		if err != nil {
+			return nil, false, err
+		}
+		return config.Merge(config.DefaultConfig(), p.Config), false, nil
+	}
+
+	conf, err := config.LoadConfig(configFile)
+	if os.IsNotExist(err) {
+		return config.DefaultConfig(), false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return config.Merge(config.DefaultConfig(), conf), true, nil
+}
+
+// flagsFromEnv sets every flag the user did not pass from its
+// LARAVELBOOT_<FLAG> environment variable, e.g. --project-name from
+// LARAVELBOOT_PROJECT_NAME.
+func flagsFromEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed {
+			return
+		}
+		name := "LARAVELBOOT_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(name); ok {
+			if setErr := flags.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("%s: %v", name, setErr)
+			}
+		}
+	})
+	return err
+}
@@ -1,152 +1,228 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"laravelboot/internal/interactive"
 	"laravelboot/internal/laravel"
+	"laravelboot/internal/ui"
 	"laravelboot/internal/utils"
 	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
 )
 
 const VERSION = "v1.0.5"
 
-func main() {
-	var dryRun bool
-	var args []string
+// globalOptions holds the flags shared by every command.
+type globalOptions struct {
+	dryRun  bool
+	path    string
+	config  string
+	yes     bool
+	quiet   bool
+	verbose bool
+	debug   bool
+	output  string
+	json    bool
 
-	for _, arg := range os.Args[1:] {
-		if arg == "--dry-run" {
-			dryRun = true
-		} else {
-			args = append(args, arg)
-		}
+	failFast        bool
+	continueOnError bool
+	report          string
+}
+
+// projectDir is the directory the command works in; the root command has
+// already changed into --path.
+func (g *globalOptions) projectDir() (string, error) {
+	return os.Getwd()
+}
+
+// configFile is --config, or .laravelboot.yaml in the project directory.
+func (g *globalOptions) configFile() string {
+	if g.config != "" {
+		return g.config
 	}
+	return ".laravelboot.yaml"
+}
 
-	if len(args) < 1 {
-		printUsage()
-		os.Exit(1)
+// configDir is where relative plugin paths in the config are resolved.
+func (g *globalOptions) configDir() string {
+	dir, err := filepath.Abs(filepath.Dir(g.configFile()))
+	if err != nil {
+		return "."
 	}
+	return dir
+}
 
-	command := args[0]
-	target := ""
-	if len(args) > 1 {
-		target = args[1]
+// policy returns the failure policy for a run of command: fail-fast or
+// best-effort as the flags say, else the command's own default.
+func (g *globalOptions) policy(command string, failFast bool) laravel.Policy {
+	switch {
+	case g.failFast:
+		failFast = true
+	case g.continueOnError:
+		failFast = false
 	}
+	return laravel.Policy{FailFast: failFast, Report: laravel.NewReport(command)}
+}
 
-	switch command {
-	case "version":
-		fmt.Printf("LaravelBoot %s\n", VERSION)
+// finishReport prints the summary of a run and saves the report to
+// --report, or to .laravelboot/report.json in the project. Dry runs and
+// projects that were never created save nothing unless --report is given.
+func (g *globalOptions) finishReport(report *laravel.Report, projectPath string) {
+	if len(report.Steps()) == 0 {
+		return
+	}
+	report.Print()
 
-	case "update":
-		if err := utils.SelfUpdate(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
+	path := g.report
+	if path == "" {
+		if g.dryRun {
+			return
+		}
+		if _, err := os.Stat(projectPath); err != nil {
+			return
 		}
+		path = laravel.ReportPath(projectPath)
+	}
+	if err := report.Save(path); err != nil {
+		ui.Warnf("⚠️ Warning: could not save the report: %v\n", err)
+		return
+	}
+	ui.Printf("📝 Report saved to %s\n", path)
+}
 
-	case "init":
-		conf, err := interactive.RunInit()
-		if err != nil {
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		if ui.JSON() {
+			ui.Error(err)
+		} else {
 			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
 		}
-		if err := conf.Save(".laravelboot.yaml"); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error saving config: %v\n", err)
-			os.Exit(1)
+		if errors.Is(err, laravel.ErrInterrupted) {
+			os.Exit(130)
 		}
-		fmt.Println("✨ Configuration saved to .laravelboot.yaml")
+		os.Exit(1)
+	}
+}
 
-	case "new":
-		if !dryRun {
-			go utils.CheckForUpdate(VERSION)
-		}
-		if target == "" {
-			printUsage()
-			os.Exit(1)
-		}
-		appName := target
-		preset := ""
-
-		// Check for preset flags or positional
-		for _, arg := range os.Args[1:] {
-			if arg == "--all" {
-				preset = "all"
-			} else if arg == "--enterprise" {
-				preset = "enterprise"
-			}
-		}
+// configureOutput applies --quiet, --verbose, --debug and --output.
+func (g *globalOptions) configureOutput(cmd *cobra.Command) error {
+	format := g.output
+	if g.json {
+		format = "json"
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("--output must be text or json, not %q", format)
+	}
 
-		// Fallback to third arg if defined (e.g. laravelboot new myapp saas)
-		if len(args) > 2 && preset == "" {
-			preset = args[2]
-		}
+	level := ui.LevelNormal
+	switch {
+	case g.debug:
+		level = ui.LevelDebug
+	case g.verbose:
+		level = ui.LevelVerbose
+	case g.quiet:
+		level = ui.LevelQuiet
+	}
+	ui.Configure(level, format == "json")
+	return nil
+}
 
-		creator := laravel.NewCreator(appName, preset, dryRun)
-		if err := creator.Create(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
-		}
+func newRootCmd() *cobra.Command {
+	opts := &globalOptions{}
 
-	case "add":
-		if !dryRun {
-			go utils.CheckForUpdate(VERSION)
-		}
-		if target == "" {
-			printUsage()
-			os.Exit(1)
-		}
-		cwd, _ := os.Getwd()
-		switch target {
-		case "auth":
-			manager := laravel.NewAuthManager(cwd, dryRun)
-			if err := manager.AddAuth(); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
-			}
-		case "roles", "media", "activity", "activity-log", "search", "reporting", "traits", "middleware", "exports", "jobs", "rules", "responses", "notifications", "scheduler", "cache", "versioning", "softdeletes", "storage", "events", "logging", "platform":
-			manager := laravel.NewPlatformManager(cwd, dryRun)
-			if err := manager.RunStep(target); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
-			}
-		case "docker", "security", "rate-limit", "health", "infra":
-			manager := laravel.NewInfraManager(cwd, dryRun)
-			if err := manager.RunStep(target); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
+	root := &cobra.Command{
+		Use:   "laravelboot",
+		Short: "Scaffold and evolve production-ready Laravel APIs",
+		Long: `LaravelBoot creates Laravel API projects from a config file or preset and
+adds platform, infrastructure and enterprise features to existing projects.`,
+		Version:       VERSION,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.configureOutput(cmd); err != nil {
+				return err
 			}
-		case "quality", "pro-arch", "docs-pro", "ci", "monitoring", "tenancy", "helpers", "enterprise":
-			manager := laravel.NewEnterpriseManager(cwd, dryRun)
-			if err := manager.RunStep(target); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
+			// Resolve --config and --report before changing into --path so
+			// a relative path means what the user typed.
+			for _, p := range []*string{&opts.config, &opts.report} {
+				if *p == "" {
+					continue
+				}
+				abs, err := filepath.Abs(*p)
+				if err != nil {
+					return err
+				}
+				*p = abs
 			}
-		case "all":
-			manager := laravel.NewFullStackManager(cwd, dryRun)
-			if err := manager.AddAll(); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-				os.Exit(1)
+			if opts.path != "" {
+				if err := os.Chdir(opts.path); err != nil {
+					return fmt.Errorf("--path: %v", err)
+				}
 			}
-		default:
-			printUsage()
-			os.Exit(1)
-		}
-	default:
-		printUsage()
-		os.Exit(1)
+			return nil
+		},
+	}
+	root.SetVersionTemplate("LaravelBoot {{.Version}}\n")
+
+	flags := root.PersistentFlags()
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "print what would happen without changing anything")
+	flags.StringVar(&opts.path, "path", "", "run in this directory instead of the current one")
+	flags.StringVar(&opts.config, "config", "", "config file (default: .laravelboot.yaml in --path)")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "answer yes to confirmation prompts")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "only print warnings and errors")
+	flags.BoolVar(&opts.verbose, "verbose", false, "also print finished steps, commands and files")
+	flags.BoolVar(&opts.debug, "debug", false, "also stream composer and artisan output live")
+	flags.StringVar(&opts.output, "output", "text", "output format: text or json (newline-delimited events)")
+	flags.BoolVar(&opts.json, "json", false, "shorthand for --output=json")
+	flags.BoolVar(&opts.failFast, "fail-fast", false, "stop at the first failed step (default for add and apply)")
+	flags.BoolVar(&opts.continueOnError, "continue-on-error", false, "run the remaining steps after a failure (default for new)")
+	flags.StringVar(&opts.report, "report", "", "save the run report here (default: .laravelboot/report.json in the project)")
+	root.MarkFlagsMutuallyExclusive("quiet", "verbose", "debug")
+	root.MarkFlagsMutuallyExclusive("fail-fast", "continue-on-error")
+	root.RegisterFlagCompletionFunc("output", fixedCompletion([]string{"text", "json"}))
+
+	root.AddGroup(
+		&cobra.Group{ID: "project", Title: "Project Commands:"},
+		&cobra.Group{ID: "generate", Title: "Generators:"},
+		&cobra.Group{ID: "manage", Title: "Presets & Plugins:"},
+	)
+	for _, cmd := range []*cobra.Command{newInitCmd(opts), newNewCmd(opts), newResumeCmd(opts), newAddCmd(opts), newApplyCmd(opts)} {
+		cmd.GroupID = "project"
+		root.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{newExportCmd(opts), newSDKCmd(opts)} {
+		cmd.GroupID = "generate"
+		root.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{newPresetCmd(opts), newPluginCmd(opts)} {
+		cmd.GroupID = "manage"
+		root.AddCommand(cmd)
+	}
+	root.AddCommand(newDoctorCmd(opts), newVersionCmd(), newUpdateCmd(), newManCmd(root))
+
+	return root
+}
+
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show the LaravelBoot version",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("LaravelBoot %s\n", VERSION)
+		},
 	}
 }
 
-func printUsage() {
-	fmt.Printf("LaravelBoot %s\n\n", VERSION)
-	fmt.Println("Usage:")
-	fmt.Println("  laravelboot init                    Initialize configuration")
-	fmt.Println("  laravelboot new <project-name>      Create new project")
-	fmt.Println("  laravelboot update                  Update CLI tool")
-	fmt.Println("  laravelboot version                 Show version")
-	fmt.Println("\nAdd Stacks:")
-	fmt.Println("  laravelboot add auth                Passport/Sanctum Auth")
-	fmt.Println("  laravelboot add platform            Roles, Media, Search, Activity")
-	fmt.Println("  laravelboot add infra               Docker, Health, Security")
-	fmt.Println("  laravelboot add enterprise          Pest, Scramle, CI, Monitoring")
-	fmt.Println("  laravelboot add all                 Install EVERYTHING")
+func newUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update",
+		Short: "Update LaravelBoot to the latest release",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return utils.SelfUpdate()
+		},
+	}
 }
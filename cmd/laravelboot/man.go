@@ -0,0 +1,30 @@
+package main
+
+import (
+	"laravelboot/internal/ui"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+func newManCmd(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:    "man <dir>",
+		Short:  "Generate man pages for every command",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(args[0], 0755); err != nil {
+				return err
+			}
+			header := &doc.GenManHeader{Title: "LARAVELBOOT", Section: "1", Source: "LaravelBoot " + VERSION}
+			root.DisableAutoGenTag = true
+			if err := doc.GenManTree(root, header, args[0]); err != nil {
+				return err
+			}
+			ui.Printf("📖 Man pages written to %s\n", args[0])
+			return nil
+		},
+	}
+}
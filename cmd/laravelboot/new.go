@@ -0,0 +1,133 @@
+package main
+
+import (
+	"laravelboot/internal/config"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/presets"
+	"laravelboot/internal/utils"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func newNewCmd(opts *globalOptions) *cobra.Command {
+	var (
+		preset     string
+		all        bool
+		enterprise bool
+		resume     bool
+		skipChecks bool
+		overrides  config.Config
+	)
+
+	cmd := &cobra.Command{
+		Use:   "new <project-name> [preset]",
+		Short: "Create a new Laravel API project",
+		Long: `Create a new Laravel API project.
+
+The config is built from the preset (or the defaults when there is neither a
+preset nor a config file), then the config file, then the flags below.
+
+Progress is checkpointed after every step. If a run is interrupted or a
+step fails, --resume continues from that step with the original config.`,
+		Example: `  laravelboot new shop
+  laravelboot new shop --resume
+  laravelboot new shop --preset saas --database postgres
+  laravelboot new shop --preset git+https://github.com/acme/presets.git//api.yaml#v1.2.0`,
+		Args: cobra.RangeArgs(1, 2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return presetNames(), cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.dryRun {
+				go utils.CheckForUpdate(VERSION)
+			}
+			if len(args) > 1 && preset == "" {
+				preset = args[1]
+			}
+			if all {
+				preset = "all"
+			} else if enterprise {
+				preset = "enterprise"
+			}
+
+			// Resuming a run that stopped before the project existed is
+			// just a new run.
+			if resume {
+				if _, err := os.Stat(args[0]); err == nil {
+					creator, err := laravel.ResumeCreator(args[0], opts.dryRun)
+					if err != nil {
+						return err
+					}
+					creator.SkipChecks = skipChecks
+					return runCreator(opts, creator)
+				}
+			}
+
+			creator, err := laravel.NewCreator(args[0], preset, opts.configFile(), &overrides, opts.dryRun)
+			if err != nil {
+				return err
+			}
+			creator.SkipChecks = skipChecks
+			return runCreator(opts, creator)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&preset, "preset", "", "preset name, YAML file or git+<repo>//<file>#<ref>")
+	flags.StringVar(&overrides.Database, "database", "", "database: mysql, postgres, sqlite or mongo")
+	flags.StringVar(&overrides.Auth, "auth", "", "auth driver: sanctum or passport")
+	flags.StringVar(&overrides.Architecture, "architecture", "", "architecture: domain-based or standard")
+	flags.StringSliceVar(&overrides.Features, "features", nil, "platform features (replaces the config list)")
+	flags.StringSliceVar(&overrides.Infra, "infra", nil, "infra features (replaces the config list)")
+	flags.StringVar(&overrides.Options.Docker.PHPVersion, "php", "", "PHP version for the Docker images: 8.2, 8.3 or 8.4")
+	flags.StringVar(&overrides.Options.Responses.Format, "response-format", "", "API response envelope: custom, jsonapi or problem")
+	flags.StringVar(&overrides.Options.Pagination.Strategy, "pagination", "", "Default pagination strategy: offset, simple or cursor")
+	flags.StringVar(&overrides.Options.Messaging.Driver, "messaging-driver", "", "broker for messaging: rabbitmq, kafka or redis")
+	flags.StringSliceVar(&overrides.Options.WebhookReceiver.Sources, "webhook-sources", nil, "webhook-receiver sources, one route each")
+	flags.StringSliceVar(&overrides.Options.FeatureFlags.Flags, "feature-flags", nil, "feature flag names, one class each")
+	flags.StringVar(&overrides.Options.Idempotency.Store, "idempotency-store", "", "cache store for idempotency: redis, database, memcached, dynamodb, file or array")
+	flags.BoolVar(&resume, "resume", false, "continue an interrupted or failed run of this project")
+	flags.BoolVar(&skipChecks, "skip-checks", false, "do not run the environment doctor first")
+	flags.BoolVar(&all, "all", false, "shorthand for --preset all")
+	flags.BoolVar(&enterprise, "enterprise", false, "shorthand for --preset enterprise")
+	flags.MarkHidden("all")
+	flags.MarkHidden("enterprise")
+
+	cmd.RegisterFlagCompletionFunc("preset", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return presetNames(), cobra.ShellCompDirectiveDefault
+	})
+	cmd.RegisterFlagCompletionFunc("database", fixedCompletion(config.Databases))
+	cmd.RegisterFlagCompletionFunc("auth", fixedCompletion(config.AuthDrivers))
+	cmd.RegisterFlagCompletionFunc("architecture", fixedCompletion(config.Architectures))
+	cmd.RegisterFlagCompletionFunc("features", fixedCompletion(config.PlatformFeatures))
+	cmd.RegisterFlagCompletionFunc("infra", fixedCompletion(config.InfraFeatures))
+	cmd.RegisterFlagCompletionFunc("php", fixedCompletion(config.PHPVersions))
+	cmd.RegisterFlagCompletionFunc("response-format", fixedCompletion(config.ResponseFormats))
+	cmd.RegisterFlagCompletionFunc("pagination", fixedCompletion(config.PaginationStrategies))
+	cmd.RegisterFlagCompletionFunc("messaging-driver", fixedCompletion(config.MessagingDrivers))
+	cmd.RegisterFlagCompletionFunc("idempotency-store", fixedCompletion(config.IdempotencyStores))
+
+	return cmd
+}
+
+func presetNames() []string {
+	list, err := presets.List()
+	if err != nil {
+		return presets.Builtin
+	}
+	var names []string
+	for _, p := range list {
+		names = append(names, p.Name+"\t"+p.Description)
+	}
+	return names
+}
+
+func fixedCompletion(values []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
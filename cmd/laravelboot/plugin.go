@@ -0,0 +1,142 @@
+package main
+
+import (
+	"laravelboot/internal/config"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/ui"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newPluginCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage external plugins",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List installed plugins and those the config enables",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installed, err := plugins.Installed()
+			if err != nil {
+				return err
+			}
+			if len(installed) == 0 && !ui.JSON() {
+				ui.Printf("No plugins installed in %s\n", plugins.UserDir())
+			}
+			conf, _ := config.LoadConfig(opts.configFile())
+			for _, path := range installed {
+				name := plugins.PluginName(path)
+				m := plugins.NewExternalPlugin(path, opts.dryRun).Describe()
+				if ui.JSON() {
+					emitPlugin(name, path, "installed", enabled(conf, name), m)
+					continue
+				}
+				ui.Printf("🔌 %-20s %s\n", name, path)
+				if !enabled(conf, name) {
+					ui.Printf("   not enabled: add it to plugins in %s\n", opts.configFile())
+				}
+				printManifest(m)
+			}
+			if conf != nil {
+				for _, entry := range conf.Plugins {
+					path, err := plugins.Resolve(entry, opts.configDir())
+					if err != nil {
+						ui.Warnf("⚠️ %-20s %v\n", entry, err)
+						continue
+					}
+					m := plugins.NewExternalPlugin(path, opts.dryRun).Describe()
+					if ui.JSON() {
+						emitPlugin(plugins.PluginName(path), path, opts.configFile(), true, m)
+						continue
+					}
+					ui.Printf("📄 %-20s %s (from %s)\n", plugins.PluginName(path), path, opts.configFile())
+					printManifest(m)
+				}
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "install <path|url>",
+		Short:   "Install a plugin from a local file or an HTTP(S) URL",
+		Example: "  laravelboot plugin install ./laravelboot-billing",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dest, err := plugins.InstallPlugin(args[0])
+			if err != nil {
+				return err
+			}
+			name := plugins.PluginName(dest)
+			ui.Printf("✅ Installed plugin %s to %s\n", name, dest)
+			ui.Printf("   Enable it per project by adding %s to plugins in .laravelboot.yaml\n", name)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed plugin",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			installed, _ := plugins.Installed()
+			var names []string
+			for _, path := range installed {
+				names = append(names, plugins.PluginName(path))
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := plugins.RemovePlugin(args[0]); err != nil {
+				return err
+			}
+			ui.Printf("🗑️ Removed plugin %s\n", args[0])
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+// enabled reports whether the config names the plugin in its plugins list.
+func enabled(conf *config.Config, name string) bool {
+	if conf == nil {
+		return false
+	}
+	for _, entry := range conf.Plugins {
+		if entry == name {
+			return true
+		}
+	}
+	return false
+}
+
+// emitPlugin reports a plugin as a JSON event; from is "installed" or the
+// config file that names it.
+func emitPlugin(name, path, from string, enabled bool, m *plugins.Manifest) {
+	var features []string
+	for _, f := range m.Features {
+		features = append(features, f.Name)
+	}
+	ui.Emit("plugin", map[string]any{"name": name, "path": path, "from": from, "enabled": enabled,
+		"hooks": m.Hooks, "features": features, "capabilities": m.Capabilities})
+}
+
+func printManifest(m *plugins.Manifest) {
+	if len(m.Hooks) > 0 {
+		ui.Printf("   hooks:    %s\n", strings.Join(m.Hooks, ", "))
+	}
+	for _, f := range m.Features {
+		ui.Printf("   feature:  %-18s %s\n", f.Name, f.Description)
+	}
+	if len(m.Capabilities) > 0 {
+		ui.Printf("   supports: %s\n", strings.Join(m.Capabilities, ", "))
+	}
+	if len(m.Config) > 0 {
+		ui.Printf("   config:   plugin_config.<name> accepts %d option(s)\n", len(m.Config))
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/presets"
+	"laravelboot/internal/ui"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func newPresetCmd(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preset",
+		Short: "List, inspect and validate presets",
+		Long: `Presets are built in (saas, fintech, enterprise, all), YAML files in
+~/.laravelboot/presets, local YAML files, or files in a git repository pinned
+to a ref: git+https://host/repo.git//path/preset.yaml#v1.0.0`,
+	}
+
+	completePreset := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return presetNames(), cobra.ShellCompDirectiveDefault
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List built-in and custom presets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := presets.List()
+			if err != nil {
+				return err
+			}
+			for _, p := range list {
+				if ui.JSON() {
+					ui.Emit("preset", map[string]any{"name": p.Name, "description": p.Description, "source": p.Source})
+					continue
+				}
+				ui.Printf("🎯 %-14s %s\n", p.Name, p.Description)
+				if p.Source != "built-in" {
+					ui.Printf("   %s\n", p.Source)
+				}
+			}
+			if !ui.JSON() {
+				ui.Printf("\nCustom presets: %s/<name>.yaml\n", presets.UserDir())
+			}
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "show <preset>",
+		Short:             "Print a preset with inheritance resolved",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePreset,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := presets.Load(args[0])
+			if err != nil {
+				return err
+			}
+			data, err := yaml.Marshal(p.Config)
+			if err != nil {
+				return err
+			}
+			if ui.JSON() {
+				ui.Emit("preset", map[string]any{"name": p.Name, "description": p.Description, "source": p.Source, "extends": p.Extends, "yaml": string(data)})
+				return nil
+			}
+			ui.Printf("# %s (%s)\n", p.Name, p.Source)
+			if p.Description != "" {
+				ui.Printf("# %s\n", p.Description)
+			}
+			if p.Extends != "" {
+				ui.Printf("# extends %s (shown resolved)\n", p.Extends)
+			}
+			ui.Printf("%s", data)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "validate <preset>",
+		Short:             "Check a preset for unknown keys and values",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePreset,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := presets.Load(args[0])
+			if err != nil {
+				return err
+			}
+			pluginMgr, err := plugins.LoadPluginManager(p.Config, opts.configDir(), opts.dryRun)
+			if err != nil {
+				return err
+			}
+			if err := p.Config.Validate(pluginMgr.FeatureNames()...); err != nil {
+				return err
+			}
+			ui.Printf("✅ Preset %s is valid\n", p.Name)
+			return nil
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/ui"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+func newResumeCmd(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume [project-dir]",
+		Short: "Continue an interrupted or failed 'new' run",
+		Long: `Continue the 'new' run that created the project in project-dir (default: the
+current directory) from the step where it stopped, with the config it was
+started with. Same as 'laravelboot new <name> --resume'.`,
+		Example: `  laravelboot resume shop
+  cd shop && laravelboot resume`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			dir, err := filepath.Abs(dir)
+			if err != nil {
+				return err
+			}
+			if err := os.Chdir(filepath.Dir(dir)); err != nil {
+				return err
+			}
+
+			creator, err := laravel.ResumeCreator(filepath.Base(dir), opts.dryRun)
+			if err != nil {
+				return err
+			}
+			return runCreator(opts, creator)
+		},
+	}
+}
+
+// runCreator runs a "new" with the failure policy from the flags, stopping
+// between steps on Ctrl-C, and reports the result.
+func runCreator(opts *globalOptions, creator *laravel.Creator) error {
+	cwd, err := opts.projectDir()
+	if err != nil {
+		return err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	creator.Policy = opts.policy("new "+creator.Name, false)
+	creator.Policy.Context = ctx
+	defer opts.finishReport(creator.Policy.Report, filepath.Join(cwd, creator.Name))
+	return creator.Create()
+}
+
+// interruptContext is cancelled on the first Ctrl-C, which lets the steps
+// in progress finish and records them before the run stops. A second
+// Ctrl-C quits at once.
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-signals:
+			ui.Warnf("\n⚠️ Interrupted: finishing the steps in progress (press Ctrl-C again to quit now)\n")
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
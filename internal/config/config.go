@@ -1,19 +1,121 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
 
+// SchemaVersion is the newest config format this build understands. Files
+// without a version are treated as version 1.
+const SchemaVersion = 1
+
 type Config struct {
+	Version      int      `yaml:"version,omitempty"`
 	ProjectName  string   `yaml:"project_name"`
-	Database     string   `yaml:"database"`     // mysql, postgres, sqlite, mongo
-	Auth         string   `yaml:"auth"`         // sanctum, passport
-	Features     []string `yaml:"features"`     // roles, media, search, activity-log
-	Infra        []string `yaml:"infra"`        // docker, health, security, rate-limit
-	Enterprise   []string `yaml:"enterprise"`   // quality, pro-arch, docs-pro, ci, monitoring
-	Architecture string   `yaml:"architecture"` // domain-based, standard
+	Database     string   `yaml:"database"`          // mysql, postgres, sqlite, mongo
+	Auth         string   `yaml:"auth"`              // sanctum, passport
+	Features     []string `yaml:"features"`          // roles, media, search, activity-log
+	Infra        []string `yaml:"infra"`             // docker, health, security, rate-limit
+	Enterprise   []string `yaml:"enterprise"`        // quality, pro-arch, docs-pro, ci, monitoring
+	Architecture string   `yaml:"architecture"`      // domain-based, standard
+	Plugins      []string `yaml:"plugins,omitempty"` // names or paths of external plugins
+
+	// PluginConfig holds one section per plugin, validated against the
+	// schema the plugin declares.
+	PluginConfig map[string]map[string]interface{} `yaml:"plugin_config,omitempty"`
+
+	// Options tune individual features; unset values keep each feature's
+	// defaults.
+	Options Options `yaml:"options,omitempty"`
+
+	// file and node remember where the config was read from so validation
+	// errors can point at a line.
+	file string
+	node *yaml.Node
+}
+
+type Options struct {
+	RateLimit       RateLimitOptions       `yaml:"rate-limit,omitempty"`
+	Quality         QualityOptions         `yaml:"quality,omitempty"`
+	Docker          DockerOptions          `yaml:"docker,omitempty"`
+	Responses       ResponsesOptions       `yaml:"responses,omitempty"`
+	Pagination      PaginationOptions      `yaml:"pagination,omitempty"`
+	WebhookReceiver WebhookReceiverOptions `yaml:"webhook-receiver,omitempty"`
+	Messaging       MessagingOptions       `yaml:"messaging,omitempty"`
+	FeatureFlags    FeatureFlagsOptions    `yaml:"feature-flags,omitempty"`
+	Idempotency     IdempotencyOptions     `yaml:"idempotency,omitempty"`
+}
+
+type RateLimitOptions struct {
+	PerMinute int `yaml:"per_minute,omitempty"` // default 60
+}
+
+type QualityOptions struct {
+	PHPStanLevel *int `yaml:"phpstan_level,omitempty"` // 0-9, default 5
+}
+
+type DockerOptions struct {
+	PHPVersion string `yaml:"php_version,omitempty"` // default 8.3
+}
+
+type ResponsesOptions struct {
+	Format string `yaml:"format,omitempty"` // custom (default), jsonapi or problem
+}
+
+type PaginationOptions struct {
+	Strategy    string `yaml:"strategy,omitempty"`     // offset (default), simple or cursor
+	DefaultSize int    `yaml:"default_size,omitempty"` // default 15
+	MaxSize     int    `yaml:"max_size,omitempty"`     // default 100
+}
+
+type WebhookReceiverOptions struct {
+	// Sources each get a route; stripe and github use their providers'
+	// signatures, other names the HMAC scheme of the webhooks feature.
+	Sources []string `yaml:"sources,omitempty"` // default [stripe, github]
+}
+
+type MessagingOptions struct {
+	Driver string `yaml:"driver,omitempty"` // rabbitmq (default), kafka or redis
+}
+
+type FeatureFlagsOptions struct {
+	// Flags each get a definition class in app/Features.
+	Flags []string `yaml:"flags,omitempty"` // default [new-dashboard]
+}
+
+type IdempotencyOptions struct {
+	// Store is the cache store for stored responses and locks; it must
+	// support atomic locks.
+	Store string `yaml:"store,omitempty"` // default: the app's cache store
+}
+
+// Sizes returns the default and maximum page sizes, filling in the
+// defaults for unset values.
+func (p PaginationOptions) Sizes() (size, max int) {
+	size, max = p.DefaultSize, p.MaxSize
+	if max <= 0 {
+		max = 100
+	}
+	if size <= 0 {
+		size = min(15, max)
+	}
+	return size, max
+}
+
+// HomeDir is where LaravelBoot keeps user-level state such as installed
+// plugins. It honours LARAVELBOOT_HOME and defaults to ~/.laravelboot.
+func HomeDir() string {
+	if dir := os.Getenv("LARAVELBOOT_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".laravelboot"
+	}
+	return filepath.Join(home, ".laravelboot")
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -22,15 +124,44 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
-	var conf Config
-	if err := yaml.Unmarshal(data, &conf); err != nil {
-		return nil, err
+	return ParseConfig(data, path)
+}
+
+// ParseConfig decodes a config strictly: unknown keys, wrong types and
+// newer schema versions are errors that name the offending line.
+func ParseConfig(data []byte, filename string) (*Config, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
 	}
 
-	return &conf, nil
+	conf := &Config{file: filename}
+	if len(root.Content) == 0 {
+		return conf, nil
+	}
+	doc := root.Content[0]
+
+	if problems := checkKeys(doc, configType, ""); len(problems) > 0 {
+		return nil, conf.problemsError(problems)
+	}
+	if err := doc.Decode(conf); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	if conf.Version > SchemaVersion {
+		return nil, fmt.Errorf("%s: config version %d requires a newer LaravelBoot (this build supports version %d)", filename, conf.Version, SchemaVersion)
+	}
+	if conf.Version < 0 {
+		return nil, fmt.Errorf("%s: invalid config version %d", filename, conf.Version)
+	}
+
+	conf.node = doc
+	return conf, nil
 }
 
 func (c *Config) Save(path string) error {
+	if c.Version == 0 {
+		c.Version = SchemaVersion
+	}
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return err
@@ -41,6 +172,7 @@ func (c *Config) Save(path string) error {
 
 func DefaultConfig() *Config {
 	return &Config{
+		Version:      SchemaVersion,
 		ProjectName:  "myapp",
 		Database:     "mysql",
 		Auth:         "sanctum",
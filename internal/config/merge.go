@@ -0,0 +1,85 @@
+package config
+
+// Merge overlays over on base and returns a new config: scalars replace
+// when set, lists replace when present (an explicit [] clears them),
+// plugin sections merge by plugin name and options merge per key.
+func Merge(base, over *Config) *Config {
+	out := *base
+	if over.Version != 0 {
+		out.Version = over.Version
+	}
+	if over.ProjectName != "" {
+		out.ProjectName = over.ProjectName
+	}
+	if over.Database != "" {
+		out.Database = over.Database
+	}
+	if over.Auth != "" {
+		out.Auth = over.Auth
+	}
+	if over.Architecture != "" {
+		out.Architecture = over.Architecture
+	}
+	if over.Features != nil {
+		out.Features = over.Features
+	}
+	if over.Infra != nil {
+		out.Infra = over.Infra
+	}
+	if over.Enterprise != nil {
+		out.Enterprise = over.Enterprise
+	}
+	if over.Plugins != nil {
+		out.Plugins = over.Plugins
+	}
+	if over.PluginConfig != nil {
+		out.PluginConfig = map[string]map[string]interface{}{}
+		for name, section := range base.PluginConfig {
+			out.PluginConfig[name] = section
+		}
+		for name, section := range over.PluginConfig {
+			out.PluginConfig[name] = section
+		}
+	}
+
+	if over.Options.RateLimit.PerMinute != 0 {
+		out.Options.RateLimit.PerMinute = over.Options.RateLimit.PerMinute
+	}
+	if over.Options.Quality.PHPStanLevel != nil {
+		out.Options.Quality.PHPStanLevel = over.Options.Quality.PHPStanLevel
+	}
+	if over.Options.Docker.PHPVersion != "" {
+		out.Options.Docker.PHPVersion = over.Options.Docker.PHPVersion
+	}
+	if over.Options.Responses.Format != "" {
+		out.Options.Responses.Format = over.Options.Responses.Format
+	}
+	if over.Options.Pagination.Strategy != "" {
+		out.Options.Pagination.Strategy = over.Options.Pagination.Strategy
+	}
+	if over.Options.Pagination.DefaultSize != 0 {
+		out.Options.Pagination.DefaultSize = over.Options.Pagination.DefaultSize
+	}
+	if over.Options.Pagination.MaxSize != 0 {
+		out.Options.Pagination.MaxSize = over.Options.Pagination.MaxSize
+	}
+	if len(over.Options.WebhookReceiver.Sources) > 0 {
+		out.Options.WebhookReceiver.Sources = over.Options.WebhookReceiver.Sources
+	}
+	if over.Options.Messaging.Driver != "" {
+		out.Options.Messaging.Driver = over.Options.Messaging.Driver
+	}
+	if len(over.Options.FeatureFlags.Flags) > 0 {
+		out.Options.FeatureFlags.Flags = over.Options.FeatureFlags.Flags
+	}
+	if over.Options.Idempotency.Store != "" {
+		out.Options.Idempotency.Store = over.Options.Idempotency.Store
+	}
+
+	// Keep the source of the layer that was read from a file so errors
+	// still point at its lines.
+	if over.node != nil {
+		out.file, out.node = over.file, over.node
+	}
+	return &out
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Accepted values for each config field. Feature, infra and enterprise
+// lists may additionally name features registered by plugins.
+var (
+	Databases     = []string{"mysql", "postgres", "sqlite", "mongo"}
+	AuthDrivers   = []string{"sanctum", "passport"}
+	Architectures = []string{"domain-based", "standard"}
+	PHPVersions   = []string{"8.2", "8.3", "8.4"}
+
+	// ResponseFormats are the API envelopes: success/message/data,
+	// JSON:API documents, or that envelope with RFC 9457 problem details
+	// for errors.
+	ResponseFormats = []string{"custom", "jsonapi", "problem"}
+
+	// PaginationStrategies are page numbers with a total count, page
+	// numbers without one, and keyset pagination with opaque cursors.
+	PaginationStrategies = []string{"offset", "simple", "cursor"}
+
+	// MessagingDrivers are the brokers the messaging feature can talk to.
+	MessagingDrivers = []string{"rabbitmq", "kafka", "redis"}
+
+	// IdempotencyStores are the Laravel cache stores with atomic locks.
+	IdempotencyStores = []string{"redis", "database", "memcached", "dynamodb", "file", "array"}
+
+	PlatformFeatures = []string{
+		"roles", "media", "activity", "activity-log", "search", "reporting",
+		"traits", "middleware", "exports", "jobs", "rules", "responses",
+		"notifications", "scheduler", "cache", "versioning", "softdeletes",
+		"storage", "events", "logging", "idempotency", "webhooks",
+		"webhook-receiver", "outbox", "messaging", "realtime", "feature-flags", "audit",
+		"platform",
+	}
+	InfraFeatures      = []string{"docker", "security", "rate-limit", "health", "infra"}
+	EnterpriseFeatures = []string{"quality", "pro-arch", "docs-pro", "ci", "monitoring", "tenancy", "helpers", "enterprise"}
+)
+
+var configType = reflect.TypeOf(Config{})
+
+// namePattern keeps webhook source and feature flag names usable in
+// routes, env variable names and class names.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// problem is one validation failure. path uses dots and [i] indexes, e.g.
+// features[2] or options.docker.php_version.
+type problem struct {
+	path  string
+	value string
+	msg   string
+	line  int
+}
+
+// Validate reports every invalid value in the config. pluginFeatures are
+// extra names accepted in the feature lists.
+func (c *Config) Validate(pluginFeatures ...string) error {
+	var problems []problem
+
+	check := func(field, value string, allowed []string) {
+		if value != "" && !contains(allowed, value) {
+			problems = append(problems, problem{
+				path:  field,
+				value: value,
+				msg:   fmt.Sprintf("%q is not one of %s%s", value, strings.Join(allowed, ", "), suggest(value, allowed)),
+			})
+		}
+	}
+	check("database", c.Database, Databases)
+	check("auth", c.Auth, AuthDrivers)
+	check("architecture", c.Architecture, Architectures)
+
+	checkList := func(field string, values, allowed []string) {
+		known := append(append([]string{}, allowed...), pluginFeatures...)
+		for i, v := range values {
+			if !contains(known, v) {
+				problems = append(problems, problem{
+					path:  fmt.Sprintf("%s[%d]", field, i),
+					value: v,
+					msg:   fmt.Sprintf("unknown feature %q%s", v, suggest(v, known)),
+				})
+			}
+		}
+	}
+	checkList("features", c.Features, PlatformFeatures)
+	checkList("infra", c.Infra, InfraFeatures)
+	checkList("enterprise", c.Enterprise, EnterpriseFeatures)
+
+	if n := c.Options.RateLimit.PerMinute; n < 0 {
+		problems = append(problems, problem{path: "options.rate-limit.per_minute", value: strconv.Itoa(n), msg: "must be a positive number of requests"})
+	}
+	if lvl := c.Options.Quality.PHPStanLevel; lvl != nil && (*lvl < 0 || *lvl > 9) {
+		problems = append(problems, problem{path: "options.quality.phpstan_level", value: strconv.Itoa(*lvl), msg: "must be between 0 and 9"})
+	}
+	check("options.docker.php_version", c.Options.Docker.PHPVersion, PHPVersions)
+	check("options.responses.format", c.Options.Responses.Format, ResponseFormats)
+	check("options.pagination.strategy", c.Options.Pagination.Strategy, PaginationStrategies)
+	check("options.messaging.driver", c.Options.Messaging.Driver, MessagingDrivers)
+	check("options.idempotency.store", c.Options.Idempotency.Store, IdempotencyStores)
+	if n := c.Options.Pagination.DefaultSize; n < 0 {
+		problems = append(problems, problem{path: "options.pagination.default_size", value: strconv.Itoa(n), msg: "must be a positive page size"})
+	}
+	if n := c.Options.Pagination.MaxSize; n < 0 {
+		problems = append(problems, problem{path: "options.pagination.max_size", value: strconv.Itoa(n), msg: "must be a positive page size"})
+	}
+	if size, max := c.Options.Pagination.Sizes(); size > max {
+		problems = append(problems, problem{path: "options.pagination.default_size", value: strconv.Itoa(size), msg: fmt.Sprintf("must not exceed max_size (%d)", max)})
+	}
+
+	names := func(path string, list []string) {
+		seen := map[string]bool{}
+		for i, name := range list {
+			path := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case !namePattern.MatchString(name):
+				problems = append(problems, problem{path: path, value: name, msg: "must be lowercase letters, digits and dashes, starting with a letter"})
+			case seen[name]:
+				problems = append(problems, problem{path: path, value: name, msg: "is listed twice"})
+			}
+			seen[name] = true
+		}
+	}
+	names("options.webhook-receiver.sources", c.Options.WebhookReceiver.Sources)
+	names("options.feature-flags.flags", c.Options.FeatureFlags.Flags)
+
+	if len(problems) > 0 {
+		return c.problemsError(problems)
+	}
+	return nil
+}
+
+func (c *Config) problemsError(problems []problem) error {
+	for i, p := range problems {
+		if p.line == 0 {
+			if n := lookup(c.node, p.path); n != nil && (p.value == "" || n.Value == p.value) {
+				problems[i].line = n.Line
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].line, problems[j].line
+		return a != 0 && (b == 0 || a < b)
+	})
+
+	var lines []string
+	for _, p := range problems {
+		prefix := p.path + ": "
+		if p.line > 0 {
+			prefix = fmt.Sprintf("line %d: %s", p.line, prefix)
+		}
+		lines = append(lines, prefix+p.msg)
+	}
+
+	head := "invalid configuration"
+	if c.file != "" {
+		head = c.file + ": " + head
+	}
+	return fmt.Errorf("%s:\n  - %s", head, strings.Join(lines, "\n  - "))
+}
+
+// checkKeys walks a mapping node against the struct it decodes into and
+// reports keys with no matching field.
+func checkKeys(node *yaml.Node, t reflect.Type, path string) []problem {
+	if node.Kind != yaml.MappingNode || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := map[string]reflect.Type{}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+		names = append(names, name)
+	}
+
+	var problems []problem
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		ft, ok := fields[key.Value]
+		if !ok {
+			problems = append(problems, problem{
+				path: path + key.Value,
+				msg:  "unknown key" + suggest(key.Value, names),
+				line: key.Line,
+			})
+			continue
+		}
+		if ft.Kind() == reflect.Struct {
+			problems = append(problems, checkKeys(value, ft, path+key.Value+".")...)
+		}
+	}
+	return problems
+}
+
+// lookup finds the node at a path such as features[2] or options.docker.php_version.
+func lookup(node *yaml.Node, path string) *yaml.Node {
+	for _, part := range strings.Split(path, ".") {
+		if node == nil {
+			return nil
+		}
+		index := -1
+		if open := strings.Index(part, "["); open >= 0 {
+			index, _ = strconv.Atoi(strings.TrimSuffix(part[open+1:], "]"))
+			part = part[:open]
+		}
+
+		var next *yaml.Node
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == part {
+					next = node.Content[i+1]
+				}
+			}
+		}
+		if next != nil && index >= 0 {
+			if next.Kind != yaml.SequenceNode || index >= len(next.Content) {
+				return nil
+			}
+			next = next.Content[index]
+		}
+		node = next
+	}
+	return node
+}
+
+// suggest returns a "did you mean" hint for the closest allowed value.
+func suggest(value string, allowed []string) string {
+	if len(value) < 4 {
+		return ""
+	}
+	best, bestDist := "", len(value)/2+1
+	for _, a := range allowed {
+		if d := distance(value, a); d < bestDist {
+			best, bestDist = a, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// distance is the Levenshtein edit distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
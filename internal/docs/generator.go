@@ -3,7 +3,7 @@ package docs
 import (
 	"fmt"
 	"laravelboot/internal/config"
-	"os"
+	"laravelboot/internal/ui"
 	"path/filepath"
 )
 
@@ -33,8 +33,8 @@ This project was generated using LaravelBoot.
 
 	path := filepath.Join(projectPath, "README-API.md")
 	if dryRun {
-		fmt.Printf("[Dry Run] Would generate documentation: %s\n", path)
+		ui.Printf("[Dry Run] Would generate documentation: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"laravelboot/internal/project"
+	"path/filepath"
+	"strings"
+)
+
+func (e *CollectionExporter) bruno() (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	manifest, err := json.MarshalIndent(map[string]interface{}{
+		"version": "1",
+		"name":    e.name,
+		"type":    "collection",
+		"ignore":  []string{"node_modules", ".git"},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files["bruno.json"] = manifest
+
+	files[filepath.Join("environments", "Local.bru")] = []byte(fmt.Sprintf(`vars {
+  baseUrl: %s
+  token:
+}
+`, e.BaseURL))
+
+	order, grouped := e.groups()
+	seq := 0
+	for _, g := range order {
+		for _, r := range grouped[g] {
+			seq++
+			name := strings.NewReplacer("@", "-", " ", "-", "/", "-").Replace(r.Name())
+			files[filepath.Join(g, name+".bru")] = []byte(e.brunoRequest(r, seq))
+		}
+	}
+
+	return files, nil
+}
+
+func (e *CollectionExporter) brunoRequest(r project.Route, seq int) string {
+	var b strings.Builder
+	method := strings.ToLower(r.Method)
+
+	fmt.Fprintf(&b, "meta {\n  name: %s\n  type: http\n  seq: %d\n}\n\n", r.Name(), seq)
+
+	fields := bodyFields(r)
+	bodyMode := "none"
+	if hasBody(r) && len(fields) > 0 {
+		bodyMode = "json"
+		if r.HasFileUpload() {
+			bodyMode = "multipartForm"
+		}
+	}
+	authMode := "none"
+	if r.Authenticated() {
+		authMode = "bearer"
+	}
+
+	fmt.Fprintf(&b, "%s {\n  url: {{baseUrl}}%s\n  body: %s\n  auth: %s\n}\n\n", method, pathWithVariables(r.URI), bodyMode, authMode)
+	b.WriteString("headers {\n  Accept: application/json\n}\n\n")
+
+	if r.Authenticated() {
+		b.WriteString("auth:bearer {\n  token: {{token}}\n}\n\n")
+	}
+
+	switch bodyMode {
+	case "json":
+		payload := map[string]string{}
+		for _, f := range fields {
+			payload[f.Name] = sampleValue(f)
+		}
+		raw, _ := json.MarshalIndent(payload, "  ", "  ")
+		fmt.Fprintf(&b, "body:json {\n  %s\n}\n\n", raw)
+	case "multipartForm":
+		b.WriteString("body:multipart-form {\n")
+		for _, f := range fields {
+			if isFileField(f) {
+				fmt.Fprintf(&b, "  %s: @file()\n", f.Name)
+			} else {
+				fmt.Fprintf(&b, "  %s: %s\n", f.Name, sampleValue(f))
+			}
+		}
+		b.WriteString("}\n\n")
+	}
+
+	if isLogin(r) {
+		b.WriteString("script:post-response {\n  if (res.body.data && res.body.data.token) {\n    bru.setEnvVar(\"token\", res.body.data.token);\n  }\n}\n")
+	}
+
+	return b.String()
+}
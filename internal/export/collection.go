@@ -0,0 +1,223 @@
+package export
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/project"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultBaseURL = "http://localhost:8000"
+	loginAction    = "login"
+)
+
+type CollectionExporter struct {
+	ProjectPath string
+	Format      string
+	Output      string
+	BaseURL     string
+	DryRun      bool
+
+	name   string
+	prefix string
+	routes []project.Route
+}
+
+func NewCollectionExporter(projectPath, format, output string, dryRun bool) *CollectionExporter {
+	return &CollectionExporter{
+		ProjectPath: projectPath,
+		Format:      format,
+		Output:      output,
+		BaseURL:     appURL(projectPath),
+		DryRun:      dryRun,
+	}
+}
+
+func (e *CollectionExporter) Export() error {
+	routes, err := project.DiscoverRoutes(e.ProjectPath)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no API routes found in routes/api.php")
+	}
+	project.SortRoutes(routes)
+
+	e.routes = routes
+	e.prefix = project.APIPrefix(e.ProjectPath)
+	e.name = projectName(e.ProjectPath)
+
+	var files map[string][]byte
+	switch e.Format {
+	case "postman":
+		files, err = e.postman()
+	case "insomnia":
+		files, err = e.insomnia()
+	case "bruno":
+		files, err = e.bruno()
+	default:
+		return fmt.Errorf("unknown collection format: %s (expected postman, insomnia or bruno)", e.Format)
+	}
+	if err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		path := filepath.Join(e.outputDir(), name)
+		if e.DryRun {
+			ui.Printf("[Dry Run] Would write %s\n", path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %v", filepath.Dir(path), err)
+		}
+		if err := ui.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+	}
+
+	ui.Printf("📮 Exported %d routes as a %s collection to %s\n", len(e.routes), e.Format, e.outputDir())
+	return nil
+}
+
+func (e *CollectionExporter) outputDir() string {
+	if e.Output != "" {
+		return e.Output
+	}
+	if e.Format == "bruno" {
+		return filepath.Join(e.ProjectPath, "collections", "bruno")
+	}
+	return filepath.Join(e.ProjectPath, "collections")
+}
+
+// groups folds routes into folders, keeping discovery order except that the
+// folder holding the login request comes first so runners obtain a token
+// before calling protected routes.
+func (e *CollectionExporter) groups() ([]string, map[string][]project.Route) {
+	var order []string
+	grouped := map[string][]project.Route{}
+	for _, r := range e.routes {
+		g := r.Group(e.prefix)
+		if _, ok := grouped[g]; !ok {
+			order = append(order, g)
+		}
+		if isLogin(r) {
+			grouped[g] = append([]project.Route{r}, grouped[g]...)
+			order = moveToFront(order, g)
+			continue
+		}
+		grouped[g] = append(grouped[g], r)
+	}
+	return order, grouped
+}
+
+func moveToFront(order []string, name string) []string {
+	out := []string{name}
+	for _, o := range order {
+		if o != name {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func isLogin(r project.Route) bool {
+	return r.Method == "POST" && r.Action == loginAction
+}
+
+// sampleValue produces a placeholder body value from validation rules.
+func sampleValue(f project.Field) string {
+	switch {
+	case strings.Contains(f.Name, "email"):
+		return "user@example.com"
+	case strings.Contains(f.Name, "password"):
+		return "password"
+	case f.Name == "device_name":
+		return "laravelboot"
+	case strings.Contains(f.Rules, "integer") || strings.Contains(f.Rules, "numeric"):
+		return "1"
+	case strings.Contains(f.Rules, "boolean"):
+		return "true"
+	}
+	return ""
+}
+
+// bodyFields drops nested wildcard keys like tags.* which only describe
+// array items. Arrays whose items are validated as files (files.*) become a
+// files[] file field, which clients repeat once per upload.
+func bodyFields(r project.Route) []project.Field {
+	fileArrays := map[string]project.Field{}
+	for _, f := range r.Fields {
+		if name, ok := strings.CutSuffix(f.Name, ".*"); ok && !strings.Contains(name, "*") && isFileField(f) {
+			fileArrays[name] = project.Field{Name: name + "[]", Rules: f.Rules}
+		}
+	}
+
+	var fields []project.Field
+	for _, f := range r.Fields {
+		if item, ok := fileArrays[f.Name]; ok {
+			fields = append(fields, item)
+			delete(fileArrays, f.Name)
+			continue
+		}
+		if name, ok := strings.CutSuffix(f.Name, ".*"); ok {
+			if item, ok := fileArrays[name]; ok {
+				fields = append(fields, item)
+				delete(fileArrays, name)
+			}
+			continue
+		}
+		if strings.Contains(f.Name, "*") {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func isFileField(f project.Field) bool {
+	return strings.Contains(f.Rules, "file") || strings.Contains(f.Rules, "image")
+}
+
+func hasBody(r project.Route) bool {
+	return r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" || r.Method == "DELETE"
+}
+
+// pathWithVariables rewrites Laravel {param} segments to the {{param}}
+// placeholder style shared by all three clients.
+func pathWithVariables(uri string) string {
+	uri = strings.ReplaceAll(uri, "{", "{{")
+	return strings.ReplaceAll(uri, "}", "}}")
+}
+
+// appURL is the project's APP_URL from .env, or DefaultBaseURL when it is
+// not set.
+func appURL(projectPath string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, ".env"))
+	if err != nil {
+		return DefaultBaseURL
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "APP_URL=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if value == "" || strings.Contains(value, "${") {
+			break
+		}
+		return strings.TrimSuffix(value, "/")
+	}
+	return DefaultBaseURL
+}
+
+func projectName(projectPath string) string {
+	if conf, err := config.LoadConfig(filepath.Join(projectPath, ".laravelboot.yaml")); err == nil && conf.ProjectName != "" {
+		return conf.ProjectName
+	}
+	return filepath.Base(projectPath)
+}
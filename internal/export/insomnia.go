@@ -0,0 +1,131 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"laravelboot/internal/project"
+	"strings"
+)
+
+type insomniaResource map[string]interface{}
+
+type insomniaExport struct {
+	Type      string             `json:"_type"`
+	Format    int                `json:"__export_format"`
+	Source    string             `json:"__export_source"`
+	Resources []insomniaResource `json:"resources"`
+}
+
+func (e *CollectionExporter) insomnia() (map[string][]byte, error) {
+	workspaceID := "wrk_laravelboot"
+	loginID := ""
+
+	resources := []insomniaResource{{
+		"_id":      workspaceID,
+		"_type":    "workspace",
+		"parentId": nil,
+		"name":     e.name,
+		"scope":    "collection",
+	}}
+
+	order, grouped := e.groups()
+	var requests []insomniaResource
+	for i, g := range order {
+		folderID := fmt.Sprintf("fld_%d", i+1)
+		resources = append(resources, insomniaResource{
+			"_id":      folderID,
+			"_type":    "request_group",
+			"parentId": workspaceID,
+			"name":     g,
+		})
+		for j, r := range grouped[g] {
+			id := fmt.Sprintf("req_%d_%d", i+1, j+1)
+			if isLogin(r) {
+				loginID = id
+			}
+			requests = append(requests, e.insomniaRequest(id, folderID, r))
+		}
+	}
+
+	// Chain the token to the login response so it refreshes automatically.
+	token := ""
+	if loginID != "" {
+		path := base64.StdEncoding.EncodeToString([]byte("$.data.token"))
+		token = fmt.Sprintf("{%% response 'body', '%s', 'b64::%s::46b', 'when-expired', 3600 %%}", loginID, path)
+	}
+
+	resources = append(resources, insomniaResource{
+		"_id":      "env_base",
+		"_type":    "environment",
+		"parentId": workspaceID,
+		"name":     "Base Environment",
+		"data": map[string]string{
+			"base_url": e.BaseURL,
+			"token":    token,
+		},
+	})
+	resources = append(resources, requests...)
+
+	data, err := json.MarshalIndent(insomniaExport{
+		Type:      "export",
+		Format:    4,
+		Source:    "laravelboot",
+		Resources: resources,
+	}, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{e.name + ".insomnia.json": data}, nil
+}
+
+func (e *CollectionExporter) insomniaRequest(id, parentID string, r project.Route) insomniaResource {
+	headers := []map[string]string{{"name": "Accept", "value": "application/json"}}
+	req := insomniaResource{
+		"_id":      id,
+		"_type":    "request",
+		"parentId": parentID,
+		"name":     r.Name(),
+		"method":   r.Method,
+		"url":      "{{ _.base_url }}" + insomniaPath(r.URI),
+		"body":     map[string]interface{}{},
+	}
+
+	if r.Authenticated() {
+		req["authentication"] = map[string]interface{}{
+			"type":  "bearer",
+			"token": "{{ _.token }}",
+		}
+	}
+
+	if fields := bodyFields(r); hasBody(r) && len(fields) > 0 {
+		if r.HasFileUpload() {
+			var params []map[string]string
+			for _, f := range fields {
+				p := map[string]string{"name": f.Name, "value": sampleValue(f)}
+				if isFileField(f) {
+					p = map[string]string{"name": f.Name, "type": "file", "fileName": ""}
+				}
+				params = append(params, p)
+			}
+			req["body"] = map[string]interface{}{"mimeType": "multipart/form-data", "params": params}
+		} else {
+			payload := map[string]string{}
+			for _, f := range fields {
+				payload[f.Name] = sampleValue(f)
+			}
+			raw, _ := json.MarshalIndent(payload, "", "    ")
+			req["body"] = map[string]interface{}{"mimeType": "application/json", "text": string(raw)}
+			headers = append(headers, map[string]string{"name": "Content-Type", "value": "application/json"})
+		}
+	}
+
+	req["headers"] = headers
+	return req
+}
+
+// insomniaPath turns {param} segments into environment lookups.
+func insomniaPath(uri string) string {
+	return strings.NewReplacer("{", "{{ _.", "}", " }}").Replace(uri)
+}
@@ -0,0 +1,181 @@
+package export
+
+import (
+	"encoding/json"
+	"laravelboot/internal/project"
+	"strings"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanKV struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+type postmanAuth struct {
+	Type   string      `json:"type"`
+	Bearer []postmanKV `json:"bearer,omitempty"`
+}
+
+type postmanScript struct {
+	Type string   `json:"type"`
+	Exec []string `json:"exec"`
+}
+
+type postmanEvent struct {
+	Listen string        `json:"listen"`
+	Script postmanScript `json:"script"`
+}
+
+type postmanBody struct {
+	Mode     string      `json:"mode"`
+	Raw      string      `json:"raw,omitempty"`
+	FormData []postmanKV `json:"formdata,omitempty"`
+	Options  interface{} `json:"options,omitempty"`
+}
+
+type postmanURL struct {
+	Raw  string   `json:"raw"`
+	Host []string `json:"host"`
+	Path []string `json:"path"`
+}
+
+type postmanRequest struct {
+	Method string       `json:"method"`
+	Header []postmanKV  `json:"header"`
+	Auth   *postmanAuth `json:"auth,omitempty"`
+	Body   *postmanBody `json:"body,omitempty"`
+	URL    postmanURL   `json:"url"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item,omitempty"`
+	Request *postmanRequest `json:"request,omitempty"`
+	Event   []postmanEvent  `json:"event,omitempty"`
+}
+
+type postmanCollection struct {
+	Info struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []postmanItem `json:"item"`
+	Variable []postmanKV   `json:"variable"`
+}
+
+type postmanEnvValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Enabled bool   `json:"enabled"`
+}
+
+type postmanEnvironment struct {
+	Name   string            `json:"name"`
+	Values []postmanEnvValue `json:"values"`
+	Scope  string            `json:"_postman_variable_scope"`
+}
+
+func (e *CollectionExporter) postman() (map[string][]byte, error) {
+	var c postmanCollection
+	c.Info.Name = e.name
+	c.Info.Schema = postmanSchema
+	c.Variable = []postmanKV{
+		{Key: "baseUrl", Value: e.BaseURL},
+		{Key: "token", Value: ""},
+	}
+
+	order, grouped := e.groups()
+	for _, g := range order {
+		folder := postmanItem{Name: g}
+		for _, r := range grouped[g] {
+			folder.Item = append(folder.Item, e.postmanItem(r))
+		}
+		c.Item = append(c.Item, folder)
+	}
+
+	collection, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	env := postmanEnvironment{Name: e.name + " (local)", Scope: "environment"}
+	for _, kv := range c.Variable {
+		env.Values = append(env.Values, postmanEnvValue{Key: kv.Key, Value: kv.Value, Enabled: true})
+	}
+	environment, err := json.MarshalIndent(env, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		e.name + ".postman_collection.json":  collection,
+		e.name + ".postman_environment.json": environment,
+	}, nil
+}
+
+func (e *CollectionExporter) postmanItem(r project.Route) postmanItem {
+	path := pathWithVariables(r.URI)
+	req := &postmanRequest{
+		Method: r.Method,
+		Header: []postmanKV{{Key: "Accept", Value: "application/json"}},
+		URL: postmanURL{
+			Raw:  "{{baseUrl}}" + path,
+			Host: []string{"{{baseUrl}}"},
+			Path: strings.Split(strings.Trim(path, "/"), "/"),
+		},
+	}
+
+	if r.Authenticated() {
+		req.Auth = &postmanAuth{
+			Type:   "bearer",
+			Bearer: []postmanKV{{Key: "token", Value: "{{token}}", Type: "string"}},
+		}
+	}
+
+	if fields := bodyFields(r); hasBody(r) && len(fields) > 0 {
+		if r.HasFileUpload() {
+			body := &postmanBody{Mode: "formdata"}
+			for _, f := range fields {
+				kv := postmanKV{Key: f.Name, Value: sampleValue(f), Type: "text"}
+				if isFileField(f) {
+					kv = postmanKV{Key: f.Name, Type: "file"}
+				}
+				body.FormData = append(body.FormData, kv)
+			}
+			req.Body = body
+		} else {
+			payload := map[string]string{}
+			for _, f := range fields {
+				payload[f.Name] = sampleValue(f)
+			}
+			raw, _ := json.MarshalIndent(payload, "", "    ")
+			req.Body = &postmanBody{
+				Mode:    "raw",
+				Raw:     string(raw),
+				Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+			}
+			req.Header = append(req.Header, postmanKV{Key: "Content-Type", Value: "application/json"})
+		}
+	}
+
+	item := postmanItem{Name: r.Name(), Request: req}
+	if isLogin(r) {
+		item.Event = []postmanEvent{{
+			Listen: "test",
+			Script: postmanScript{
+				Type: "text/javascript",
+				Exec: []string{
+					"const body = pm.response.json();",
+					"if (body.data && body.data.token) {",
+					"    pm.collectionVariables.set(\"token\", body.data.token);",
+					"    pm.environment.set(\"token\", body.data.token);",
+					"}",
+				},
+			},
+		}}
+	}
+	return item
+}
@@ -1,48 +1,35 @@
 package interactive
 
 import (
-	"bufio"
 	"fmt"
 	"laravelboot/internal/config"
-	"os"
-	"strings"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/ui"
 )
 
-func RunInit() (*config.Config, error) {
-	reader := bufio.NewReader(os.Stdin)
-	conf := config.DefaultConfig()
-
-	fmt.Println("🚀 Welcome to LaravelBoot Interactive Setup")
-	fmt.Println("-------------------------------------------")
-
-	fmt.Printf("Project Name [%s]: ", conf.ProjectName)
-	name, _ := reader.ReadString('\n')
-	name = strings.TrimSpace(name)
-	if name != "" {
-		conf.ProjectName = name
+// RunInit runs the full-screen wizard starting from base and returns the
+// config the user confirmed.
+func RunInit(base *config.Config, pluginFeatures []plugins.FeatureSpec) (*config.Config, error) {
+	if !IsTerminal() {
+		return nil, fmt.Errorf("the init wizard needs a terminal")
 	}
 
-	fmt.Printf("Database (mysql, postgres, sqlite) [%s]: ", conf.Database)
-	db, _ := reader.ReadString('\n')
-	db = strings.TrimSpace(db)
-	if db != "" {
-		conf.Database = db
+	conf, err := runWizard(base, pluginFeatures)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Auth (sanctum, passport) [%s]: ", conf.Auth)
-	auth, _ := reader.ReadString('\n')
-	auth = strings.TrimSpace(auth)
-	if auth != "" {
-		conf.Auth = auth
-	}
+	ui.Println("✅ Configuration generated!")
+	return conf, nil
+}
 
-	fmt.Printf("Enable Enterprise Stack (Quality, CI, Monitoring, Pro-Arch) (y/n) [n]: ")
-	ent, _ := reader.ReadString('\n')
-	ent = strings.TrimSpace(ent)
-	if strings.ToLower(ent) == "y" {
-		conf.Enterprise = []string{"quality", "pro-arch", "docs-pro", "ci", "monitoring"}
+// runWizard holds the screen while the wizard runs. The deferred close
+// restores the terminal even when the wizard panics.
+func runWizard(base *config.Config, pluginFeatures []plugins.FeatureSpec) (*config.Config, error) {
+	s, err := openScreen()
+	if err != nil {
+		return nil, err
 	}
-
-	fmt.Println("\n✅ Configuration generated!")
-	return conf, nil
+	defer s.close()
+	return newWizard(s, base, pluginFeatures).run()
 }
@@ -0,0 +1,165 @@
+package interactive
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+type key int
+
+const (
+	keyRune key = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keySpace
+	keyTab
+	keyBackspace
+	keyEsc
+	keyCtrlC
+	keyUnknown
+)
+
+// screen is a full-screen terminal in raw mode, drawn on the alternate
+// buffer so the user's scrollback is left untouched.
+type screen struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+// IsTerminal reports whether both stdin and stdout are attached to a TTY,
+// which the init wizard needs.
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+func openScreen() (*screen, error) {
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	s := &screen{in: os.Stdin, out: os.Stdout, state: state}
+	fmt.Fprint(s.out, "\x1b[?1049h\x1b[?25l")
+	return s, nil
+}
+
+func (s *screen) close() {
+	fmt.Fprint(s.out, "\x1b[?25h\x1b[?1049l")
+	term.Restore(int(s.in.Fd()), s.state)
+}
+
+// size returns the terminal size, falling back to 80x24.
+func (s *screen) size() (width, height int) {
+	width, height, err := term.GetSize(int(s.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// draw replaces the screen with lines, truncating each to the width.
+func (s *screen) draw(lines []string) {
+	width, _ := s.size()
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(truncate(line, width))
+	}
+	fmt.Fprint(s.out, b.String())
+}
+
+// readKey blocks for one key press. A bare escape byte is Esc; escape
+// sequences are decoded for the arrow keys. Printable input is returned as
+// text, which holds several characters when the user pastes.
+func (s *screen) readKey() (key, string, error) {
+	buf := make([]byte, 16)
+	n, err := s.in.Read(buf)
+	if err != nil {
+		return keyUnknown, "", err
+	}
+	b := buf[:n]
+
+	switch {
+	case len(b) >= 3 && b[0] == 0x1b && (b[1] == '[' || b[1] == 'O'):
+		switch b[2] {
+		case 'A':
+			return keyUp, "", nil
+		case 'B':
+			return keyDown, "", nil
+		case 'C':
+			return keyRight, "", nil
+		case 'D':
+			return keyLeft, "", nil
+		}
+		return keyUnknown, "", nil
+	case b[0] == 0x1b:
+		return keyEsc, "", nil
+	case b[0] == 3:
+		return keyCtrlC, "", nil
+	case b[0] == '\r' || b[0] == '\n':
+		return keyEnter, "", nil
+	case b[0] == ' ':
+		return keySpace, " ", nil
+	case b[0] == '\t':
+		return keyTab, "", nil
+	case b[0] == 127 || b[0] == 8:
+		return keyBackspace, "", nil
+	}
+
+	var text strings.Builder
+	for _, r := range string(b) {
+		if r != utf8.RuneError && r >= 32 {
+			text.WriteRune(r)
+		}
+	}
+	if text.Len() == 0 {
+		return keyUnknown, "", nil
+	}
+	return keyRune, text.String(), nil
+}
+
+// truncate cuts s to width visible runes, ignoring ANSI colour sequences.
+func truncate(s string, width int) string {
+	var b strings.Builder
+	visible := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			b.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+			}
+			continue
+		case r == 0x1b:
+			inEscape = true
+			b.WriteRune(r)
+			continue
+		}
+		if visible >= width {
+			continue
+		}
+		b.WriteRune(r)
+		visible++
+	}
+	return b.String()
+}
+
+const (
+	bold  = "\x1b[1m"
+	dim   = "\x1b[2m"
+	cyan  = "\x1b[36m"
+	green = "\x1b[32m"
+	red   = "\x1b[31m"
+	reset = "\x1b[0m"
+)
@@ -0,0 +1,641 @@
+package interactive
+
+import (
+	"errors"
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/laravel"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/presets"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrCancelled is returned when the user leaves the wizard without saving.
+var ErrCancelled = errors.New("init cancelled")
+
+var projectNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+const (
+	stepPreset = iota
+	stepProject
+	stepFeatures
+	stepOptions
+	stepSummary
+)
+
+var stepTitles = []string{"Preset", "Project", "Features", "Options", "Summary"}
+
+// item is one row of the feature list; header rows name a category.
+type item struct {
+	name        string
+	group       string
+	description string
+	requires    []string
+	header      bool
+}
+
+// field is one setting on the project screen. Fields with choices cycle
+// with the arrow keys; the rest are free text.
+type field struct {
+	label   string
+	value   *string
+	choices []string
+}
+
+type wizard struct {
+	screen  *screen
+	conf    *config.Config
+	presets []*presets.Preset
+	plugins []string
+
+	step    int
+	cursor  int
+	offset  int
+	message string
+
+	fields   []field
+	sources  string // webhook-receiver sources, comma-separated
+	flags    string // feature flag names, comma-separated
+	items    []item
+	selected map[string]bool
+	auto     map[string]string // dependency -> feature that pulled it in
+}
+
+func newWizard(s *screen, base *config.Config, pluginFeatures []plugins.FeatureSpec) *wizard {
+	w := &wizard{screen: s, conf: base}
+	w.presets, _ = presets.List()
+
+	groups := []struct{ name, title string }{
+		{"platform", "Platform"},
+		{"infra", "Infrastructure & Security"},
+		{"enterprise", "Enterprise & Quality"},
+	}
+	for _, g := range groups {
+		w.items = append(w.items, item{name: g.title, header: true})
+		for _, f := range laravel.Catalog {
+			if f.Group == g.name {
+				w.items = append(w.items, item{name: f.Name, group: f.Group, description: f.Description, requires: f.Requires})
+			}
+		}
+	}
+	if len(pluginFeatures) > 0 {
+		w.items = append(w.items, item{name: "Plugins", header: true})
+		for _, f := range pluginFeatures {
+			w.items = append(w.items, item{name: f.Name, group: "plugin", description: f.Description})
+			w.plugins = append(w.plugins, f.Name)
+		}
+	}
+
+	w.useConfig(base)
+	return w
+}
+
+// useConfig loads a config's settings and feature lists into the form.
+func (w *wizard) useConfig(c *config.Config) {
+	name := w.conf.ProjectName
+	w.conf = c
+	if w.conf.ProjectName == "" {
+		w.conf.ProjectName = name
+	}
+	w.fields = []field{
+		{label: "Project name", value: &w.conf.ProjectName},
+		{label: "Database", value: &w.conf.Database, choices: config.Databases},
+		{label: "Auth", value: &w.conf.Auth, choices: config.AuthDrivers},
+		{label: "Architecture", value: &w.conf.Architecture, choices: config.Architectures},
+		{label: "PHP version", value: &w.conf.Options.Docker.PHPVersion, choices: append([]string{""}, config.PHPVersions...)},
+	}
+	w.sources = strings.Join(c.Options.WebhookReceiver.Sources, ", ")
+	w.flags = strings.Join(c.Options.FeatureFlags.Flags, ", ")
+
+	w.selected = map[string]bool{}
+	w.auto = map[string]string{}
+	var names []string
+	names = append(names, c.Features...)
+	names = append(names, c.Infra...)
+	names = append(names, c.Enterprise...)
+	for _, name := range laravel.ExpandFeatures(names) {
+		w.selected[name] = true
+	}
+	for _, it := range w.items {
+		if w.selected[it.name] {
+			w.selectDependencies(it)
+		}
+	}
+}
+
+func (w *wizard) run() (*config.Config, error) {
+	for {
+		w.render()
+		k, r, err := w.screen.readKey()
+		if err != nil {
+			return nil, err
+		}
+		if k == keyCtrlC {
+			return nil, ErrCancelled
+		}
+		w.message = ""
+
+		done, err := w.handle(k, r)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return w.result(), nil
+		}
+	}
+}
+
+func (w *wizard) goTo(step int) {
+	w.step = step
+	w.cursor = 0
+	w.offset = 0
+	if step == stepFeatures {
+		w.cursor = 1 // skip the first header
+	}
+}
+
+// optionFields are the settings on the options screen. Settings that only
+// one feature reads are shown when that feature is selected.
+func (w *wizard) optionFields() []field {
+	fields := []field{
+		{label: "Responses", value: &w.conf.Options.Responses.Format, choices: append([]string{""}, config.ResponseFormats...)},
+		{label: "Pagination", value: &w.conf.Options.Pagination.Strategy, choices: append([]string{""}, config.PaginationStrategies...)},
+	}
+	if w.selected["messaging"] {
+		fields = append(fields, field{label: "Messaging", value: &w.conf.Options.Messaging.Driver, choices: append([]string{""}, config.MessagingDrivers...)})
+	}
+	if w.selected["webhook-receiver"] {
+		fields = append(fields, field{label: "Hook sources", value: &w.sources})
+	}
+	if w.selected["feature-flags"] {
+		fields = append(fields, field{label: "Feature flags", value: &w.flags})
+	}
+	if w.selected["idempotency"] {
+		fields = append(fields, field{label: "Idempotency", value: &w.conf.Options.Idempotency.Store, choices: append([]string{""}, config.IdempotencyStores...)})
+	}
+	return fields
+}
+
+// handle applies one key press. It returns true once the summary is
+// confirmed.
+func (w *wizard) handle(k key, r string) (bool, error) {
+	typing := w.step == stepProject || w.step == stepOptions
+	if k == keyEsc || (k == keyRune && r == "b" && !typing) {
+		if w.step == stepPreset {
+			return false, ErrCancelled
+		}
+		w.goTo(w.step - 1)
+		return false, nil
+	}
+	if k == keyRune && r == "q" && !typing {
+		return false, ErrCancelled
+	}
+
+	switch w.step {
+	case stepPreset:
+		w.handlePreset(k)
+	case stepProject:
+		if w.handleFields(w.fields, k, r) {
+			if !projectNamePattern.MatchString(w.conf.ProjectName) {
+				w.cursor = 0
+				w.message = "Project name may only use letters, digits, '.', '_' and '-'"
+				return false, nil
+			}
+			w.goTo(stepFeatures)
+		}
+	case stepFeatures:
+		w.handleFeatures(k, r)
+	case stepOptions:
+		if w.handleFields(w.optionFields(), k, r) {
+			w.goTo(stepSummary)
+		}
+	case stepSummary:
+		switch k {
+		case keyUp:
+			w.offset = max(w.offset-1, 0)
+		case keyDown:
+			w.offset++
+		case keyEnter:
+			if errs := w.problems(); len(errs) > 0 {
+				w.message = "Fix the problems above before saving"
+				return false, nil
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (w *wizard) handlePreset(k key) {
+	switch k {
+	case keyUp:
+		w.cursor = max(w.cursor-1, 0)
+	case keyDown:
+		w.cursor = min(w.cursor+1, len(w.presets))
+	case keyEnter:
+		if w.cursor > 0 {
+			p := w.presets[w.cursor-1]
+			c := config.Merge(config.DefaultConfig(), p.Config)
+			c.ProjectName = ""
+			w.useConfig(c)
+		}
+		w.goTo(stepProject)
+	}
+}
+
+// handleFields edits a screen of fields and reports whether enter was
+// pressed.
+func (w *wizard) handleFields(fields []field, k key, r string) bool {
+	f := fields[w.cursor]
+	switch k {
+	case keyUp:
+		w.cursor = max(w.cursor-1, 0)
+	case keyDown, keyTab:
+		w.cursor = min(w.cursor+1, len(fields)-1)
+	case keyLeft, keyRight:
+		if len(f.choices) > 0 {
+			step := 1
+			if k == keyLeft {
+				step = len(f.choices) - 1
+			}
+			i := (indexOf(f.choices, *f.value) + step) % len(f.choices)
+			*f.value = f.choices[i]
+		}
+	case keyBackspace:
+		if f.choices == nil && len(*f.value) > 0 {
+			_, size := utf8.DecodeLastRuneInString(*f.value)
+			*f.value = (*f.value)[:len(*f.value)-size]
+		}
+	case keyRune, keySpace:
+		if f.choices == nil {
+			*f.value += r
+		}
+	case keyEnter:
+		return true
+	}
+	return false
+}
+
+func (w *wizard) handleFeatures(k key, r string) {
+	switch {
+	case k == keyUp:
+		w.moveCursor(-1)
+	case k == keyDown:
+		w.moveCursor(1)
+	case k == keySpace:
+		w.toggle(w.items[w.cursor])
+	case k == keyRune && r == "a":
+		w.toggleGroup(w.items[w.cursor].group)
+	case k == keyEnter:
+		w.goTo(stepOptions)
+	}
+}
+
+func (w *wizard) moveCursor(delta int) {
+	for i := w.cursor + delta; i >= 0 && i < len(w.items); i += delta {
+		if !w.items[i].header {
+			w.cursor = i
+			return
+		}
+	}
+}
+
+// toggle flips a feature. Selecting pulls in its dependencies; a feature
+// another selected feature depends on cannot be deselected.
+func (w *wizard) toggle(it item) {
+	if !w.selected[it.name] {
+		w.selected[it.name] = true
+		if added := w.selectDependencies(it); len(added) > 0 {
+			w.message = fmt.Sprintf("➕ %s needs %s; selected it too", it.name, strings.Join(added, ", "))
+		}
+		return
+	}
+
+	if users := w.dependents(it.name); len(users) > 0 {
+		w.message = fmt.Sprintf("%s is needed by %s", it.name, strings.Join(users, ", "))
+		return
+	}
+	delete(w.selected, it.name)
+	delete(w.auto, it.name)
+}
+
+func (w *wizard) toggleGroup(group string) {
+	all := true
+	for _, it := range w.items {
+		if it.group == group && !it.header && !w.selected[it.name] {
+			all = false
+		}
+	}
+	for _, it := range w.items {
+		if it.group != group || it.header {
+			continue
+		}
+		if all {
+			if len(w.dependents(it.name)) == 0 || allInGroup(w.items, w.dependents(it.name), group) {
+				delete(w.selected, it.name)
+				delete(w.auto, it.name)
+			}
+		} else if !w.selected[it.name] {
+			w.selected[it.name] = true
+			w.selectDependencies(it)
+		}
+	}
+}
+
+func (w *wizard) selectDependencies(it item) []string {
+	var added []string
+	for _, dep := range laravel.WithDependencies([]string{it.name}) {
+		if dep == it.name || w.selected[dep] {
+			continue
+		}
+		w.selected[dep] = true
+		w.auto[dep] = it.name
+		added = append(added, dep)
+	}
+	return added
+}
+
+// dependents lists the selected features that require name.
+func (w *wizard) dependents(name string) []string {
+	var users []string
+	for _, it := range w.items {
+		if w.selected[it.name] && indexOf(it.requires, name) >= 0 {
+			users = append(users, it.name)
+		}
+	}
+	return users
+}
+
+// result builds the config from the form, with features in catalog order.
+func (w *wizard) result() *config.Config {
+	c := *w.conf
+	c.Options.WebhookReceiver.Sources = splitList(w.sources)
+	c.Options.FeatureFlags.Flags = splitList(w.flags)
+	c.Features, c.Infra, c.Enterprise = nil, nil, nil
+	for _, it := range w.items {
+		if it.header || !w.selected[it.name] {
+			continue
+		}
+		switch it.group {
+		case "infra":
+			c.Infra = append(c.Infra, it.name)
+		case "enterprise":
+			c.Enterprise = append(c.Enterprise, it.name)
+		default:
+			c.Features = append(c.Features, it.name)
+		}
+	}
+	return &c
+}
+
+func (w *wizard) problems() []string {
+	var problems []string
+	if !projectNamePattern.MatchString(w.conf.ProjectName) {
+		problems = append(problems, "project name is not a valid directory name")
+	}
+	if err := w.result().Validate(w.plugins...); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+	return problems
+}
+
+func (w *wizard) render() {
+	_, height := w.screen.size()
+
+	var crumbs []string
+	for i, t := range stepTitles {
+		if i == w.step {
+			t = bold + cyan + t + reset
+		} else {
+			t = dim + t + reset
+		}
+		crumbs = append(crumbs, t)
+	}
+	head := []string{
+		bold + "🚀 LaravelBoot Setup" + reset + "   " + strings.Join(crumbs, dim+" › "+reset),
+		"",
+	}
+
+	var body []string
+	focus := 0
+	var help string
+	switch w.step {
+	case stepPreset:
+		body, focus = w.presetLines()
+		help = "↑/↓ move · enter choose · q quit"
+	case stepProject:
+		body, focus = w.fieldLines(w.fields)
+		help = "↑/↓ move · ←/→ change · type to edit the name · enter next · esc back"
+	case stepFeatures:
+		body, focus = w.featureLines()
+		help = "↑/↓ move · space toggle · a toggle category · enter next · esc back"
+	case stepOptions:
+		body, focus = w.fieldLines(w.optionFields())
+		help = "↑/↓ move · ←/→ change · type names separated by commas · enter next · esc back"
+	case stepSummary:
+		body = w.summaryLines()
+		focus = -1
+		help = "↑/↓ scroll · enter save · esc back · q quit"
+	}
+
+	foot := []string{""}
+	if w.message != "" {
+		foot = append(foot, w.message)
+	}
+	foot = append(foot, dim+help+reset)
+
+	room := max(height-len(head)-len(foot), 3)
+	if focus >= 0 {
+		if focus < w.offset {
+			w.offset = focus
+		} else if focus >= w.offset+room {
+			w.offset = focus - room + 1
+		}
+	}
+	w.offset = max(min(w.offset, len(body)-room), 0)
+	end := min(w.offset+room, len(body))
+
+	lines := append(head, body[w.offset:end]...)
+	w.screen.draw(append(lines, foot...))
+}
+
+func (w *wizard) presetLines() ([]string, int) {
+	lines := []string{"Start from:"}
+	options := []string{fmt.Sprintf("%-14s %s", "current", "the existing config, or the defaults")}
+	for _, p := range w.presets {
+		options = append(options, fmt.Sprintf("%-14s %s", p.Name, p.Description))
+	}
+	for i, o := range options {
+		lines = append(lines, cursorLine(i == w.cursor, o))
+	}
+	return lines, w.cursor + 1
+}
+
+func (w *wizard) fieldLines(fields []field) ([]string, int) {
+	var lines []string
+	for i, f := range fields {
+		value := *f.value
+		if f.choices != nil {
+			display := value
+			if display == "" {
+				display = "default"
+			}
+			value = "‹ " + display + " ›"
+		} else if i == w.cursor {
+			value += "▌"
+		}
+		lines = append(lines, cursorLine(i == w.cursor, fmt.Sprintf("%-14s %s", f.label, value)))
+	}
+	return lines, w.cursor
+}
+
+func (w *wizard) featureLines() ([]string, int) {
+	var lines []string
+	for i, it := range w.items {
+		if it.header {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, bold+it.name+reset)
+			continue
+		}
+		box := "[ ]"
+		if w.selected[it.name] {
+			box = green + "[x]" + reset
+		}
+		note := ""
+		if by, ok := w.auto[it.name]; ok && w.selected[it.name] {
+			note = dim + " (needed by " + by + ")" + reset
+		}
+		lines = append(lines, cursorLine(i == w.cursor, fmt.Sprintf("%s %-14s %s%s%s", box, it.name, dim, it.description, reset)+note))
+	}
+
+	// Headers and the blank lines before them shift the row index.
+	focus := 0
+	for i := 0; i <= w.cursor && i < len(w.items); i++ {
+		if w.items[i].header && i > 0 {
+			focus++
+		}
+		focus++
+	}
+	return lines, focus - 1
+}
+
+func (w *wizard) summaryLines() []string {
+	c := w.result()
+	php := c.Options.Docker.PHPVersion
+	if php == "" {
+		php = "default"
+	}
+
+	lines := []string{
+		bold + "Project" + reset,
+		fmt.Sprintf("  %-14s %s", "Name", c.ProjectName),
+		fmt.Sprintf("  %-14s %s", "Database", c.Database),
+		fmt.Sprintf("  %-14s %s", "Auth", c.Auth),
+		fmt.Sprintf("  %-14s %s", "Architecture", c.Architecture),
+		fmt.Sprintf("  %-14s %s", "PHP", php),
+		"",
+		bold + "Options" + reset,
+	}
+	for _, f := range w.optionFields() {
+		value := *f.value
+		if value == "" {
+			value = "default"
+		}
+		lines = append(lines, fmt.Sprintf("  %-14s %s", f.label, value))
+	}
+	lines = append(lines, "")
+
+	width, _ := w.screen.size()
+	list := func(title string, names []string) {
+		if len(names) == 0 {
+			lines = append(lines, fmt.Sprintf("  %-14s %snone%s", title, dim, reset))
+			return
+		}
+		line := fmt.Sprintf("  %-14s ", title)
+		for i, name := range names {
+			if i < len(names)-1 {
+				name += ","
+			}
+			if i > 0 && len(line)+len(name)+1 > width {
+				lines = append(lines, line)
+				line = strings.Repeat(" ", 17)
+			} else if i > 0 {
+				line += " "
+			}
+			line += name
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, bold+"Features"+reset)
+	list("Platform", c.Features)
+	list("Infra", c.Infra)
+	list("Enterprise", c.Enterprise)
+
+	var auto []string
+	for dep, by := range w.auto {
+		if w.selected[dep] {
+			auto = append(auto, dep+" (for "+by+")")
+		}
+	}
+	sort.Strings(auto)
+	if len(auto) > 0 {
+		list("Dependencies", auto)
+	}
+
+	var packages []string
+	files := 0
+	for _, name := range append(append(append([]string{"auth"}, c.Features...), c.Infra...), c.Enterprise...) {
+		if f, ok := laravel.LookupFeature(name); ok {
+			packages = append(packages, f.Packages...)
+			files += len(f.Files)
+		}
+	}
+	lines = append(lines, "", bold+"Will install"+reset)
+	list("Composer", packages)
+	lines = append(lines, fmt.Sprintf("  %-14s %d generated files plus the project skeleton", "Files", files))
+
+	if problems := w.problems(); len(problems) > 0 {
+		lines = append(lines, "", red+bold+"Problems"+reset)
+		for _, p := range problems {
+			lines = append(lines, red+"  "+strings.TrimSpace(p)+reset)
+		}
+	} else {
+		lines = append(lines, "", green+"✅ Configuration is valid"+reset)
+	}
+	return lines
+}
+
+// splitList reads a comma- or space-separated list; empty means unset.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+func cursorLine(active bool, text string) string {
+	if active {
+		return cyan + "❯ " + reset + text
+	}
+	return "  " + text
+}
+
+func allInGroup(items []item, names []string, group string) bool {
+	for _, it := range items {
+		if indexOf(names, it.name) >= 0 && it.group != group {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOf(list []string, value string) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,21 +19,18 @@ func NewActivityLogSetup(projectPath string, dryRun bool) *ActivityLogSetup {
 
 func (a *ActivityLogSetup) Setup() error {
 	if a.DryRun {
-		fmt.Printf("[Dry Run] Would install spatie/laravel-activitylog\n")
+		ui.Printf("[Dry Run] Would install spatie/laravel-activitylog\n")
 		return nil
 	}
 
-	fmt.Println("📝 Installing spatie/laravel-activitylog...")
-	cmd := exec.Command("composer", "require", "spatie/laravel-activitylog", "--with-all-dependencies")
-	cmd.Dir = a.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install spatie/laravel-activitylog: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(a.ProjectPath, false, "spatie/laravel-activitylog"); err != nil {
+		return err
 	}
 
-	fmt.Println("📦 Publishing migrations...")
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\Activitylog\\ActivitylogServiceProvider", "--tag=activitylog-migrations")
+	ui.Println("📦 Publishing migrations...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\Activitylog\\ActivitylogServiceProvider", "--tag=activitylog-migrations")
 	cmd.Dir = a.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(cmd); err != nil {
 		return fmt.Errorf("failed to publish activitylog migrations: %v\nOutput: %s", err, string(output))
 	}
 
@@ -68,7 +66,7 @@ trait InteractsWithActivityLog
 	if !a.DryRun {
 		os.MkdirAll(dir, 0755)
 		path := filepath.Join(dir, "InteractsWithActivityLog.php")
-		return os.WriteFile(path, []byte(content), 0644)
+		return ui.WriteFile(path, []byte(content), 0644)
 	}
 	return nil
 }
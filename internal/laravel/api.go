@@ -2,6 +2,8 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +12,7 @@ import (
 type ApiSetup struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewApiSetup(projectPath string, dryRun bool) *ApiSetup {
@@ -17,6 +20,9 @@ func NewApiSetup(projectPath string, dryRun bool) *ApiSetup {
 }
 
 func (s *ApiSetup) Configure() error {
+	if err := writeApiSupport(s.ProjectPath, s.Options, s.DryRun); err != nil {
+		return err
+	}
 	if err := s.createResponseServiceProvider(); err != nil {
 		return err
 	}
@@ -34,6 +40,7 @@ func (s *ApiSetup) createResponseServiceProvider() error {
 
 namespace App\Providers;
 
+use App\Support\Api\Envelope;
 use Illuminate\Support\ServiceProvider;
 use Illuminate\Support\Facades\Response;
 
@@ -42,35 +49,23 @@ class ApiResponseServiceProvider extends ServiceProvider
     public function boot(): void
     {
         Response::macro('success', function ($data, $message = 'Success', $code = 200) {
-            return Response::json([
-                'success' => true,
-                'message' => $message,
-                'data' => $data,
-            ], $code);
+            return Envelope::success($data, $message, $code);
         });
 
         Response::macro('created', function ($data, $message = 'Resource created successfully') {
-            return Response::json([
-                'success' => true,
-                'message' => $message,
-                'data' => $data,
-            ], 201);
+            return Envelope::success($data, $message, 201);
         });
 
         Response::macro('deleted', function ($message = 'Resource deleted successfully') {
-            return Response::json([
-                'success' => true,
-                'message' => $message,
-                'data' => null,
-            ], 200);
+            return Envelope::success(null, $message);
+        });
+
+        Response::macro('paginated', function ($paginator, $message = 'Success') {
+            return Envelope::paginated($paginator, $message);
         });
 
         Response::macro('error', function ($message = 'Error', $code = 400, $errors = []) {
-            return Response::json([
-                'success' => false,
-                'message' => $message,
-                'errors' => $errors,
-            ], $code);
+            return Envelope::error($message, $code, $errors);
         });
 
         Response::macro('unauthorized', function ($message = 'Unauthorized', $errors = []) {
@@ -89,38 +84,48 @@ class ApiResponseServiceProvider extends ServiceProvider
 `
 	path := filepath.Join(s.ProjectPath, "app/Providers/ApiResponseServiceProvider.php")
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would create file: %s\n", path)
+		ui.Printf("[Dry Run] Would create file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (s *ApiSetup) registerServiceProvider() error {
-	path := filepath.Join(s.ProjectPath, "bootstrap/providers.php")
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would register ApiResponseServiceProvider in %s\n", path)
+		ui.Printf("[Dry Run] Would register ApiResponseServiceProvider in %s\n", filepath.Join(s.ProjectPath, "bootstrap/providers.php"))
 		return nil
 	}
+	return registerProvider(s.ProjectPath, "App\\Providers\\ApiResponseServiceProvider")
+}
 
+// registerProvider adds class to bootstrap/providers.php unless it is
+// already listed.
+func registerProvider(projectPath, class string) error {
+	path := filepath.Join(projectPath, "bootstrap/providers.php")
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
 
+	entry := class + "::class,"
+	if strings.Contains(string(content), entry) {
+		return nil
+	}
+
 	newContent := strings.Replace(
 		string(content),
 		"];",
-		"    App\\Providers\\ApiResponseServiceProvider::class,\n];",
+		"    "+entry+"\n];",
 		1,
 	)
 
-	return os.WriteFile(path, []byte(newContent), 0644)
+	return ui.WriteFile(path, []byte(newContent), 0644)
 }
 
 func (s *ApiSetup) forceJsonResponse() error {
 	path := filepath.Join(s.ProjectPath, "bootstrap/app.php")
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would check %s to ensure it exists\n", path)
+		ui.Printf("[Dry Run] Would check %s to ensure it exists\n", path)
 		return nil
 	}
 
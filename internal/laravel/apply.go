@@ -0,0 +1,433 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/plugins"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Change is one step of an apply plan.
+type Change struct {
+	Action  string // add or remove
+	Feature string
+	Group   string // auth, platform, infra, enterprise or plugin
+	Details []string
+}
+
+type Plan struct {
+	Changes   []Change
+	Unchanged []string
+	Notes     []string
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *Plan) count(action string) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *Plan) Print() {
+	if ui.JSON() {
+		var changes []map[string]any
+		for _, c := range p.Changes {
+			changes = append(changes, map[string]any{"action": c.Action, "feature": c.Feature, "group": c.Group, "details": c.Details})
+		}
+		ui.Emit("plan", map[string]any{"changes": changes, "unchanged": p.Unchanged, "notes": p.Notes})
+		return
+	}
+
+	ui.Println("📋 Plan:")
+	for _, c := range p.Changes {
+		sign := "+"
+		if c.Action == "remove" {
+			sign = "-"
+		}
+		ui.Printf("  %s %-7s %-16s (%s)\n", sign, c.Action, c.Feature, c.Group)
+		for _, d := range c.Details {
+			ui.Printf("      %s\n", d)
+		}
+	}
+	if len(p.Unchanged) > 0 {
+		ui.Printf("  = %d up to date: %s\n", len(p.Unchanged), strings.Join(p.Unchanged, ", "))
+	}
+	for _, n := range p.Notes {
+		ui.Warnf("⚠️ %s\n", n)
+	}
+	if p.Empty() {
+		ui.Println("\n✅ No changes. The project matches .laravelboot.yaml.")
+		return
+	}
+	ui.Printf("\nPlan: %d to add, %d to remove.\n", p.count("add"), p.count("remove"))
+}
+
+// Reconciler converges an existing project on its .laravelboot.yaml.
+type Reconciler struct {
+	ProjectPath string
+	Config      *config.Config
+	Plugins     *plugins.PluginManager
+	DryRun      bool
+
+	// Policy decides whether Apply stops at the first failed change.
+	Policy Policy
+}
+
+func NewReconciler(projectPath string, conf *config.Config, pluginMgr *plugins.PluginManager, dryRun bool) *Reconciler {
+	return &Reconciler{ProjectPath: projectPath, Config: conf, Plugins: pluginMgr, DryRun: dryRun}
+}
+
+func (r *Reconciler) desired() []string {
+	var names []string
+	if r.Config.Auth != "" {
+		names = append(names, "auth")
+	}
+	names = append(names, r.Config.Features...)
+	names = append(names, r.Config.Infra...)
+	names = append(names, r.Config.Enterprise...)
+	// A dependency installed for a listed feature stays, even when the
+	// config does not name it.
+	return WithDependencies(ExpandFeatures(names))
+}
+
+// Plan compares the features the config asks for with those detected in
+// the project.
+func (r *Reconciler) Plan() (*Plan, error) {
+	packages, err := composerPackages(r.ProjectPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s does not look like a Laravel project: %v", r.ProjectPath, err)
+	}
+
+	plan := &Plan{}
+	want := map[string]bool{}
+	for _, name := range r.desired() {
+		want[name] = true
+
+		if f, ok := LookupFeature(name); ok {
+			if f.Installed(r.ProjectPath, packages) {
+				plan.Unchanged = append(plan.Unchanged, name)
+				continue
+			}
+			var details []string
+			if len(f.Packages) > 0 {
+				details = append(details, "composer require "+strings.Join(f.Packages, " "))
+			}
+			if len(f.DevPackages) > 0 {
+				details = append(details, "composer require --dev "+strings.Join(f.DevPackages, " "))
+			}
+			plan.Changes = append(plan.Changes, Change{Action: "add", Feature: name, Group: f.Group, Details: details})
+			continue
+		}
+
+		spec, ok := r.Plugins.FeatureSpec(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown feature: %s", name)
+		}
+		if r.pluginInstalled(spec) {
+			plan.Unchanged = append(plan.Unchanged, name)
+			continue
+		}
+		var details []string
+		if spec.Marker == "" {
+			details = append(details, "plugin feature has no marker file; it runs on every apply")
+		}
+		plan.Changes = append(plan.Changes, Change{Action: "add", Feature: name, Group: "plugin", Details: details})
+	}
+
+	// Only features LaravelBoot recorded installing are removed; one that
+	// merely looks installed may be the user's own code.
+	recorded, err := installedFeatures(r.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range Catalog {
+		switch {
+		case want[f.Name]:
+		case f.Fixed:
+			if f.Installed(r.ProjectPath, packages) {
+				plan.Notes = append(plan.Notes, fmt.Sprintf("%s is installed but not configured; it cannot be removed automatically", f.Name))
+			}
+		case !recorded[f.Name]:
+			if f.Installed(r.ProjectPath, packages) {
+				plan.Notes = append(plan.Notes, fmt.Sprintf("%s looks installed but is not configured; LaravelBoot has no record of installing it, so apply leaves it alone", f.Name))
+			}
+		default:
+			plan.Changes = append(plan.Changes, Change{Action: "remove", Feature: f.Name, Group: f.Group, Details: r.removeDetails(f, packages)})
+		}
+	}
+
+	for _, spec := range r.Plugins.FeatureSpecs() {
+		if want[spec.Name] || spec.Marker == "" || !r.pluginInstalled(spec) {
+			continue
+		}
+		if !r.Plugins.CanRemove(spec.Name) {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("plugin feature %s is installed but not configured, and its plugin cannot remove it", spec.Name))
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Action: "remove", Feature: spec.Name, Group: "plugin"})
+	}
+
+	return plan, nil
+}
+
+func (r *Reconciler) pluginInstalled(spec plugins.FeatureSpec) bool {
+	if spec.Marker == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(r.ProjectPath, spec.Marker))
+	return err == nil
+}
+
+func (r *Reconciler) removeDetails(f FeatureInfo, packages map[string]bool) []string {
+	var details []string
+	if pkgs := presentPackages(f, packages); len(pkgs) > 0 {
+		details = append(details, "composer remove "+strings.Join(pkgs, " "))
+	}
+	for _, file := range f.Files {
+		if _, err := os.Stat(filepath.Join(r.ProjectPath, file)); err == nil {
+			details = append(details, "delete "+file)
+		}
+	}
+	if routes, err := r.strippedRoutes(f); err != nil {
+		details = append(details, "blocked: "+err.Error())
+	} else if routes != nil {
+		details = append(details, "remove its routes from routes/api.php")
+	}
+	for _, file := range f.Edits {
+		details = append(details, "review "+file+" by hand")
+	}
+	return details
+}
+
+// strippedRoutes returns routes/api.php without the routes to the
+// feature's controllers, or nil when there are none. Route groups left
+// empty go too. It fails if a controller is still referenced in a way it
+// cannot remove, so the controller is not deleted from under a route.
+func (r *Reconciler) strippedRoutes(f FeatureInfo) ([]byte, error) {
+	var controllers []*regexp.Regexp
+	for _, file := range f.Files {
+		if strings.HasPrefix(file, "app/Http/Controllers/") {
+			name := strings.TrimSuffix(filepath.Base(file), ".php")
+			controllers = append(controllers, regexp.MustCompile(`\b`+name+`\b`))
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(r.ProjectPath, "routes/api.php"))
+	if err != nil || len(controllers) == 0 {
+		return nil, nil
+	}
+	refers := func(line string) bool {
+		for _, c := range controllers {
+			if c.MatchString(line) {
+				return true
+			}
+		}
+		return false
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var out []string
+	removed := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "Route::") && strings.HasSuffix(line, "->group(function () {") {
+			end := i + 1
+			for end < len(lines) && lines[end] != "});" {
+				end++
+			}
+			if end < len(lines) {
+				var kept []string
+				for _, inner := range lines[i+1 : end] {
+					if refers(inner) {
+						removed = true
+					} else {
+						kept = append(kept, inner)
+					}
+				}
+				if len(kept) > 0 || len(lines[i+1:end]) == 0 {
+					out = append(out, line)
+					out = append(out, kept...)
+					out = append(out, lines[end])
+				} else if len(out) > 0 && out[len(out)-1] == "" {
+					out = out[:len(out)-1]
+				}
+				i = end
+				continue
+			}
+		}
+		if (strings.HasPrefix(line, "Route::") || strings.HasPrefix(line, "use ")) && strings.HasSuffix(line, ";") && refers(line) {
+			removed = true
+			if len(out) > 0 && out[len(out)-1] == "" && i+1 < len(lines) && lines[i+1] == "" {
+				out = out[:len(out)-1]
+			}
+			continue
+		}
+		out = append(out, line)
+	}
+
+	for _, line := range out {
+		if refers(line) && !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			return nil, fmt.Errorf("routes/api.php still uses %s's controllers; remove those routes before removing the feature", f.Name)
+		}
+	}
+	if !removed {
+		return nil, nil
+	}
+	return []byte(strings.Join(out, "\n")), nil
+}
+
+// Apply runs removals first, then additions. With a fail-fast policy it
+// stops at the first error and skips the remaining changes; otherwise it
+// applies every change it can and returns an error naming the failures.
+func (r *Reconciler) Apply(plan *Plan) error {
+	packages, err := composerPackages(r.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	var ordered []Change
+	for _, c := range plan.Changes {
+		if c.Action == "remove" {
+			ordered = append(ordered, c)
+		}
+	}
+	for _, c := range plan.Changes {
+		if c.Action == "add" {
+			ordered = append(ordered, c)
+		}
+	}
+
+	// Install the packages of every addition in one composer batch.
+	var adds []string
+	for _, c := range plan.Changes {
+		if c.Action == "add" && c.Group != "plugin" {
+			adds = append(adds, c.Feature)
+		}
+	}
+	if len(adds) > 0 && !r.DryRun {
+		if err := NewOrchestrator(r.ProjectPath, r.DryRun).Require(adds); err != nil {
+			ui.Warnf("⚠️ Warning: batched composer require failed, each feature will install its own packages: %v\n", err)
+		}
+	}
+
+	var failures []string
+	for i, c := range ordered {
+		change := c
+		event := plugins.EventAdd
+		run := func() error { return r.add(change) }
+		if change.Action == "remove" {
+			event = plugins.EventRemove
+			run = func() error { return r.remove(change, packages) }
+		}
+
+		name := change.Action + ":" + change.Feature
+		if err := r.Policy.track(name, func() error { return r.Plugins.RunStep(name, r.Config, r.ProjectPath, run) }); err != nil {
+			if !r.Policy.FailFast {
+				ui.Warnf("⚠️ Warning: %s %s failed: %v\n", change.Action, change.Feature, err)
+				failures = append(failures, name)
+				continue
+			}
+			if r.Policy.Report != nil {
+				for _, rest := range ordered[i+1:] {
+					r.Policy.Report.Skip(rest.Action+":"+rest.Feature, "after an earlier failure (--fail-fast)")
+				}
+			}
+			return fmt.Errorf("%s %s: %v", change.Action, change.Feature, err)
+		}
+		if err := r.Plugins.Emit(plugins.Event{Name: event, Step: change.Feature, ProjectPath: r.ProjectPath, Config: r.Config}); err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d changes failed: %s", len(failures), len(ordered), strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+func (r *Reconciler) add(c Change) error {
+	ui.Printf("📦 Adding %s\n", c.Feature)
+	switch c.Group {
+	case "auth":
+		m := NewAuthManager(r.ProjectPath, r.DryRun)
+		m.Options = r.Config.Options
+		return m.AddAuth()
+	case "platform":
+		m := NewPlatformManager(r.ProjectPath, r.DryRun)
+		m.Options = r.Config.Options
+		return m.RunStep(c.Feature)
+	case "infra":
+		m := NewInfraManager(r.ProjectPath, r.DryRun)
+		m.Options = r.Config.Options
+		return m.RunStep(c.Feature)
+	case "enterprise":
+		m := NewEnterpriseManager(r.ProjectPath, r.DryRun)
+		m.Options = r.Config.Options
+		return m.RunStep(c.Feature)
+	case "plugin":
+		return r.Plugins.RunFeature(c.Feature, r.Config, r.ProjectPath)
+	}
+	return fmt.Errorf("unknown feature group: %s", c.Group)
+}
+
+func (r *Reconciler) remove(c Change, packages map[string]bool) error {
+	ui.Printf("🗑️ Removing %s\n", c.Feature)
+	if c.Group == "plugin" {
+		return r.Plugins.RemoveFeature(c.Feature, r.Config, r.ProjectPath)
+	}
+
+	f, _ := LookupFeature(c.Feature)
+	if r.DryRun {
+		for _, d := range r.removeDetails(f, packages) {
+			ui.Printf("[Dry Run] %s\n", d)
+		}
+		return nil
+	}
+
+	routes, err := r.strippedRoutes(f)
+	if err != nil {
+		return err
+	}
+
+	if pkgs := presentPackages(f, packages); len(pkgs) > 0 {
+		cmd := exec.Command("composer", append([]string{"remove"}, pkgs...)...)
+		cmd.Dir = r.ProjectPath
+		if output, err := ui.Run(cmd); err != nil {
+			return fmt.Errorf("failed to remove %s: %v\nOutput: %s", strings.Join(pkgs, " "), err, string(output))
+		}
+	}
+	if routes != nil {
+		if err := ui.WriteFile(filepath.Join(r.ProjectPath, "routes/api.php"), routes, 0644); err != nil {
+			return err
+		}
+	}
+	for _, file := range f.Files {
+		if err := os.Remove(filepath.Join(r.ProjectPath, file)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	for _, file := range f.Edits {
+		ui.Printf("   ✏️ %s was modified by %s; review it by hand\n", file, f.Name)
+	}
+	return forgetInstalled(r.ProjectPath, f.Name)
+}
+
+func presentPackages(f FeatureInfo, packages map[string]bool) []string {
+	var present []string
+	for _, pkg := range f.allPackages() {
+		if packages[pkg] {
+			present = append(present, pkg)
+		}
+	}
+	return present
+}
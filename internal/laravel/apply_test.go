@@ -0,0 +1,71 @@
+package laravel
+
+import (
+	"laravelboot/internal/config"
+	"laravelboot/internal/plugins"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// skeletonComposer is composer.json as laravel/laravel ships it.
+const skeletonComposer = `{
+    "require": {"php": "^8.2", "laravel/framework": "^11.0", "laravel/tinker": "^2.9"},
+    "require-dev": {"fakerphp/faker": "^1.23", "laravel/pail": "^1.1", "laravel/pint": "^1.13", "laravel/sail": "^1.26", "mockery/mockery": "^1.6", "nunomaduro/collision": "^8.1", "phpunit/phpunit": "^11.0.1"}
+}`
+
+func newSkeleton(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files = append(files, "composer.json")
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "<?php\n"
+		if file == "composer.json" {
+			content = skeletonComposer
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func planRemovals(t *testing.T, dir string) []string {
+	t.Helper()
+	conf := config.DefaultConfig()
+	conf.Features, conf.Infra, conf.Enterprise = nil, nil, nil
+	plan, err := NewReconciler(dir, conf, plugins.NewPluginManager(), true).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var removed []string
+	for _, c := range plan.Changes {
+		if c.Action == "remove" {
+			removed = append(removed, c.Feature)
+		}
+	}
+	return removed
+}
+
+// A fresh skeleton, even with files named like a feature's, has nothing
+// LaravelBoot installed and so nothing to remove.
+func TestFreshSkeletonPlansNoRemovals(t *testing.T) {
+	dir := newSkeleton(t, "routes/channels.php", "app/Http/Controllers/Api/FileController.php", "phpstan.neon")
+	if removed := planRemovals(t, dir); len(removed) > 0 {
+		t.Errorf("fresh skeleton plans removals: %v", removed)
+	}
+}
+
+func TestRecordedFeaturePlansRemoval(t *testing.T) {
+	dir := newSkeleton(t, "app/Jobs/BaseJob.php")
+	if err := recordStep(dir, false, "jobs", func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if removed := planRemovals(t, dir); len(removed) != 1 || removed[0] != "jobs" {
+		t.Errorf("removals = %v, want [jobs]", removed)
+	}
+}
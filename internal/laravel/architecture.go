@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -34,7 +35,7 @@ func (a *Architecture) SetupFolders() error {
 	for _, dir := range dirs {
 		path := filepath.Join(a.ProjectPath, dir)
 		if a.DryRun {
-			fmt.Printf("[Dry Run] Would create directory: %s\n", path)
+			ui.Printf("[Dry Run] Would create directory: %s\n", path)
 			continue
 		}
 		if err := os.MkdirAll(path, 0755); err != nil {
@@ -1,9 +1,8 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
-	"os/exec"
 	"path/filepath"
 )
 
@@ -18,15 +17,12 @@ func NewProArchSetup(projectPath string, dryRun bool) *ProArchSetup {
 
 func (p *ProArchSetup) Setup() error {
 	if p.DryRun {
-		fmt.Printf("[Dry Run] Would install spatie/laravel-data and setup Action patterns\n")
+		ui.Printf("[Dry Run] Would install spatie/laravel-data and setup Action patterns\n")
 		return nil
 	}
 
-	fmt.Println("🏗️ Installing spatie/laravel-data...")
-	cmd := exec.Command("composer", "require", "spatie/laravel-data", "--with-all-dependencies")
-	cmd.Dir = p.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install spatie/laravel-data: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(p.ProjectPath, false, "spatie/laravel-data"); err != nil {
+		return err
 	}
 
 	if err := p.createBaseAction(); err != nil {
@@ -52,5 +48,5 @@ trait AsAction
 	dir := filepath.Join(p.ProjectPath, "app/Support/Actions")
 	os.MkdirAll(dir, 0755)
 	path := filepath.Join(dir, "AsAction.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
@@ -0,0 +1,638 @@
+package laravel
+
+import (
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type AuditSetup struct {
+	ProjectPath string
+	DryRun      bool
+}
+
+func NewAuditSetup(projectPath string, dryRun bool) *AuditSetup {
+	return &AuditSetup{ProjectPath: projectPath, DryRun: dryRun}
+}
+
+func (a *AuditSetup) Setup() error {
+	if a.DryRun {
+		ui.Printf("[Dry Run] Would create audits table, Audited trait, hash-chained recorder, audit API and verify/prune commands\n")
+		return nil
+	}
+
+	// audit:verify and audit:prune extend BaseCommand.
+	if err := requireFeatures(a.ProjectPath, "audit", "scheduler"); err != nil {
+		return err
+	}
+
+	ui.Println("🧾 Setting up audit trail...")
+
+	steps := []func() error{
+		a.createConfig,
+		a.createMigration,
+		a.createModel,
+		a.createAuditor,
+		a.createTrait,
+		a.createController,
+		a.createCommands,
+		a.createServiceProvider,
+		a.registerRoutes,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if err := registerProvider(a.ProjectPath, "App\\Providers\\AuditServiceProvider"); err != nil {
+		return err
+	}
+	return scheduleCommand(a.ProjectPath, "Schedule::command('audit:prune')->daily();")
+}
+
+func (a *AuditSetup) createConfig() error {
+	content := `<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Models
+    |--------------------------------------------------------------------------
+    |
+    | Audited models the API can query by name:
+    | GET /api/audits/{name}/{id}. Models use the App\Audit\Audited trait.
+    |
+    */
+
+    'models' => [
+        'user' => \App\Models\User::class,
+    ],
+
+    // Attributes whose values are never stored; a change to one is
+    // recorded with the value replaced. Models add more with $auditMasked.
+    'masked' => [
+        'password', 'remember_token', 'api_token', 'secret',
+        'two_factor_secret', 'two_factor_recovery_codes',
+    ],
+
+    'mask' => '********',
+
+    // Attributes left out of diffs; an update that only changes these is
+    // not audited. Models add more with $auditExclude.
+    'exclude' => ['updated_at'],
+
+    // Header a proxy or load balancer sets; one is generated otherwise.
+    'request_id_header' => 'X-Request-Id',
+
+    // audit:prune deletes audits older than this.
+    'retention_days' => (int) env('AUDIT_RETENTION_DAYS', 365),
+
+    // Users with this role may read audits (view-audits gate).
+    'admin_role' => env('AUDIT_ADMIN_ROLE', 'admin'),
+
+];
+`
+	dir := filepath.Join(a.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "audit.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createMigration() error {
+	content := `<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('audits', function (Blueprint $table) {
+            $table->id();
+            $table->string('auditable_type');
+            $table->string('auditable_id');
+            $table->string('event');
+            $table->string('actor_type')->nullable();
+            $table->string('actor_id')->nullable();
+            $table->string('ip_address', 45)->nullable();
+            $table->string('user_agent', 1024)->nullable();
+            $table->string('request_id')->nullable();
+            $table->string('url', 2048)->nullable();
+            $table->json('old_values')->nullable();
+            $table->json('new_values')->nullable();
+            // sha256 of previous_hash and this row; see App\Audit\Auditor.
+            $table->char('previous_hash', 64)->nullable();
+            $table->char('hash', 64);
+            $table->timestamp('created_at');
+
+            $table->index(['auditable_type', 'auditable_id', 'id']);
+            $table->index(['actor_type', 'actor_id']);
+            $table->index('request_id');
+            $table->index('created_at');
+        });
+
+        // The chain head: one row holding the newest audit's hash. Writers
+        // lock it for the read and the insert, so audits chain one at a time.
+        Schema::create('audit_chain', function (Blueprint $table) {
+            $table->unsignedTinyInteger('id')->primary();
+            $table->char('hash', 64)->nullable();
+        });
+        DB::table('audit_chain')->insert(['id' => 1, 'hash' => null]);
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('audit_chain');
+        Schema::dropIfExists('audits');
+    }
+};
+`
+	dir := filepath.Join(a.ProjectPath, "database/migrations")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(a.ProjectPath, migrationFile(130, "create_audits_table")), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createModel() error {
+	content := `<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\MorphTo;
+use LogicException;
+
+/**
+ * One recorded change. Audits are written once by App\Audit\Auditor and
+ * never updated; audit:prune is the only thing that deletes them.
+ */
+class Audit extends Model
+{
+    public const UPDATED_AT = null;
+
+    protected $guarded = [];
+
+    protected function casts(): array
+    {
+        return [
+            'old_values' => 'array',
+            'new_values' => 'array',
+            'created_at' => 'datetime',
+        ];
+    }
+
+    protected static function booted(): void
+    {
+        static::updating(fn () => throw new LogicException('Audits cannot be changed.'));
+    }
+
+    public function auditable(): MorphTo
+    {
+        return $this->morphTo();
+    }
+
+    public function actor(): MorphTo
+    {
+        return $this->morphTo();
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Models")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "Audit.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createAuditor() error {
+	content := `<?php
+
+namespace App\Audit;
+
+use App\Models\Audit;
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Str;
+
+/**
+ * Writes audits with masked values and links them into a hash chain:
+ * each row's hash covers its content and the previous row's hash, so
+ * editing or deleting a row breaks every later link (audit:verify).
+ *
+ * Audits join the surrounding transaction, so a rolled-back change leaves
+ * no audit when the model shares the audits connection. The audit_chain
+ * head row stays locked from reading the previous hash until the new one
+ * commits, so concurrent writers wait for each other instead of forking.
+ */
+class Auditor
+{
+    public static function record(Model $model, string $event, ?array $old, ?array $new): ?Audit
+    {
+        $old = $old === null ? null : static::mask($model, $old);
+        $new = $new === null ? null : static::mask($model, $new);
+        $actor = auth()->user();
+        $request = app()->runningInConsole() ? null : request();
+
+        $attributes = [
+            'auditable_type' => $model->getMorphClass(),
+            'auditable_id' => (string) $model->getKey(),
+            'event' => $event,
+            'actor_type' => $actor?->getMorphClass(),
+            'actor_id' => $actor === null ? null : (string) $actor->getAuthIdentifier(),
+            'ip_address' => $request?->ip(),
+            'user_agent' => $request === null ? null : Str::limit((string) $request->userAgent(), 1020),
+            'request_id' => $request === null ? null : static::requestId($request),
+            'url' => $request === null ? null : Str::limit($request->fullUrl(), 2040),
+            'old_values' => $old,
+            'new_values' => $new,
+            'created_at' => now()->startOfSecond(),
+        ];
+
+        $db = DB::connection((new Audit)->getConnectionName());
+
+        return $db->transaction(function () use ($db, $attributes) {
+            $head = $db->table('audit_chain')->where('id', 1)->lockForUpdate()->first();
+            if ($head === null) {
+                $db->table('audit_chain')->insertOrIgnore(['id' => 1, 'hash' => null]);
+                $head = $db->table('audit_chain')->where('id', 1)->lockForUpdate()->first();
+            }
+
+            $attributes['previous_hash'] = $head->hash;
+            $attributes['hash'] = static::hash($attributes);
+
+            $audit = Audit::create($attributes);
+            $db->table('audit_chain')->where('id', 1)->update(['hash' => $attributes['hash']]);
+
+            return $audit;
+        });
+    }
+
+    /**
+     * sha256 over the previous hash and the row's content, with JSON keys
+     * sorted so the database's own JSON formatting does not matter.
+     */
+    public static function hash(array $attributes): string
+    {
+        $content = [];
+        foreach (['previous_hash', 'auditable_type', 'auditable_id', 'event', 'actor_type', 'actor_id', 'ip_address', 'user_agent', 'request_id', 'url', 'old_values', 'new_values'] as $field) {
+            $value = $attributes[$field] ?? null;
+            $content[$field] = is_array($value) ? static::sorted($value) : ($value === null ? null : (string) $value);
+        }
+        $content['created_at'] = $attributes['created_at']->format('Y-m-d H:i:s');
+
+        return hash('sha256', json_encode($content, JSON_UNESCAPED_SLASHES | JSON_UNESCAPED_UNICODE | JSON_THROW_ON_ERROR));
+    }
+
+    protected static function mask(Model $model, array $values): array
+    {
+        $masked = array_merge(config('audit.masked', []), $model->auditMasked ?? []);
+        $mask = config('audit.mask', '********');
+
+        foreach ($values as $key => $value) {
+            if (in_array($key, $masked, true) && $value !== null) {
+                $values[$key] = $mask;
+            }
+        }
+
+        return $values;
+    }
+
+    /**
+     * The request's id from the configured header, or one generated once
+     * per request.
+     */
+    protected static function requestId($request): string
+    {
+        $header = $request->headers->get(config('audit.request_id_header', 'X-Request-Id'));
+        if ($header !== null && $header !== '') {
+            return Str::limit($header, 250, '');
+        }
+
+        if (! $request->attributes->has('audit_request_id')) {
+            $request->attributes->set('audit_request_id', (string) Str::uuid());
+        }
+
+        return $request->attributes->get('audit_request_id');
+    }
+
+    protected static function sorted(array $value): array
+    {
+        ksort($value);
+        foreach ($value as $key => $item) {
+            if (is_array($item)) {
+                $value[$key] = static::sorted($item);
+            }
+        }
+
+        return $value;
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Audit")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "Auditor.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createTrait() error {
+	content := `<?php
+
+namespace App\Audit;
+
+use App\Models\Audit;
+use Illuminate\Database\Eloquent\Relations\MorphMany;
+
+/**
+ * Records creates, updates, deletes and restores in the audits table.
+ *
+ *   class Invoice extends Model
+ *   {
+ *       use Audited;
+ *
+ *       public array $auditMasked = ['iban'];      // recorded as changed, value hidden
+ *       public array $auditExclude = ['viewed_at']; // not recorded at all
+ *   }
+ *
+ * Updates record only the changed attributes. Mass updates and deletes
+ * through the query builder bypass model events and are not audited.
+ */
+trait Audited
+{
+    public static function bootAudited(): void
+    {
+        static::created(function ($model) {
+            Auditor::record($model, 'created', null, $model->auditValues($model->getAttributes()));
+        });
+
+        static::updated(function ($model) {
+            $new = $model->auditValues($model->getChanges());
+            if ($new === []) {
+                return;
+            }
+
+            $old = array_intersect_key($model->getOriginal(), $new);
+            Auditor::record($model, 'updated', $model->auditValues($old), $new);
+        });
+
+        static::deleted(function ($model) {
+            Auditor::record($model, 'deleted', $model->auditValues($model->getAttributes()), null);
+        });
+
+        if (method_exists(static::class, 'restored')) {
+            static::restored(function ($model) {
+                Auditor::record($model, 'restored', null, $model->auditValues($model->getAttributes()));
+            });
+        }
+    }
+
+    public function audits(): MorphMany
+    {
+        return $this->morphMany(Audit::class, 'auditable')->latest('id');
+    }
+
+    protected function auditValues(array $values): array
+    {
+        return array_diff_key($values, array_flip(array_merge(config('audit.exclude', []), $this->auditExclude ?? [])));
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Audit")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "Audited.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createController() error {
+	content := `<?php
+
+namespace App\Http\Controllers\Api;
+
+use App\Http\Controllers\Controller;
+use App\Models\Audit;
+use App\Traits\ApiResponse;
+use Illuminate\Http\Request;
+
+/**
+ * Read-only access to the audit trail.
+ */
+class AuditController extends Controller
+{
+    use ApiResponse;
+
+    /**
+     * All audits, newest first, filtered by ?event=, ?actor_type= and
+     * ?actor_id=, ?request_id=, ?from= and ?to= (dates).
+     */
+    public function index(Request $request)
+    {
+        return $this->paginated($this->filtered(Audit::query(), $request)->paginate());
+    }
+
+    /**
+     * The audits of one model: /api/audits/user/5 for a name in audit.models.
+     */
+    public function forModel(Request $request, string $type, string $id)
+    {
+        $class = config("audit.models.{$type}");
+        abort_if($class === null, 404, "No audited model named [{$type}].");
+
+        $query = Audit::where('auditable_type', (new $class)->getMorphClass())->where('auditable_id', $id);
+
+        return $this->paginated($this->filtered($query, $request)->paginate());
+    }
+
+    public function show(Audit $audit)
+    {
+        return $this->success($audit);
+    }
+
+    protected function filtered($query, Request $request)
+    {
+        $validated = $request->validate([
+            'event' => ['sometimes', 'string'],
+            'actor_type' => ['sometimes', 'string'],
+            'actor_id' => ['sometimes', 'string'],
+            'request_id' => ['sometimes', 'string'],
+            'from' => ['sometimes', 'date'],
+            'to' => ['sometimes', 'date'],
+        ]);
+
+        foreach (['event', 'actor_type', 'actor_id', 'request_id'] as $field) {
+            if (isset($validated[$field])) {
+                $query->where($field, $validated[$field]);
+            }
+        }
+        if (isset($validated['from'])) {
+            $query->where('created_at', '>=', $validated['from']);
+        }
+        if (isset($validated['to'])) {
+            $query->where('created_at', '<=', $validated['to']);
+        }
+
+        return $query->orderByDesc('id');
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Http/Controllers/Api")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "AuditController.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) createCommands() error {
+	verify := `<?php
+
+namespace App\Console\Commands;
+
+use App\Audit\Auditor;
+use App\Models\Audit;
+use Illuminate\Support\Facades\DB;
+
+/**
+ * Recomputes every audit's hash, checks that each row links to the one
+ * before it and that the newest row is the recorded chain head. The oldest
+ * remaining row is trusted as the anchor, since audit:prune removes the
+ * rows before it.
+ */
+class VerifyAuditChainCommand extends BaseCommand
+{
+    protected $signature = 'audit:verify';
+
+    protected $description = 'Check the audit trail hash chain for tampering';
+
+    public function handle(): int
+    {
+        $previous = false;
+        $checked = 0;
+        $broken = null;
+
+        Audit::query()->chunkById(1000, function ($audits) use (&$previous, &$checked, &$broken) {
+            foreach ($audits as $audit) {
+                $attributes = $audit->getAttributes();
+                $attributes['old_values'] = $audit->old_values;
+                $attributes['new_values'] = $audit->new_values;
+                $attributes['created_at'] = $audit->created_at;
+
+                if (! hash_equals($audit->hash, Auditor::hash($attributes))) {
+                    $broken = "audit {$audit->id} does not match its hash (changed after it was written)";
+                } elseif ($previous !== false && $audit->previous_hash !== $previous) {
+                    $broken = "audit {$audit->id} does not link to the audit before it (a row was removed or inserted)";
+                }
+                if ($broken !== null) {
+                    return false;
+                }
+
+                $previous = $audit->hash;
+                $checked++;
+            }
+        });
+
+        $head = DB::connection((new Audit)->getConnectionName())->table('audit_chain')->where('id', 1)->value('hash');
+        if ($broken === null && $head !== ($previous === false ? null : $previous)) {
+            $broken = 'the newest audit is not the chain head (audits were removed from the end)';
+        }
+
+        if ($broken !== null) {
+            $this->logError("Audit chain broken: {$broken}");
+
+            return self::FAILURE;
+        }
+
+        $this->logInfo("Audit chain intact ({$checked} audits)");
+
+        return self::SUCCESS;
+    }
+}
+`
+	prune := `<?php
+
+namespace App\Console\Commands;
+
+use App\Models\Audit;
+
+/**
+ * Deletes audits past the retention period, oldest first. The hash of the
+ * last deleted row is logged: it is what the oldest remaining row links
+ * to, so keep it to prove nothing else was removed.
+ */
+class PruneAuditsCommand extends BaseCommand
+{
+    protected $signature = 'audit:prune {--days= : Keep this many days (default audit.retention_days)}';
+
+    protected $description = 'Delete audits older than the retention period';
+
+    public function handle(): int
+    {
+        return $this->executeWithTiming(function () {
+            $days = (int) ($this->option('days') ?? config('audit.retention_days', 365));
+            $cutoff = now()->subDays($days);
+
+            $last = Audit::where('created_at', '<', $cutoff)->orderByDesc('id')->first(['id', 'hash']);
+            if ($last === null) {
+                $this->logInfo("No audits older than {$days} days");
+
+                return self::SUCCESS;
+            }
+
+            $deleted = 0;
+            do {
+                $count = Audit::where('id', '<=', $last->id)->orderBy('id')->limit(1000)->delete();
+                $deleted += $count;
+            } while ($count > 0);
+
+            $this->logInfo("Deleted {$deleted} audits up to #{$last->id}; chain anchor hash {$last->hash}");
+
+            return self::SUCCESS;
+        });
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Console/Commands")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "VerifyAuditChainCommand.php"), []byte(verify), 0644); err != nil {
+		return err
+	}
+	return ui.WriteFile(filepath.Join(dir, "PruneAuditsCommand.php"), []byte(prune), 0644)
+}
+
+func (a *AuditSetup) createServiceProvider() error {
+	content := `<?php
+
+namespace App\Providers;
+
+use Illuminate\Support\Facades\Gate;
+use Illuminate\Support\ServiceProvider;
+
+class AuditServiceProvider extends ServiceProvider
+{
+    public function boot(): void
+    {
+        // Who may read audits; adjust to your authorization rules.
+        Gate::define('view-audits', fn ($user) => method_exists($user, 'hasRole')
+            && $user->hasRole(config('audit.admin_role', 'admin')));
+    }
+}
+`
+	dir := filepath.Join(a.ProjectPath, "app/Providers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "AuditServiceProvider.php"), []byte(content), 0644)
+}
+
+func (a *AuditSetup) registerRoutes() error {
+	path := filepath.Join(a.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping audit routes\n")
+		return nil
+	}
+	if strings.Contains(string(content), "AuditController") {
+		return nil
+	}
+
+	routes := `
+Route::middleware(['auth:sanctum', 'can:view-audits'])->prefix('audits')->group(function () {
+    Route::get('/', [\App\Http\Controllers\Api\AuditController::class, 'index']);
+    Route::get('/{audit}', [\App\Http\Controllers\Api\AuditController::class, 'show'])->whereNumber('audit');
+    Route::get('/{type}/{id}', [\App\Http\Controllers\Api\AuditController::class, 'forModel']);
+});
+`
+	return ui.WriteFile(path, append(content, routes...), 0644)
+}
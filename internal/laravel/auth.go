@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 	"strings"
@@ -88,16 +88,16 @@ class AuthController extends Controller
 	}
 	path := filepath.Join(dir, "AuthController.php")
 	if a.DryRun {
-		fmt.Printf("[Dry Run] Would create AuthController: %s\n", path)
+		ui.Printf("[Dry Run] Would create AuthController: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (a *AuthSetup) setupRoutes() error {
 	path := filepath.Join(a.ProjectPath, "routes/api.php")
 	if a.DryRun {
-		fmt.Printf("[Dry Run] Would add auth routes to %s\n", path)
+		ui.Printf("[Dry Run] Would add auth routes to %s\n", path)
 		return nil
 	}
 
@@ -117,13 +117,13 @@ Route::middleware('auth:sanctum')->group(function () {
 });
 `
 	newContent := string(content) + routes
-	return os.WriteFile(path, []byte(newContent), 0644)
+	return ui.WriteFile(path, []byte(newContent), 0644)
 }
 
 func (a *AuthSetup) ensureUserHasApiTokens() error {
 	path := filepath.Join(a.ProjectPath, "app/Models/User.php")
 	if a.DryRun {
-		fmt.Printf("[Dry Run] Would ensure User model uses HasApiTokens\n")
+		ui.Printf("[Dry Run] Would ensure User model uses HasApiTokens\n")
 		return nil
 	}
 
@@ -139,5 +139,5 @@ func (a *AuthSetup) ensureUserHasApiTokens() error {
 		sContent = strings.Replace(sContent, "use HasFactory, Notifiable;", "use HasApiTokens, HasFactory, Notifiable;", 1)
 	}
 
-	return os.WriteFile(path, []byte(sContent), 0644)
+	return ui.WriteFile(path, []byte(sContent), 0644)
 }
@@ -1,12 +1,14 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 )
 
 type AuthManager struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewAuthManager(projectPath string, dryRun bool) *AuthManager {
@@ -14,7 +16,7 @@ func NewAuthManager(projectPath string, dryRun bool) *AuthManager {
 }
 
 func (m *AuthManager) AddAuth() error {
-	fmt.Println("🔐 Adding Authentication and Database features...")
+	ui.Println("🔐 Adding Authentication and Database features...")
 
 	// 1. Install Sanctum/API
 	sanctum := NewSanctumInstaller(m.ProjectPath, m.DryRun)
@@ -24,6 +26,7 @@ func (m *AuthManager) AddAuth() error {
 
 	// 2. Pagination & Support
 	pagination := NewPaginationSetup(m.ProjectPath, m.DryRun)
+	pagination.Options = m.Options
 	if err := pagination.Setup(); err != nil {
 		return err
 	}
@@ -40,6 +43,6 @@ func (m *AuthManager) AddAuth() error {
 		return err
 	}
 
-	fmt.Println("\n✅ Authentication and API features added successfully!")
+	ui.Println("\n✅ Authentication and API features added successfully!")
 	return nil
 }
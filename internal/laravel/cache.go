@@ -1,9 +1,8 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
-	"os/exec"
 	"path/filepath"
 )
 
@@ -18,17 +17,14 @@ func NewCacheSetup(projectPath string, dryRun bool) *CacheSetup {
 
 func (c *CacheSetup) Setup() error {
 	if c.DryRun {
-		fmt.Printf("[Dry Run] Would setup caching layer\n")
+		ui.Printf("[Dry Run] Would setup caching layer\n")
 		return nil
 	}
 
-	fmt.Println("💾 Setting up caching layer...")
+	ui.Println("💾 Setting up caching layer...")
 
 	// Install predis for Redis support
-	fmt.Println("📦 Installing predis/predis...")
-	cmd := exec.Command("composer", "require", "predis/predis", "--with-all-dependencies")
-	cmd.Dir = c.ProjectPath
-	_ = cmd.Run()
+	_ = composerRequire(c.ProjectPath, false, "predis/predis")
 
 	if err := c.createCacheService(); err != nil {
 		return err
@@ -147,7 +143,7 @@ class CacheService
 `
 	dir := filepath.Join(c.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "CacheService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "CacheService.php"), []byte(content), 0644)
 }
 
 func (c *CacheSetup) createCacheableTrait() error {
@@ -246,5 +242,5 @@ trait Cacheable
 `
 	dir := filepath.Join(c.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "Cacheable.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "Cacheable.php"), []byte(content), 0644)
 }
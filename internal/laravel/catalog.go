@@ -0,0 +1,253 @@
+package laravel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FeatureInfo records what a feature leaves behind in a project, so apply
+// can tell whether it is installed and undo it.
+type FeatureInfo struct {
+	Name        string
+	Group       string // auth, platform, infra or enterprise
+	Description string
+	Requires    []string // features whose files or packages it builds on
+	After       []string // features it runs after when both are selected, without needing them
+	Packages    []string // composer packages it requires
+	DevPackages []string // composer packages it requires with --dev
+	Extensions  []string // PHP extensions it needs; "gd|imagick" means either
+	Suggests    []string // PHP extensions it runs better with
+	Files       []string // files it creates, relative to the project root
+	Edits       []string // shared files it modifies; left for manual cleanup on removal
+	Marker      string   // text in the first Edits file that shows it is installed
+	Fixed       bool     // cannot be removed automatically
+	Exclusive   bool     // runs composer or artisan commands that change shared state; never runs alongside other steps
+}
+
+var Catalog = []FeatureInfo{
+	{Name: "auth", Group: "auth", Description: "Sanctum/Passport + base auth controller", Files: []string{"app/Http/Controllers/Api/AuthController.php"}, Edits: []string{"routes/api.php", "app/Models/User.php", "app/Support/Api/Envelope.php"}, Fixed: true},
+
+	{Name: "roles", Group: "platform", Description: "Spatie permissions and roles", Packages: []string{"spatie/laravel-permission"}, Edits: []string{"app/Models/User.php"}, Exclusive: true},
+	{Name: "media", Group: "platform", Description: "Spatie MediaLibrary + SpatieMediaService", Packages: []string{"spatie/laravel-medialibrary"}, Extensions: []string{"gd|imagick", "exif"}, Files: []string{"app/Traits/HasMedia.php", "app/Services/SpatieMediaService.php"}, Exclusive: true},
+	{Name: "activity-log", Group: "platform", Description: "Spatie ActivityLog", Packages: []string{"spatie/laravel-activitylog"}, Files: []string{"app/Support/Concerns/InteractsWithActivityLog.php"}, Exclusive: true},
+	{Name: "search", Group: "platform", Description: "Laravel Scout + Typesense", Packages: []string{"laravel/scout", "typesense/typesense-php", "typesense/laravel-scout-typesense-driver"}, Files: []string{"config/scout.php"}, Exclusive: true},
+	{Name: "reporting", Group: "platform", Description: "Excel (Maatwebsite) + PDF (dompdf)", Packages: []string{"dompdf/dompdf", "maatwebsite/excel"}, Extensions: []string{"gd", "zip", "xmlwriter", "iconv"}, Suggests: []string{"intl"}, Exclusive: true},
+	{Name: "traits", Group: "platform", Description: "Common API traits (Api, HandlesPagination, Auditable)", Files: []string{"app/Traits/Api.php", "app/Traits/HandlesPagination.php", "app/Traits/Auditable.php"}, Edits: []string{"app/Support/Api/Envelope.php"}},
+	{Name: "middleware", Group: "platform", Description: "DBTransaction + ForceJson middleware", Files: []string{"app/Http/Middleware/DBTransaction.php", "app/Http/Middleware/ForceJson.php"}},
+	{Name: "exports", Group: "platform", Description: "Base Export/Import classes for Excel", Requires: []string{"reporting"}, Files: []string{"app/Exports/BaseExport.php", "app/Imports/BaseImport.php"}},
+	{Name: "jobs", Group: "platform", Description: "Base Job class with queue support", Suggests: []string{"pcntl", "redis"}, Files: []string{"app/Jobs/BaseJob.php"}},
+	{Name: "rules", Group: "platform", Description: "Custom validation rules (Base64Image, PhoneNumber, ...)", Files: []string{"app/Rules/Base64Image.php", "app/Rules/PhoneNumber.php", "app/Rules/ScopedUnique.php", "app/Rules/StrongPassword.php", "app/Rules/TimeFormat.php"}},
+	{Name: "responses", Group: "platform", Description: "API response helpers + exception handler", Files: []string{"app/Traits/ApiResponse.php", "app/Exceptions/Handler.php"}, Edits: []string{"app/Support/Api/Envelope.php"}},
+	{Name: "notifications", Group: "platform", Description: "Notifications system with services", Files: []string{"app/Notifications/BaseNotification.php", "app/Notifications/WelcomeNotification.php", "app/Services/NotificationService.php"}, Exclusive: true},
+	{Name: "scheduler", Group: "platform", Description: "Console commands + scheduling", Files: []string{"app/Console/Commands/BaseCommand.php", "app/Console/Commands/CleanupCommand.php", "app/Console/Commands/HealthCheckCommand.php"}},
+	{Name: "cache", Group: "platform", Description: "Caching layer with Redis + Cacheable trait", Packages: []string{"predis/predis"}, Suggests: []string{"redis"}, Files: []string{"app/Services/CacheService.php", "app/Traits/Cacheable.php"}, Exclusive: true},
+	{Name: "versioning", Group: "platform", Description: "API versioning (v1, v2 structure)", Requires: []string{"responses"}, Files: []string{"app/Http/Controllers/Api/V1/V1Controller.php", "app/Http/Controllers/Api/V2/V2Controller.php"}, Edits: []string{"bootstrap/app.php", "routes/api.php"}},
+	{Name: "softdeletes", Group: "platform", Description: "Soft deletes + trash management", Files: []string{"app/Traits/HasSoftDeletes.php", "app/Services/TrashService.php"}},
+	{Name: "storage", Group: "platform", Description: "File storage service + controller", Requires: []string{"responses"}, Files: []string{"app/Http/Controllers/Api/FileController.php", "app/Services/FileService.php"}, Edits: []string{"routes/api.php"}},
+	{Name: "events", Group: "platform", Description: "Events & listeners scaffolding", Requires: []string{"notifications"}, Files: []string{"app/Events/BaseEvent.php", "app/Events/UserRegistered.php", "app/Listeners/BaseListener.php", "app/Listeners/SendWelcomeEmail.php"}},
+	{Name: "logging", Group: "platform", Description: "Request logging + Slack notifications", Files: []string{"app/Http/Middleware/LogRequests.php", "app/Services/LogService.php", "app/Logging/SlackLogHandler.php"}},
+	{Name: "idempotency", Group: "platform", Description: "Idempotency-Key middleware with replay, locks and pruning", Requires: []string{"cache", "scheduler"}, Files: []string{"config/idempotency.php", "app/Http/Middleware/EnsureIdempotency.php", "app/Console/Commands/PruneIdempotencyKeysCommand.php"}, Edits: []string{"bootstrap/app.php", "routes/console.php"}},
+	{Name: "webhooks", Group: "platform", Description: "Signed outgoing webhooks with subscriptions, delivery log and replay", Requires: []string{"jobs", "events", "responses"}, Files: []string{"config/webhooks.php", "database/migrations/2025_01_01_000100_create_webhook_endpoints_table.php", "database/migrations/2025_01_01_000101_create_webhook_deliveries_table.php", "app/Models/WebhookEndpoint.php", "app/Models/WebhookDelivery.php", "app/Webhooks/ShouldBroadcastWebhook.php", "app/Webhooks/BroadcastsWebhook.php", "app/Webhooks/WebhookDispatcher.php", "app/Jobs/DeliverWebhookJob.php", "app/Providers/WebhookServiceProvider.php", "app/Http/Controllers/Api/WebhookEndpointController.php"}, Edits: []string{"routes/api.php", "bootstrap/providers.php", "routes/console.php"}},
+	{Name: "webhook-receiver", Group: "platform", Description: "Incoming webhooks: signature checks, raw storage, dedup and queued handlers", Requires: []string{"jobs", "responses"}, Files: []string{"config/webhook-receiver.php", "database/migrations/2025_01_01_000110_create_webhook_calls_table.php", "app/Models/WebhookCall.php", "app/Webhooks/Receiving/SignatureVerifier.php", "app/Webhooks/Receiving/HmacSignature.php", "app/Webhooks/Receiving/StripeSignature.php", "app/Webhooks/Receiving/GitHubSignature.php", "app/Jobs/Webhooks/WebhookHandlerJob.php", "app/Http/Controllers/Api/WebhookReceiverController.php"}, Edits: []string{"routes/api.php", "routes/console.php"}},
+	{Name: "outbox", Group: "platform", Description: "Transactional outbox with relay worker and pluggable publishers", Requires: []string{"events", "scheduler"}, Files: []string{"config/outbox.php", "database/migrations/2025_01_01_000120_create_outbox_messages_table.php", "app/Models/OutboxMessage.php", "app/Outbox/Outbox.php", "app/Outbox/RecordsToOutbox.php", "app/Outbox/ShouldPublishViaOutbox.php", "app/Outbox/PublishesViaOutbox.php", "app/Outbox/Publishers/Publisher.php", "app/Outbox/Publishers/QueuePublisher.php", "app/Outbox/Publishers/HttpPublisher.php", "app/Outbox/Publishers/LogPublisher.php", "app/Console/Commands/OutboxRelayCommand.php", "app/Providers/OutboxServiceProvider.php"}, Edits: []string{"bootstrap/providers.php", "routes/console.php"}},
+	{Name: "messaging", Group: "platform", Description: "RabbitMQ, Kafka or Redis Streams publishers and consumers with dead letters", Requires: []string{"scheduler"}, Suggests: []string{"pcntl"}, Files: []string{"config/messaging.php", "app/Messaging/Message.php", "app/Messaging/Broker.php", "app/Messaging/Drivers/RabbitMqBroker.php", "app/Messaging/Drivers/KafkaBroker.php", "app/Messaging/Drivers/RedisStreamsBroker.php", "app/Messaging/BasePublisher.php", "app/Messaging/BaseConsumer.php", "app/Console/Commands/ConsumeMessagesCommand.php", "app/Providers/MessagingServiceProvider.php", "app/Outbox/Publishers/BrokerPublisher.php"}, Edits: []string{"bootstrap/providers.php", "docker-compose.yml", "config/outbox.php"}, Exclusive: true},
+	{Name: "realtime", Group: "platform", Description: "Laravel Reverb broadcasting with Sanctum channel auth and a websocket probe", Requires: []string{"auth"}, Packages: []string{"laravel/reverb"}, Suggests: []string{"pcntl"}, Files: []string{"config/reverb.php", "routes/channels.php", "app/Http/Controllers/Api/RealtimeHealthController.php"}, Edits: []string{"bootstrap/app.php", "routes/api.php", ".env", ".env.example", "docker-compose.yml"}, Exclusive: true},
+	{Name: "feature-flags", Group: "platform", Description: "Laravel Pennant flags with an admin API, route middleware and test helpers", Requires: []string{"auth", "responses"}, After: []string{"quality", "tenancy"}, Packages: []string{"laravel/pennant"}, Files: []string{"config/pennant.php", "config/feature-flags.php", "app/Support/FeatureFlags/FeatureScope.php", "app/Http/Middleware/RequireFeature.php", "app/Http/Controllers/Api/FeatureFlagController.php", "app/Providers/FeatureFlagServiceProvider.php", "tests/Support/FeatureFlags.php"}, Edits: []string{"routes/api.php", "bootstrap/app.php", "bootstrap/providers.php", "tests/Pest.php"}, Exclusive: true},
+	{Name: "audit", Group: "platform", Description: "Hash-chained audit trail with masked diffs, a read-only API and retention", Requires: []string{"auth", "responses", "scheduler"}, Files: []string{"config/audit.php", "database/migrations/2025_01_01_000130_create_audits_table.php", "app/Models/Audit.php", "app/Audit/Auditor.php", "app/Audit/Audited.php", "app/Http/Controllers/Api/AuditController.php", "app/Console/Commands/VerifyAuditChainCommand.php", "app/Console/Commands/PruneAuditsCommand.php", "app/Providers/AuditServiceProvider.php"}, Edits: []string{"routes/api.php", "bootstrap/providers.php", "routes/console.php"}},
+
+	{Name: "docker", Group: "infra", Description: "Dev & prod Dockerfiles + Compose", Files: []string{"docker/Dockerfile", "docker/Dockerfile.prod", "docker-compose.yml"}},
+	{Name: "security", Group: "infra", Description: "Force JSON middleware + env validation", Files: []string{"app/Http/Middleware/ForceJsonResponse.php", "app/Support/Env/EnvValidator.php"}},
+	{Name: "rate-limit", Group: "infra", Description: "API throttling", Suggests: []string{"redis"}, Edits: []string{"app/Providers/AppServiceProvider.php"}, Marker: "RateLimiter::for('api'"},
+	{Name: "health", Group: "infra", Description: "Health & readiness endpoints", Files: []string{"app/Http/Controllers/Api/HealthController.php"}, Edits: []string{"routes/api.php"}, Exclusive: true},
+
+	{Name: "quality", Group: "enterprise", Description: "Pint + PHPStan + Pest", DevPackages: []string{"laravel/pint", "phpstan/phpstan", "nunomaduro/larastan", "pestphp/pest", "pestphp/pest-plugin-laravel"}, Files: []string{"phpstan.neon"}, Exclusive: true},
+	{Name: "pro-arch", Group: "enterprise", Description: "Spatie Data + action classes", Packages: []string{"spatie/laravel-data"}, Files: []string{"app/Support/Actions/AsAction.php"}, Exclusive: true},
+	{Name: "docs-pro", Group: "enterprise", Description: "Automated OpenAPI docs (Scramble)", Packages: []string{"dedoc/scramble"}, Exclusive: true},
+	{Name: "ci", Group: "enterprise", Description: "GitHub Actions + GitLab CI workflows", Files: []string{".github/workflows/ci.yml", ".gitlab-ci.yml"}},
+	{Name: "monitoring", Group: "enterprise", Description: "Laravel Pulse", Packages: []string{"laravel/pulse"}, Suggests: []string{"pcntl"}, Exclusive: true},
+	{Name: "tenancy", Group: "enterprise", Description: "Multi-tenancy (stancl/tenancy)", Packages: []string{"stancl/tenancy"}, Files: []string{"config/tenancy.php", "routes/tenant.php"}, Exclusive: true},
+	{Name: "helpers", Group: "enterprise", Description: "Global helpers.php with autoloading", Files: []string{"app/helpers.php"}, Edits: []string{"composer.json"}, Exclusive: true},
+}
+
+// Bundles expand the group names accepted by "add" and the config lists.
+var Bundles = map[string][]string{
+	"platform": {
+		"roles", "media", "activity-log", "search", "reporting", "traits",
+		"middleware", "exports", "jobs", "rules", "responses", "notifications",
+		"scheduler", "cache", "versioning", "softdeletes", "storage", "events", "logging",
+	},
+	"infra":      {"docker", "security", "rate-limit", "health"},
+	"enterprise": {"quality", "pro-arch", "docs-pro", "ci", "monitoring", "helpers"},
+}
+
+var aliases = map[string]string{"activity": "activity-log"}
+
+// LookupFeature finds a catalog entry by name or alias.
+func LookupFeature(name string) (FeatureInfo, bool) {
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	for _, f := range Catalog {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FeatureInfo{}, false
+}
+
+// ExpandFeatures resolves aliases and bundles to catalog names, keeping
+// order and dropping duplicates. Names not in the catalog (plugin
+// features) pass through unchanged.
+func ExpandFeatures(names []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		expanded := []string{name}
+		if bundle, ok := Bundles[name]; ok {
+			expanded = bundle
+		}
+		for _, n := range expanded {
+			if alias, ok := aliases[n]; ok {
+				n = alias
+			}
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// WithDependencies adds the features each name requires, placing every
+// dependency before the first feature that needs it.
+func WithDependencies(names []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	var visit func(string)
+	visit = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		if f, ok := LookupFeature(name); ok {
+			for _, dep := range f.Requires {
+				visit(dep)
+			}
+		}
+		out = append(out, name)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return out
+}
+
+// MissingDependencies lists, in install order, the features that names
+// require and that are neither among names nor installed in the project,
+// so "add" can install them first.
+func MissingDependencies(projectPath string, names []string) []string {
+	packages, _ := composerPackages(projectPath)
+	recorded, _ := installedFeatures(projectPath)
+	asked := map[string]bool{}
+	for _, name := range ExpandFeatures(names) {
+		asked[name] = true
+	}
+
+	var missing []string
+	for _, name := range WithDependencies(ExpandFeatures(names)) {
+		f, ok := LookupFeature(name)
+		if !ok || asked[name] || recorded[name] || f.Installed(projectPath, packages) {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	return missing
+}
+
+// requireFeatures fails, naming the dependency, when a feature this one
+// builds on is not installed, instead of generating classes that extend
+// a base class the project does not have.
+func requireFeatures(projectPath, feature string, deps ...string) error {
+	for _, dep := range deps {
+		if f, ok := LookupFeature(dep); ok && !f.Installed(projectPath, nil) {
+			return fmt.Errorf("%s needs the %s feature; run 'laravelboot add %s' first", feature, dep, dep)
+		}
+	}
+	return nil
+}
+
+// Configurable drops the fixed features, such as auth, that every project
+// gets from its own config setting and that feature lists cannot name.
+func Configurable(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if f, ok := LookupFeature(name); ok && f.Fixed {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// Installed reports whether all of the feature's packages, files and
+// marker are present in the project. One of them alone proves nothing:
+// the skeleton already requires laravel/pint, and routes/channels.php is
+// not only made by realtime. A nil packages map checks files only.
+func (f FeatureInfo) Installed(projectPath string, packages map[string]bool) bool {
+	evidence := 0
+	if packages != nil {
+		for _, pkg := range f.allPackages() {
+			if !packages[pkg] {
+				return false
+			}
+			evidence++
+		}
+	}
+	for _, file := range f.Files {
+		if _, err := os.Stat(filepath.Join(projectPath, file)); err != nil {
+			return false
+		}
+		evidence++
+	}
+	if f.Marker != "" && len(f.Edits) > 0 {
+		content, err := os.ReadFile(filepath.Join(projectPath, f.Edits[0]))
+		if err != nil || !strings.Contains(string(content), f.Marker) {
+			return false
+		}
+		evidence++
+	}
+	return evidence > 0
+}
+
+func (f FeatureInfo) allPackages() []string {
+	return append(append([]string{}, f.Packages...), f.DevPackages...)
+}
+
+// composerPackages lists everything in composer.json's require and
+// require-dev sections.
+func composerPackages(projectPath string) (map[string]bool, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, "composer.json"))
+	if err != nil {
+		return nil, err
+	}
+	var composer struct {
+		Require    map[string]string `json:"require"`
+		RequireDev map[string]string `json:"require-dev"`
+	}
+	if err := json.Unmarshal(data, &composer); err != nil {
+		return nil, err
+	}
+
+	packages := map[string]bool{}
+	for name := range composer.Require {
+		packages[name] = true
+	}
+	for name := range composer.RequireDev {
+		packages[name] = true
+	}
+	return packages, nil
+}
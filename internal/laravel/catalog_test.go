@@ -0,0 +1,69 @@
+package laravel
+
+import (
+	"laravelboot/internal/config"
+	"testing"
+)
+
+// Every feature init can be asked for must still validate once its
+// dependencies are expanded the way init saves them.
+func TestDependenciesExpandToValidConfig(t *testing.T) {
+	for _, f := range Catalog {
+		if f.Fixed {
+			continue
+		}
+		c := config.DefaultConfig()
+		c.Features, c.Infra, c.Enterprise = nil, nil, nil
+		list := Configurable(WithDependencies([]string{f.Name}))
+		switch f.Group {
+		case "infra":
+			c.Infra = list
+		case "enterprise":
+			c.Enterprise = list
+		default:
+			c.Features = list
+		}
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: %v", f.Name, err)
+		}
+	}
+}
+
+// feature-flags edits files that quality and tenancy create, so it must
+// run after them even when listed first.
+func TestWavesRunAfterOrderedFeatures(t *testing.T) {
+	waves := NewOrchestrator(t.TempDir(), true).waves([]string{"feature-flags", "responses", "quality", "tenancy"})
+	wave := map[string]int{}
+	for i, names := range waves {
+		for _, name := range names {
+			wave[name] = i
+		}
+	}
+	for _, before := range []string{"quality", "tenancy"} {
+		if wave["feature-flags"] <= wave[before] {
+			t.Errorf("feature-flags runs in wave %d, not after %s (wave %d)", wave["feature-flags"], before, wave[before])
+		}
+	}
+}
+
+// Features that run composer or artisan must not share a wave: when the
+// batched require fails, each runs its own composer require against the
+// same composer.json and composer.lock.
+func TestWavesSerialiseComposerFeatures(t *testing.T) {
+	for _, wave := range NewOrchestrator(t.TempDir(), true).waves([]string{"roles", "media", "traits", "search"}) {
+		composer := 0
+		for _, name := range wave {
+			if f, _ := LookupFeature(name); len(f.allPackages()) > 0 {
+				composer++
+			}
+		}
+		if composer > 0 && len(wave) > 1 {
+			t.Errorf("wave %v runs a composer-backed feature alongside others", wave)
+		}
+	}
+	for _, f := range Catalog {
+		if len(f.allPackages()) > 0 && !f.Exclusive {
+			t.Errorf("%s requires composer packages but is not Exclusive", f.Name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package laravel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"laravelboot/internal/config"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInterrupted is returned when a run stops because the user pressed
+// Ctrl-C; the steps finished so far are in the checkpoint.
+var ErrInterrupted = errors.New("interrupted")
+
+// Checkpoint records which steps of a "new" run have finished, with the
+// config the run was started with, so an interrupted run can resume where
+// it stopped. It is saved after every step and removed once the run
+// succeeds. It is safe for concurrent steps.
+type Checkpoint struct {
+	Name       string         `yaml:"name"`
+	ConfigPath string         `yaml:"config_path"`
+	Config     *config.Config `yaml:"config"`
+	Done       []string       `yaml:"done"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// CheckpointPath is where a "new" run keeps its checkpoint.
+func CheckpointPath(projectPath string) string {
+	return filepath.Join(projectPath, ".laravelboot", "checkpoint.yaml")
+}
+
+func NewCheckpoint(projectPath, name, configPath string, conf *config.Config) *Checkpoint {
+	return &Checkpoint{Name: name, ConfigPath: configPath, Config: conf, path: CheckpointPath(projectPath)}
+}
+
+// LoadCheckpoint reads the checkpoint of the project at projectPath.
+func LoadCheckpoint(projectPath string) (*Checkpoint, error) {
+	path := CheckpointPath(projectPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{path: path}
+	if err := yaml.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if cp.Config == nil {
+		return nil, fmt.Errorf("%s: no config recorded", path)
+	}
+	return cp, nil
+}
+
+// IsDone reports whether step finished in an earlier run.
+func (c *Checkpoint) IsDone(step string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.Done {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkDone records step as finished and saves the checkpoint.
+func (c *Checkpoint) MarkDone(step string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Done = append(c.Done, step)
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// Remove deletes the checkpoint once the run has finished.
+func (c *Checkpoint) Remove() error {
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// interrupted reports whether ctx was cancelled by Ctrl-C.
+func interrupted(ctx context.Context) bool {
+	return ctx != nil && ctx.Err() != nil
+}
@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -59,13 +59,13 @@ jobs:
 `
 	dir := filepath.Join(c.ProjectPath, ".github/workflows")
 	if c.DryRun {
-		fmt.Printf("[Dry Run] Would create GitHub Actions workflow: %s\n", filepath.Join(dir, "ci.yml"))
+		ui.Printf("[Dry Run] Would create GitHub Actions workflow: %s\n", filepath.Join(dir, "ci.yml"))
 		return nil
 	}
 
 	os.MkdirAll(dir, 0755)
 	path := filepath.Join(dir, "ci.yml")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (c *CicdSetup) SetupGitLab() error {
@@ -91,9 +91,9 @@ test:
 `
 	path := filepath.Join(c.ProjectPath, ".gitlab-ci.yml")
 	if c.DryRun {
-		fmt.Printf("[Dry Run] Would create GitLab CI configuration: %s\n", path)
+		ui.Printf("[Dry Run] Would create GitLab CI configuration: %s\n", path)
 		return nil
 	}
 
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
@@ -0,0 +1,43 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/ui"
+	"os/exec"
+	"strings"
+)
+
+// composerRequire installs the packages composer.json does not list yet, in
+// one composer call. Setups use it for their own packages, so a package the
+// orchestrator already installed in a batch is not resolved again.
+func composerRequire(projectPath string, dev bool, packages ...string) error {
+	installed, err := composerPackages(projectPath)
+	if err != nil {
+		installed = map[string]bool{}
+	}
+	var missing []string
+	for _, pkg := range packages {
+		if !installed[pkg] {
+			installed[pkg] = true
+			missing = append(missing, pkg)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	args := []string{"require"}
+	if dev {
+		args = append(args, "--dev")
+	}
+	args = append(args, missing...)
+	args = append(args, "--with-all-dependencies")
+
+	ui.Printf("📦 Installing %s...\n", strings.Join(missing, ", "))
+	cmd := exec.Command("composer", args...)
+	cmd.Dir = projectPath
+	if output, err := ui.Run(cmd); err != nil {
+		return fmt.Errorf("failed to install %s: %v\nOutput: %s", strings.Join(missing, " "), err, string(output))
+	}
+	return nil
+}
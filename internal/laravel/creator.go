@@ -1,125 +1,265 @@
 package laravel
 
 import (
+	"errors"
 	"fmt"
 	"laravelboot/internal/config"
 	"laravelboot/internal/docs"
 	"laravelboot/internal/plugins"
 	"laravelboot/internal/presets"
+	"laravelboot/internal/ui"
 	"os"
+	"path/filepath"
 )
 
 type Creator struct {
-	Name   string
-	DryRun bool
-	Config *config.Config
+	Name       string
+	DryRun     bool
+	Config     *config.Config
+	ConfigPath string
+
+	// Policy decides whether a failed step stops the run. By default the
+	// remaining steps still run and Create fails at the end.
+	Policy Policy
+
+	// Checkpoint is the progress of an earlier run to resume, if any.
+	Checkpoint *Checkpoint
+
+	// SkipChecks skips the environment doctor before the run.
+	SkipChecks bool
 }
 
-func NewCreator(name string, preset string, dryRun bool) *Creator {
-	// Try to load config if it exists
-	conf, _ := config.LoadConfig(".laravelboot.yaml")
-	if conf == nil {
-		if preset != "" {
-			conf = presets.GetPreset(preset)
-		} else {
-			conf = config.DefaultConfig()
-		}
+// NewCreator resolves the project config from the preset, the config file
+// and CLI overrides, in that order.
+func NewCreator(name, preset, configPath string, overrides *config.Config, dryRun bool) (*Creator, error) {
+	conf, err := presets.Compose(preset, configPath, overrides)
+	if err != nil {
+		return nil, err
 	}
 	conf.ProjectName = name
 
 	return &Creator{
-		Name:   name,
-		DryRun: dryRun,
-		Config: conf,
-	}
+		Name:       name,
+		DryRun:     dryRun,
+		Config:     conf,
+		ConfigPath: configPath,
+	}, nil
 }
 
-func (c *Creator) Create() error {
-	fmt.Printf("🚀 Creating new Laravel API project: %s (Config-Driven)\n", c.Name)
+// ResumeCreator continues the interrupted or failed run that created the
+// project name in the current directory, with the config it started with.
+func ResumeCreator(name string, dryRun bool) (*Creator, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	cp, err := LoadCheckpoint(filepath.Join(cwd, name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s has no unfinished run to resume", name)
+	} else if err != nil {
+		return nil, err
+	}
+	cp.Config.ProjectName = name
 
-	installer := NewInstaller(c.DryRun)
-	if err := installer.CheckDependencies(); err != nil {
+	return &Creator{
+		Name:       name,
+		DryRun:     dryRun,
+		Config:     cp.Config,
+		ConfigPath: cp.ConfigPath,
+		Checkpoint: cp,
+	}, nil
+}
+
+func (c *Creator) Create() (err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
 		return err
 	}
+	projectPath := fmt.Sprintf("%s/%s", cwd, c.Name)
 
-	if err := installer.CreateProject(c.Name); err != nil {
+	configDir, err := filepath.Abs(filepath.Dir(c.ConfigPath))
+	if err != nil {
 		return err
 	}
 
-	projectPath, err := os.Getwd()
+	if c.Checkpoint != nil {
+		ui.Printf("⏯️ Resuming Laravel API project: %s (%d steps already done)\n", c.Name, len(c.Checkpoint.Done))
+	} else {
+		ui.Printf("🚀 Creating new Laravel API project: %s (Config-Driven)\n", c.Name)
+		if _, err := os.Stat(projectPath); err == nil {
+			if _, err := os.Stat(CheckpointPath(projectPath)); err == nil {
+				return fmt.Errorf("%s was not finished; run 'laravelboot new %s --resume' to continue", c.Name, c.Name)
+			}
+			return fmt.Errorf("directory %s already exists", projectPath)
+		}
+		if !c.DryRun {
+			c.Checkpoint = NewCheckpoint(projectPath, c.Name, filepath.Join(configDir, filepath.Base(c.ConfigPath)), c.Config)
+		}
+	}
+	c.Policy.Checkpoint = c.Checkpoint
+	defer func() {
+		switch {
+		case err == nil && c.Checkpoint != nil:
+			if rerr := c.Checkpoint.Remove(); rerr != nil {
+				ui.Warnf("⚠️ Warning: could not remove the checkpoint: %v\n", rerr)
+			}
+		case err != nil && c.Checkpoint != nil && c.Checkpoint.IsDone("project"):
+			ui.Printf("💡 Run 'laravelboot new %s --resume' to pick up where it stopped.\n", c.Name)
+		}
+	}()
+	pluginMgr, err := plugins.LoadPluginManager(c.Config, configDir, c.DryRun)
 	if err != nil {
 		return err
 	}
-	projectPath = fmt.Sprintf("%s/%s", projectPath, c.Name)
+	if err := c.Config.Validate(pluginMgr.FeatureNames()...); err != nil {
+		return err
+	}
+	if c.Policy.Report == nil {
+		c.Policy.Report = NewReport("new " + c.Name)
+	}
+	hooked := func(name string, fn func() error) error {
+		return pluginMgr.RunStep(name, c.Config, projectPath, fn)
+	}
+	step := func(name string, fn func() error) error {
+		return c.Policy.track(name, func() error { return hooked(name, fn) })
+	}
+	// optional runs a step the project can do without: its failure stops
+	// the run only with --fail-fast.
+	optional := func(name string, fn func() error) error {
+		if err := step(name, fn); err != nil {
+			if errors.Is(err, ErrInterrupted) {
+				return err
+			}
+			if c.Policy.FailFast {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			ui.Warnf("⚠️ Warning: %s failed: %v\n", name, err)
+		}
+		return nil
+	}
+
+	if err := pluginMgr.Emit(plugins.Event{Name: plugins.EventBeforeCreate, ProjectPath: projectPath, Config: c.Config}); err != nil {
+		return err
+	}
+
+	// A dry run changes nothing, so it only reports what would fail.
+	if !c.SkipChecks {
+		checks := NewDoctor(cwd, c.Config).Run()
+		PrintChecks(checks, true)
+		if err := ChecksErr(checks); err != nil && !c.DryRun {
+			return err
+		}
+	}
+
+	installer := NewInstaller(c.DryRun)
+
+	if err := step("project", func() error { return installer.CreateProject(c.Name) }); err != nil {
+		return err
+	}
 
 	// Base Architecture
 	arch := NewArchitecture(projectPath, c.DryRun)
-	if err := arch.SetupFolders(); err != nil {
+	if err := optional("architecture", arch.SetupFolders); err != nil {
 		return err
 	}
 
 	// API Setup
 	api := NewApiSetup(projectPath, c.DryRun)
-	if err := api.Configure(); err != nil {
+	api.Options = c.Config.Options
+	if err := optional("api", api.Configure); err != nil {
 		return err
 	}
 
+	// Features, infra and enterprise lists may also name plugin features;
+	// those run after the built-in ones.
+	var names []string
+	names = append(names, c.Config.Features...)
+	names = append(names, c.Config.Infra...)
+	names = append(names, c.Config.Enterprise...)
+	var builtin, pluginFeatures []string
+	// Dependencies come first, so no feature builds on a missing one.
+	for _, name := range Configurable(WithDependencies(ExpandFeatures(names))) {
+		if pluginMgr.HasFeature(name) {
+			pluginFeatures = append(pluginFeatures, name)
+		} else {
+			builtin = append(builtin, name)
+		}
+	}
+
+	// One composer resolution for every selected feature, including the
+	// core query builder. A failed batch is not a failed step: each
+	// feature then installs its own packages.
+	orchestrator := NewOrchestrator(projectPath, c.DryRun)
+	orchestrator.Options = c.Config.Options
+	orchestrator.Step = hooked
+	orchestrator.Policy = c.Policy
+	step("composer", func() error {
+		if err := orchestrator.Require(builtin, "spatie/laravel-query-builder"); err != nil {
+			ui.Warnf("⚠️ Warning: batched composer require failed, each feature will install its own packages: %v\n", err)
+		}
+		return nil
+	})
+
 	// Spatie Query Builder (Core in Phase 1)
 	spatie := NewSpatieQueryBuilder(projectPath, c.DryRun)
-	if err := spatie.Install(); err != nil {
-		return err
-	}
-	if err := spatie.CreateExample(); err != nil {
+	spatie.Options = c.Config.Options
+	if err := optional("query-builder", func() error {
+		if err := spatie.Install(); err != nil {
+			return err
+		}
+		return spatie.CreateExample()
+	}); err != nil {
 		return err
 	}
 
 	// Apply Auth from config
 	if c.Config.Auth != "" {
 		authMgr := NewAuthManager(projectPath, c.DryRun)
-		if err := authMgr.AddAuth(); err != nil {
-			fmt.Printf("⚠️ Warning: auth setup failed: %v\n", err)
+		authMgr.Options = c.Config.Options
+		if err := optional("auth", authMgr.AddAuth); err != nil {
+			return err
 		}
 	}
 
-	// Apply features from config
-	platform := NewPlatformManager(projectPath, c.DryRun)
-	for _, feature := range c.Config.Features {
-		fmt.Printf("📦 Adding feature: %s\n", feature)
-		if err := platform.RunStep(feature); err != nil {
-			fmt.Printf("⚠️ Warning: feature %s failed: %v\n", feature, err)
-		}
+	// Apply features from config, in parallel where they touch different files
+	failed := orchestrator.Install(builtin)
+	if interrupted(c.Policy.Context) {
+		return ErrInterrupted
 	}
-
-	// Apply infra from config
-	infra := NewInfraManager(projectPath, c.DryRun)
-	for _, step := range c.Config.Infra {
-		fmt.Printf("🛡️ Adding infra: %s\n", step)
-		if err := infra.RunStep(step); err != nil {
-			fmt.Printf("⚠️ Warning: infra %s failed: %v\n", step, err)
+	for _, name := range builtin {
+		err, ok := failed[name]
+		switch {
+		case !ok || errors.Is(err, ErrSkipped):
+		case c.Policy.FailFast:
+			return fmt.Errorf("%s: %v", stepName(name), err)
+		default:
+			ui.Warnf("⚠️ Warning: feature %s failed: %v\n", name, err)
 		}
 	}
-
-	// Apply enterprise from config
-	enterprise := NewEnterpriseManager(projectPath, c.DryRun)
-	for _, step := range c.Config.Enterprise {
-		fmt.Printf("👑 Adding enterprise feature: %s\n", step)
-		if err := enterprise.RunStep(step); err != nil {
-			fmt.Printf("⚠️ Warning: enterprise feature %s failed: %v\n", step, err)
+	for _, name := range pluginFeatures {
+		ui.Printf("🧩 Adding plugin feature: %s\n", name)
+		if err := optional("feature:"+name, func() error { return pluginMgr.RunFeature(name, c.Config, projectPath) }); err != nil {
+			return err
 		}
 	}
 
 	// Run Plugins
-	pluginMgr := plugins.NewPluginManager()
-	pluginMgr.Register(&plugins.LogPlugin{})
-	if err := pluginMgr.RunAll(c.Config, projectPath); err != nil {
+	if err := optional("plugins", func() error { return pluginMgr.RunAll(c.Config, projectPath) }); err != nil {
 		return err
 	}
 
 	// Generate Docs
-	if err := docs.Generate(projectPath, c.Config, c.DryRun); err != nil {
+	if err := optional("docs", func() error { return docs.Generate(projectPath, c.Config, c.DryRun) }); err != nil {
+		return err
+	}
+
+	if err := pluginMgr.Emit(plugins.Event{Name: plugins.EventAfterCreate, ProjectPath: projectPath, Config: c.Config}); err != nil {
 		return err
 	}
 
-	fmt.Printf("\n✨ Project '%s' created successfully!\n", c.Name)
+	if err := c.Policy.Report.Err(); err != nil {
+		return err
+	}
+	ui.Printf("\n✨ Project '%s' created successfully!\n", c.Name)
 	return nil
 }
@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os/exec"
 )
 
@@ -14,15 +15,22 @@ func NewDatabaseSetup(projectPath string, dryRun bool) *DatabaseSetup {
 	return &DatabaseSetup{ProjectPath: projectPath, DryRun: dryRun}
 }
 
+// migrationFile names a migration generated by a feature. The fixed date
+// keeps the name stable, so the catalog can list the file and running the
+// feature again overwrites it instead of adding a second copy.
+func migrationFile(seq int, name string) string {
+	return fmt.Sprintf("database/migrations/2025_01_01_%06d_%s.php", seq, name)
+}
+
 func (d *DatabaseSetup) RunMigrations() error {
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would run: php artisan migrate\n")
+		ui.Printf("[Dry Run] Would run: php artisan migrate\n")
 		return nil
 	}
 
 	cmd := exec.Command("php", "artisan", "migrate", "--force")
 	cmd.Dir = d.ProjectPath
-	output, err := cmd.CombinedOutput()
+	output, err := ui.Run(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %v\nOutput: %s", err, string(output))
 	}
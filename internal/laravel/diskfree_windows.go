@@ -0,0 +1,18 @@
+//go:build windows
+
+package laravel
+
+import "golang.org/x/sys/windows"
+
+// freeBytes is the space available to this user on the volume of dir.
+func freeBytes(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(path, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
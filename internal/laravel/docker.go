@@ -2,17 +2,22 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type DockerSetup struct {
 	ProjectPath string
 	DryRun      bool
+	PHPVersion  string
+	Services    []string // extra compose services, keys of composeServices
 }
 
 func NewDockerSetup(projectPath string, dryRun bool) *DockerSetup {
-	return &DockerSetup{ProjectPath: projectPath, DryRun: dryRun}
+	return &DockerSetup{ProjectPath: projectPath, DryRun: dryRun, PHPVersion: "8.3"}
 }
 
 func (d *DockerSetup) Setup() error {
@@ -34,14 +39,14 @@ func (d *DockerSetup) Setup() error {
 func (d *DockerSetup) createDockerDir() error {
 	path := filepath.Join(d.ProjectPath, "docker")
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would create directory: %s\n", path)
+		ui.Printf("[Dry Run] Would create directory: %s\n", path)
 		return nil
 	}
 	return os.MkdirAll(path, 0755)
 }
 
 func (d *DockerSetup) createDevDockerfile() error {
-	content := `FROM php:8.3-fpm
+	content := fmt.Sprintf(`FROM php:%s-fpm
 
 # Install system dependencies
 RUN apt-get update && apt-get install -y \
@@ -66,17 +71,17 @@ COPY --from=composer:latest /usr/bin/composer /usr/bin/composer
 WORKDIR /var/www
 
 USER $user
-`
+`, d.PHPVersion)
 	path := filepath.Join(d.ProjectPath, "docker/Dockerfile")
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would create file: %s\n", path)
+		ui.Printf("[Dry Run] Would create file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (d *DockerSetup) createProdDockerfile() error {
-	content := `FROM php:8.3-fpm as build
+	content := fmt.Sprintf(`FROM php:%[1]s-fpm as build
 
 WORKDIR /var/www
 
@@ -92,20 +97,20 @@ RUN docker-php-ext-install pdo_mysql mbstring exif pcntl bcmath gd
 COPY . .
 RUN composer install --no-dev --optimize-autoloader
 
-FROM php:8.3-fpm-alpine
+FROM php:%[1]s-fpm-alpine
 
 RUN docker-php-ext-install pdo_mysql
 
 COPY --from=build /var/www /var/www
 
 WORKDIR /var/www
-`
+`, d.PHPVersion)
 	path := filepath.Join(d.ProjectPath, "docker/Dockerfile.prod")
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would create file: %s\n", path)
+		ui.Printf("[Dry Run] Would create file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (d *DockerSetup) createDockerCompose() error {
@@ -146,8 +151,145 @@ volumes:
 `
 	path := filepath.Join(d.ProjectPath, "docker-compose.yml")
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would create file: %s\n", path)
+		ui.Printf("[Dry Run] Would create file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	if err := ui.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+	return addComposeServices(d.ProjectPath, d.Services...)
+}
+
+type composeService struct {
+	block  string // the service, indented under services:
+	volume string // named volume it mounts, if any
+}
+
+// composeServices are the backing services features add to
+// docker-compose.yml next to app and db.
+var composeServices = map[string]composeService{
+	"rabbitmq": {volume: "rabbitmqdata", block: `  rabbitmq:
+    image: rabbitmq:3-management
+    container_name: myapp-rabbitmq
+    restart: unless-stopped
+    environment:
+      RABBITMQ_DEFAULT_USER: ${RABBITMQ_USER:-guest}
+      RABBITMQ_DEFAULT_PASS: ${RABBITMQ_PASSWORD:-guest}
+    ports:
+      - "5672:5672"
+      - "15672:15672"
+    volumes:
+      - rabbitmqdata:/var/lib/rabbitmq
+    networks:
+      - myapp-network
+`},
+	"kafka": {volume: "kafkadata", block: `  kafka:
+    image: apache/kafka:3.8.0
+    container_name: myapp-kafka
+    restart: unless-stopped
+    environment:
+      KAFKA_NODE_ID: 1
+      KAFKA_PROCESS_ROLES: broker,controller
+      KAFKA_LISTENERS: PLAINTEXT://:9092,CONTROLLER://:9093
+      KAFKA_ADVERTISED_LISTENERS: PLAINTEXT://kafka:9092
+      KAFKA_CONTROLLER_LISTENER_NAMES: CONTROLLER
+      KAFKA_LISTENER_SECURITY_PROTOCOL_MAP: CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT
+      KAFKA_CONTROLLER_QUORUM_VOTERS: 1@kafka:9093
+      KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR: 1
+      KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR: 1
+      KAFKA_TRANSACTION_STATE_LOG_MIN_ISR: 1
+      KAFKA_AUTO_CREATE_TOPICS_ENABLE: "true"
+      KAFKA_LOG_DIRS: /var/lib/kafka/data
+    volumes:
+      - kafkadata:/var/lib/kafka/data
+    networks:
+      - myapp-network
+`},
+	"reverb": {block: `  reverb:
+    build:
+      context: .
+      dockerfile: docker/Dockerfile
+    image: myapp-app
+    container_name: myapp-reverb
+    restart: unless-stopped
+    working_dir: /var/www
+    command: php artisan reverb:start --host=0.0.0.0 --port=8080
+    ports:
+      - "8080:8080"
+    volumes:
+      - ./:/var/www
+    healthcheck:
+      test: ["CMD", "php", "-r", "exit(@fsockopen('127.0.0.1', 8080) ? 0 : 1);"]
+      interval: 10s
+      timeout: 3s
+      retries: 3
+    networks:
+      - myapp-network
+`},
+	"redis": {volume: "redisdata", block: `  redis:
+    image: redis:7-alpine
+    container_name: myapp-redis
+    restart: unless-stopped
+    command: redis-server --appendonly yes
+    ports:
+      - "6379:6379"
+    volumes:
+      - redisdata:/data
+    networks:
+      - myapp-network
+`},
+}
+
+// addComposeServices adds the named services, and their volumes, to an
+// existing docker-compose.yml. Services already in the file are left
+// alone; without a compose file there is nothing to do, and the docker
+// feature adds them when it writes one.
+func addComposeServices(projectPath string, names ...string) error {
+	path := filepath.Join(projectPath, "docker-compose.yml")
+	data, err := os.ReadFile(path)
+	if err != nil || len(names) == 0 {
+		return nil
+	}
+	content := string(data)
+	changed := false
+	for _, name := range names {
+		svc, ok := composeServices[name]
+		if !ok {
+			return fmt.Errorf("unknown compose service: %s", name)
+		}
+		if strings.Contains(content, "\n  "+name+":\n") {
+			continue
+		}
+		i := strings.Index(content, "\nnetworks:\n")
+		if i < 0 {
+			ui.Warnf("⚠️ Could not find the networks section in docker-compose.yml, add the %s service manually\n", name)
+			continue
+		}
+		content = content[:i+1] + svc.block + "\n" + content[i+1:]
+		if svc.volume != "" && !strings.Contains(content, "\n  "+svc.volume+":\n") {
+			if strings.Contains(content, "\nvolumes:\n") {
+				content = strings.Replace(content, "\nvolumes:\n", "\nvolumes:\n  "+svc.volume+":\n", 1)
+			} else {
+				content = strings.TrimRight(content, "\n") + "\n\nvolumes:\n  " + svc.volume + ":\n"
+			}
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return ui.WriteFile(path, []byte(content), 0644)
+}
+
+// installedServices lists the compose services of features already in
+// the project, so a regenerated docker-compose.yml keeps them.
+func installedServices(projectPath string, opts config.Options) []string {
+	var services []string
+	if f, ok := LookupFeature("messaging"); ok && f.Installed(projectPath, nil) {
+		services = append(services, messagingDriver(opts.Messaging.Driver))
+	}
+	if f, ok := LookupFeature("realtime"); ok && f.Installed(projectPath, nil) {
+		services = append(services, "reverb")
+	}
+	return services
 }
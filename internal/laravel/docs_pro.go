@@ -1,8 +1,7 @@
 package laravel
 
 import (
-	"fmt"
-	"os/exec"
+	"laravelboot/internal/ui"
 )
 
 type DocsProSetup struct {
@@ -16,15 +15,12 @@ func NewDocsProSetup(projectPath string, dryRun bool) *DocsProSetup {
 
 func (d *DocsProSetup) Setup() error {
 	if d.DryRun {
-		fmt.Printf("[Dry Run] Would install dedoc/scramble for automated API docs\n")
+		ui.Printf("[Dry Run] Would install dedoc/scramble for automated API docs\n")
 		return nil
 	}
 
-	fmt.Println("📚 Installing dedoc/scramble...")
-	cmd := exec.Command("composer", "require", "dedoc/scramble", "--with-all-dependencies")
-	cmd.Dir = d.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install Scramble: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(d.ProjectPath, false, "dedoc/scramble"); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,398 @@
+package laravel
+
+import (
+	"encoding/json"
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Check is one result of the environment doctor.
+type Check struct {
+	Name   string
+	Status string
+	Detail string
+	Fix    string // how to fix it with this system's package manager
+}
+
+const (
+	CheckOK   = "ok"
+	CheckInfo = "info" // an optional tool is missing
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// Doctor checks that this machine can build and run a project: PHP and
+// the extensions the selected features need, composer, optional tools,
+// disk space and writable directories.
+type Doctor struct {
+	Dir    string         // where the project lives or will be created
+	Config *config.Config // the database and features to check for
+
+	// LaravelVersion is the major version the project targets.
+	LaravelVersion int
+
+	// PackageManager picks the fix-it commands: apt, dnf, apk, pacman,
+	// zypper, brew or windows.
+	PackageManager string
+
+	phpVersion string
+	modules    map[string]bool
+}
+
+func NewDoctor(dir string, conf *config.Config) *Doctor {
+	return &Doctor{
+		Dir:            dir,
+		Config:         conf,
+		LaravelVersion: laravelVersion(dir),
+		PackageManager: detectPackageManager(),
+	}
+}
+
+// latestLaravel is the major version "new" installs.
+const latestLaravel = 12
+
+// minPHP is the oldest PHP each Laravel major supports.
+var minPHP = map[int]string{10: "8.1", 11: "8.2", 12: "8.2"}
+
+// baseExtensions are what every Laravel application needs.
+var baseExtensions = []string{"ctype", "curl", "dom", "fileinfo", "filter", "hash", "mbstring", "openssl", "pcre", "pdo", "session", "tokenizer", "xml"}
+
+// baseSuggests ship in the generated Docker images; Laravel's Number
+// helper needs intl.
+var baseSuggests = []string{"bcmath", "intl"}
+
+var databaseExtensions = map[string]string{"mysql": "pdo_mysql", "postgres": "pdo_pgsql", "sqlite": "pdo_sqlite", "mongo": "mongodb"}
+
+// Minimum free space for a new project with its vendor directory, and the
+// amount below which the doctor warns.
+const (
+	minFreeBytes  = 200 << 20
+	warnFreeBytes = 1 << 30
+)
+
+// Run performs every check, in the order they are printed.
+func (d *Doctor) Run() []Check {
+	checks := []Check{d.checkPHP()}
+	if d.modules != nil {
+		checks = append(checks, d.checkExtensions()...)
+	}
+	checks = append(checks, d.checkComposer())
+	checks = append(checks, d.checkTools()...)
+	checks = append(checks, d.checkDisk(), d.checkWritable())
+	return checks
+}
+
+func (d *Doctor) checkPHP() Check {
+	if _, err := exec.LookPath("php"); err != nil {
+		return Check{Name: "php", Status: CheckFail, Detail: "php is not installed or not in PATH", Fix: d.toolFix("php")}
+	}
+	out, err := ui.Run(exec.Command("php", "-r", "echo PHP_VERSION;"))
+	if err != nil {
+		return Check{Name: "php", Status: CheckFail, Detail: fmt.Sprintf("php does not run: %v", err), Fix: d.toolFix("php")}
+	}
+	d.phpVersion = strings.TrimSpace(string(out))
+	d.modules = map[string]bool{}
+	if out, err := ui.Run(exec.Command("php", "-m")); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "[") {
+				d.modules[strings.ToLower(line)] = true
+			}
+		}
+	}
+
+	min := minPHP[d.LaravelVersion]
+	if !versionAtLeast(d.phpVersion, min) {
+		return Check{Name: "php", Status: CheckFail, Detail: fmt.Sprintf("PHP %s is older than %s, which Laravel %d needs", d.phpVersion, min, d.LaravelVersion), Fix: d.toolFix("php")}
+	}
+	detail := fmt.Sprintf("PHP %s (Laravel %d needs %s+)", d.phpVersion, d.LaravelVersion, min)
+	if d.Config != nil && d.selected("docker") {
+		if docker := d.Config.Options.Docker.PHPVersion; docker != "" && !strings.HasPrefix(d.phpVersion, docker+".") {
+			return Check{Name: "php", Status: CheckWarn, Detail: detail + fmt.Sprintf("; the Docker images use PHP %s, so composer.lock may not match them", docker)}
+		}
+	}
+	return Check{Name: "php", Status: CheckOK, Detail: detail}
+}
+
+// checkExtensions reports every missing extension with the features that
+// need it, and one line for those that are loaded.
+func (d *Doctor) checkExtensions() []Check {
+	type need struct {
+		spec     string
+		required bool
+		by       []string
+	}
+	var needs []*need
+	index := map[string]*need{}
+	add := func(spec, by string, required bool) {
+		n, ok := index[spec]
+		if !ok {
+			n = &need{spec: spec}
+			index[spec] = n
+			needs = append(needs, n)
+		}
+		n.required = n.required || required
+		if !slices.Contains(n.by, by) {
+			n.by = append(n.by, by)
+		}
+	}
+
+	for _, ext := range baseExtensions {
+		add(ext, "Laravel", true)
+	}
+	for _, ext := range baseSuggests {
+		add(ext, "Laravel", false)
+	}
+	if d.Config != nil {
+		if ext, ok := databaseExtensions[d.Config.Database]; ok {
+			add(ext, "database: "+d.Config.Database, true)
+		}
+		if d.selected("messaging") {
+			driver := messagingDriver(d.Config.Options.Messaging.Driver)
+			if ext := messagingDrivers[driver].extension; ext != "" {
+				add(ext, "messaging: "+driver, true)
+			}
+		}
+		for _, name := range d.features() {
+			f, _ := LookupFeature(name)
+			for _, ext := range f.Extensions {
+				add(ext, f.Name, true)
+			}
+			for _, ext := range f.Suggests {
+				add(ext, f.Name, false)
+			}
+		}
+	}
+
+	var checks []Check
+	loaded := 0
+	for _, n := range needs {
+		if d.hasExtension(n.spec) {
+			loaded++
+			continue
+		}
+		status, verb := CheckWarn, "recommended for"
+		if n.required {
+			status, verb = CheckFail, "needed by"
+		}
+		name := "ext-" + strings.ReplaceAll(n.spec, "|", " or ext-")
+		first, _, _ := strings.Cut(n.spec, "|")
+		checks = append(checks, Check{Name: name, Status: status, Detail: verb + " " + strings.Join(n.by, ", "), Fix: d.extensionFix(first)})
+	}
+	ok := Check{Name: "extensions", Status: CheckOK, Detail: fmt.Sprintf("%d of %d needed PHP extensions loaded", loaded, len(needs))}
+	return append([]Check{ok}, checks...)
+}
+
+func (d *Doctor) hasExtension(spec string) bool {
+	for _, ext := range strings.Split(spec, "|") {
+		if d.modules[ext] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Doctor) checkComposer() Check {
+	if _, err := exec.LookPath("composer"); err != nil {
+		return Check{Name: "composer", Status: CheckFail, Detail: "composer is not installed or not in PATH", Fix: d.toolFix("composer")}
+	}
+	out, err := ui.Run(exec.Command("composer", "--version", "--no-ansi"))
+	version := regexp.MustCompile(`\d+\.\d+\.\d+`).FindString(string(out))
+	switch {
+	case err != nil || version == "":
+		return Check{Name: "composer", Status: CheckWarn, Detail: "could not read the composer version"}
+	case !versionAtLeast(version, "2.2"):
+		return Check{Name: "composer", Status: CheckFail, Detail: fmt.Sprintf("Composer %s is too old; Laravel needs 2.2+", version), Fix: "composer self-update"}
+	}
+	return Check{Name: "composer", Status: CheckOK, Detail: "Composer " + version}
+}
+
+// checkTools looks for the tools some features or commands use. A missing
+// tool is a warning only when a selected feature needs it.
+func (d *Doctor) checkTools() []Check {
+	tools := []struct {
+		name, use, feature string
+	}{
+		{"docker", "runs the generated Docker setup", "docker"},
+		{"node", "builds front-end assets with Vite", ""},
+		{"laravel", "creates projects faster; composer create-project is used without it", ""},
+		{"git", "fetches git+ presets and plugins", ""},
+	}
+
+	var checks []Check
+	for _, t := range tools {
+		path, err := exec.LookPath(t.name)
+		switch {
+		case err == nil:
+			checks = append(checks, Check{Name: t.name, Status: CheckOK, Detail: path})
+		case t.feature != "" && d.selected(t.feature):
+			checks = append(checks, Check{Name: t.name, Status: CheckWarn, Detail: "not found; " + t.use, Fix: d.toolFix(t.name)})
+		default:
+			checks = append(checks, Check{Name: t.name, Status: CheckInfo, Detail: "not found (optional); " + t.use, Fix: d.toolFix(t.name)})
+		}
+	}
+	return checks
+}
+
+func (d *Doctor) checkDisk() Check {
+	free, err := freeBytes(existingDir(d.Dir))
+	switch {
+	case err != nil:
+		return Check{Name: "disk", Status: CheckWarn, Detail: fmt.Sprintf("could not read free space: %v", err)}
+	case free < minFreeBytes:
+		return Check{Name: "disk", Status: CheckFail, Detail: fmt.Sprintf("%s free; a project with its vendor directory needs at least %s", formatBytes(free), formatBytes(minFreeBytes)), Fix: "free some space or use --path to create the project elsewhere"}
+	case free < warnFreeBytes:
+		return Check{Name: "disk", Status: CheckWarn, Detail: fmt.Sprintf("only %s free", formatBytes(free))}
+	}
+	return Check{Name: "disk", Status: CheckOK, Detail: formatBytes(free) + " free"}
+}
+
+// checkWritable makes sure the project directory and composer's cache can
+// be written to.
+func (d *Doctor) checkWritable() Check {
+	dirs := []string{existingDir(d.Dir)}
+	if out, err := ui.Run(exec.Command("composer", "config", "--global", "cache-dir", "--no-ansi")); err == nil {
+		if cache := strings.TrimSpace(string(out)); cache != "" {
+			if _, err := os.Stat(cache); err == nil {
+				dirs = append(dirs, cache)
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		f, err := os.CreateTemp(dir, ".laravelboot-doctor-*")
+		if err != nil {
+			return Check{Name: "writable", Status: CheckFail, Detail: fmt.Sprintf("%s is not writable", dir), Fix: "sudo chown -R $(whoami) " + dir}
+		}
+		f.Close()
+		os.Remove(f.Name())
+	}
+	return Check{Name: "writable", Status: CheckOK, Detail: strings.Join(dirs, ", ")}
+}
+
+// features returns the catalog features the config selects.
+func (d *Doctor) features() []string {
+	var names []string
+	names = append(names, d.Config.Features...)
+	names = append(names, d.Config.Infra...)
+	names = append(names, d.Config.Enterprise...)
+	return ExpandFeatures(names)
+}
+
+func (d *Doctor) selected(feature string) bool {
+	return d.Config != nil && slices.Contains(d.features(), feature)
+}
+
+// PrintChecks shows the results, or only the warnings and failures when
+// problemsOnly is set.
+func PrintChecks(checks []Check, problemsOnly bool) {
+	if ui.JSON() {
+		for _, c := range checks {
+			if !problemsOnly || c.Status == CheckWarn || c.Status == CheckFail {
+				ui.Emit("check", map[string]any{"name": c.Name, "status": c.Status, "detail": c.Detail, "fix": c.Fix})
+			}
+		}
+		return
+	}
+
+	marks := map[string]string{CheckOK: "✅", CheckInfo: "➖", CheckWarn: "⚠️ ", CheckFail: "❌"}
+	for _, c := range checks {
+		if problemsOnly && c.Status != CheckWarn && c.Status != CheckFail {
+			continue
+		}
+		line := fmt.Sprintf("  %s %-12s %s\n", marks[c.Status], c.Name, c.Detail)
+		if c.Status == CheckOK || c.Status == CheckInfo {
+			ui.Printf("%s", line)
+		} else {
+			ui.Warnf("%s", line)
+		}
+		if c.Fix != "" && c.Status != CheckOK {
+			ui.Printf("     → %s\n", c.Fix)
+		}
+	}
+}
+
+// ChecksErr returns an error naming the failed checks, or nil.
+func ChecksErr(checks []Check) error {
+	var failed []string
+	for _, c := range checks {
+		if c.Status == CheckFail {
+			failed = append(failed, c.Name)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("environment check failed: %s (run 'laravelboot doctor' for details)", strings.Join(failed, ", "))
+}
+
+// laravelVersion reads the Laravel major an existing project requires,
+// or returns the one "new" installs.
+func laravelVersion(dir string) int {
+	data, err := os.ReadFile(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		return latestLaravel
+	}
+	var composer struct {
+		Require map[string]string `json:"require"`
+	}
+	if json.Unmarshal(data, &composer) != nil {
+		return latestLaravel
+	}
+	major, err := strconv.Atoi(regexp.MustCompile(`\d+`).FindString(composer.Require["laravel/framework"]))
+	if _, known := minPHP[major]; err != nil || !known {
+		return latestLaravel
+	}
+	return major
+}
+
+// versionAtLeast compares dotted versions numerically; missing parts of
+// min count as zero.
+func versionAtLeast(version, min string) bool {
+	have := strings.Split(version, ".")
+	for i, part := range strings.Split(min, ".") {
+		want, _ := strconv.Atoi(part)
+		got := 0
+		if i < len(have) {
+			got, _ = strconv.Atoi(regexp.MustCompile(`^\d+`).FindString(have[i]))
+		}
+		if got != want {
+			return got > want
+		}
+	}
+	return true
+}
+
+// existingDir walks up from dir to the nearest directory that exists.
+func existingDir(dir string) string {
+	dir, _ = filepath.Abs(dir)
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+func formatBytes(n uint64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%d MB", n>>20)
+	}
+	return fmt.Sprintf("%d KB", n>>10)
+}
@@ -0,0 +1,155 @@
+package laravel
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// detectPackageManager names the package manager the fix-it suggestions
+// are written for.
+func detectPackageManager() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "brew"
+	case "windows":
+		return "windows"
+	}
+	for _, pm := range []struct{ bin, name string }{
+		{"apt-get", "apt"}, {"dnf", "dnf"}, {"yum", "dnf"}, {"apk", "apk"}, {"pacman", "pacman"}, {"zypper", "zypper"},
+	} {
+		if _, err := exec.LookPath(pm.bin); err == nil {
+			return pm.name
+		}
+	}
+	return ""
+}
+
+// toolFixes are the install commands per tool and package manager; ""
+// is the fallback.
+var toolFixes = map[string]map[string]string{
+	"php": {
+		"apt":     "sudo apt install php-cli (add ppa:ondrej/php for newer versions)",
+		"dnf":     "sudo dnf install php-cli",
+		"apk":     "apk add php83",
+		"pacman":  "sudo pacman -S php",
+		"zypper":  "sudo zypper install php8",
+		"brew":    "brew install php",
+		"windows": "scoop install php",
+		"":        "install PHP from https://www.php.net/downloads",
+	},
+	"composer": {
+		"dnf":     "sudo dnf install composer",
+		"apk":     "apk add composer",
+		"pacman":  "sudo pacman -S composer",
+		"brew":    "brew install composer",
+		"windows": "scoop install composer",
+		"":        "install Composer from https://getcomposer.org/download/",
+	},
+	"docker": {
+		"apt":     "sudo apt install docker.io docker-compose-v2",
+		"dnf":     "sudo dnf install docker",
+		"apk":     "apk add docker docker-cli-compose",
+		"pacman":  "sudo pacman -S docker docker-compose",
+		"zypper":  "sudo zypper install docker",
+		"brew":    "brew install --cask docker",
+		"windows": "install Docker Desktop from https://docs.docker.com/desktop/",
+		"":        "install Docker from https://docs.docker.com/get-docker/",
+	},
+	"node": {
+		"apt":     "sudo apt install nodejs npm",
+		"dnf":     "sudo dnf install nodejs",
+		"apk":     "apk add nodejs npm",
+		"pacman":  "sudo pacman -S nodejs npm",
+		"zypper":  "sudo zypper install nodejs",
+		"brew":    "brew install node",
+		"windows": "scoop install nodejs",
+		"":        "install Node.js from https://nodejs.org/",
+	},
+	"laravel": {
+		"": "composer global require laravel/installer",
+	},
+	"git": {
+		"apt":     "sudo apt install git",
+		"dnf":     "sudo dnf install git",
+		"apk":     "apk add git",
+		"pacman":  "sudo pacman -S git",
+		"zypper":  "sudo zypper install git",
+		"brew":    "brew install git",
+		"windows": "scoop install git",
+		"":        "install git from https://git-scm.com/downloads",
+	},
+}
+
+func (d *Doctor) toolFix(tool string) string {
+	if fix, ok := toolFixes[tool][d.PackageManager]; ok {
+		return fix
+	}
+	return toolFixes[tool][""]
+}
+
+// peclExtensions are not bundled with PHP and come from PECL.
+var peclExtensions = map[string]bool{"redis": true, "imagick": true, "mongodb": true}
+
+// Distribution packages that ship an extension under another name.
+var (
+	aptPackages = map[string]string{
+		"pdo_mysql": "mysql", "pdo_pgsql": "pgsql", "pdo_sqlite": "sqlite3",
+		"dom": "xml", "xmlwriter": "xml", "xmlreader": "xml", "simplexml": "xml",
+		"ctype": "common", "exif": "common", "fileinfo": "common", "iconv": "common", "pdo": "common", "tokenizer": "common",
+		"pcntl": "cli",
+	}
+	dnfPackages = map[string]string{
+		"pdo_mysql": "mysqlnd", "pdo_pgsql": "pgsql", "pdo_sqlite": "pdo", "pdo": "pdo",
+		"dom": "xml", "xmlwriter": "xml", "xmlreader": "xml", "simplexml": "xml",
+		"ctype": "common", "exif": "common", "fileinfo": "common", "iconv": "common", "tokenizer": "common", "curl": "common",
+		"pcntl": "process", "redis": "pecl-redis5", "imagick": "pecl-imagick", "mongodb": "pecl-mongodb",
+	}
+)
+
+// extensionFix is how to install or enable ext with this system's
+// package manager and PHP version.
+func (d *Doctor) extensionFix(ext string) string {
+	minor := d.phpVersion
+	if parts := strings.Split(d.phpVersion, "."); len(parts) >= 2 {
+		minor = parts[0] + "." + parts[1]
+	}
+	name := func(m map[string]string) string {
+		if pkg, ok := m[ext]; ok {
+			return pkg
+		}
+		return ext
+	}
+
+	switch d.PackageManager {
+	case "apt":
+		return fmt.Sprintf("sudo apt install php%s-%s", minor, name(aptPackages))
+	case "dnf":
+		return "sudo dnf install php-" + name(dnfPackages)
+	case "apk":
+		pkg := ext
+		if peclExtensions[ext] {
+			pkg = "pecl-" + ext
+		}
+		return fmt.Sprintf("apk add php%s-%s", strings.ReplaceAll(minor, ".", ""), pkg)
+	case "pacman":
+		return fmt.Sprintf("sudo pacman -S php-%s, then enable extension=%s in /etc/php/php.ini", strings.TrimPrefix(ext, "pdo_"), ext)
+	case "zypper":
+		return fmt.Sprintf("sudo zypper install php8-%s", ext)
+	case "brew":
+		if peclExtensions[ext] {
+			return "pecl install " + ext
+		}
+		return fmt.Sprintf("brew reinstall php@%s (it bundles %s)", minor, ext)
+	case "windows":
+		if peclExtensions[ext] {
+			return fmt.Sprintf("download php_%s.dll from https://pecl.php.net/package/%s into PHP's ext directory, then enable extension=%s in php.ini", ext, ext, ext)
+		}
+		return fmt.Sprintf("enable extension=%s in php.ini (php --ini shows where it is)", ext)
+	}
+	if peclExtensions[ext] {
+		return "pecl install " + ext
+	}
+	return fmt.Sprintf("install or enable the PHP %s extension", ext)
+}
@@ -2,21 +2,31 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 )
 
 type EnterpriseManager struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
+	Policy      Policy
 }
 
 func NewEnterpriseManager(projectPath string, dryRun bool) *EnterpriseManager {
 	return &EnterpriseManager{ProjectPath: projectPath, DryRun: dryRun}
 }
 
+// RunStep installs one feature, or a whole bundle, and records what it
+// installed in .laravelboot/installed.yaml.
 func (m *EnterpriseManager) RunStep(name string) error {
+	return recordStep(m.ProjectPath, m.DryRun, name, func() error { return m.runStep(name) })
+}
+
+func (m *EnterpriseManager) runStep(name string) error {
 	switch name {
 	case "quality":
-		return NewQualitySetup(m.ProjectPath, m.DryRun).Setup()
+		return m.quality().Setup()
 	case "pro-arch":
 		return NewProArchSetup(m.ProjectPath, m.DryRun).Setup()
 	case "docs-pro":
@@ -30,27 +40,20 @@ func (m *EnterpriseManager) RunStep(name string) error {
 	case "helpers":
 		return NewHelpersSetup(m.ProjectPath, m.DryRun).Setup()
 	case "enterprise":
-		fmt.Println("👑 Installing complete Enterprise stack...")
-		if err := NewQualitySetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewProArchSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewDocsProSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewCicdSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewMonitoringSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewHelpersSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		return nil
+		ui.Println("👑 Installing complete Enterprise stack...")
+		o := NewOrchestrator(m.ProjectPath, m.DryRun)
+		o.Options = m.Options
+		o.Policy = m.Policy
+		return o.Run(Bundles["enterprise"])
 	default:
 		return fmt.Errorf("unknown enterprise feature: %s", name)
 	}
 }
+
+func (m *EnterpriseManager) quality() *QualitySetup {
+	q := NewQualitySetup(m.ProjectPath, m.DryRun)
+	if lvl := m.Options.Quality.PHPStanLevel; lvl != nil {
+		q.PHPStanLevel = *lvl
+	}
+	return q
+}
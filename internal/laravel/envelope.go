@@ -0,0 +1,346 @@
+package laravel
+
+import (
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultResponseFormat is the success/message/data envelope.
+const DefaultResponseFormat = "custom"
+
+// DefaultPaginationStrategy is page numbers with a total count.
+const DefaultPaginationStrategy = "offset"
+
+// envelopePath is the class every generated response goes through: the
+// response macros, both ApiResponse traits, HandlesPagination and the
+// exception handler. Only this file differs between response formats.
+const envelopePath = "app/Support/Api/Envelope.php"
+
+// paginationPath negotiates page[size], page[number] and page[cursor] for
+// every paginated endpoint.
+const paginationPath = "app/Support/Api/Pagination.php"
+
+// writeApiSupport creates the Envelope and Pagination classes from opts.
+// Several setups write them, always with the same content for the same
+// options.
+func writeApiSupport(projectPath string, opts config.Options, dryRun bool) error {
+	files := []struct{ path, content, note string }{
+		{envelopePath, envelopeClass(opts.Responses.Format), responseFormat(opts.Responses.Format) + " responses"},
+		{paginationPath, paginationClass(opts.Pagination), paginationStrategy(opts.Pagination.Strategy) + " pagination"},
+	}
+	for _, f := range files {
+		path := filepath.Join(projectPath, f.path)
+		if dryRun {
+			ui.Printf("[Dry Run] Would create file: %s (%s)\n", path, f.note)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ui.WriteFile(path, []byte(f.content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func responseFormat(format string) string {
+	if format == "" {
+		return DefaultResponseFormat
+	}
+	return format
+}
+
+func paginationStrategy(strategy string) string {
+	if strategy == "" {
+		return DefaultPaginationStrategy
+	}
+	return strategy
+}
+
+func envelopeClass(format string) string {
+	format = responseFormat(format)
+	success, errorBody := customSuccess, customError
+	switch format {
+	case "jsonapi":
+		success, errorBody = jsonAPISuccess, jsonAPIError
+	case "problem":
+		errorBody = problemError
+	}
+
+	return strings.NewReplacer(
+		"{{format}}", format,
+		"{{success}}", strings.TrimPrefix(success, "\n"),
+		"{{error}}", strings.TrimPrefix(errorBody, "\n"),
+	).Replace(envelopeTemplate)
+}
+
+const envelopeTemplate = `<?php
+
+namespace App\Support\Api;
+
+use Illuminate\Contracts\Pagination\CursorPaginator;
+use Illuminate\Contracts\Pagination\LengthAwarePaginator;
+use Illuminate\Contracts\Pagination\Paginator;
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Http\JsonResponse;
+use Illuminate\Http\Resources\Json\JsonResource;
+use Illuminate\Http\Resources\Json\ResourceCollection;
+use Illuminate\Support\Collection;
+use Symfony\Component\HttpFoundation\Response;
+
+/**
+ * Builds every API response body in the "{{format}}" format set by
+ * options.responses.format in .laravelboot.yaml. Response macros, the
+ * ApiResponse traits and the exception handler all go through this class,
+ * so the envelope only ever changes here.
+ */
+class Envelope
+{
+    public const FORMAT = '{{format}}';
+
+{{success}}
+{{error}}
+    /**
+     * A page of results from any pagination strategy. Resources, when
+     * given, transform the items.
+     */
+    public static function paginated(Paginator|CursorPaginator $paginator, string $message = 'Success', ?JsonResource $resource = null): JsonResponse
+    {
+        $items = $resource ?? $paginator->items();
+
+        return static::success($items, $message, 200, static::paginationMeta($paginator), static::paginationLinks($paginator));
+    }
+
+    /**
+     * The same keys for every strategy: strategy, per_page and has_more,
+     * then page numbers (offset, simple), the total (offset) or the
+     * cursors (cursor).
+     */
+    public static function paginationMeta(Paginator|CursorPaginator $paginator): array
+    {
+        if ($paginator instanceof CursorPaginator) {
+            return [
+                'strategy' => 'cursor',
+                'per_page' => $paginator->perPage(),
+                'has_more' => $paginator->hasMorePages(),
+                'next_cursor' => $paginator->nextCursor()?->encode(),
+                'prev_cursor' => $paginator->previousCursor()?->encode(),
+            ];
+        }
+
+        $meta = [
+            'strategy' => $paginator instanceof LengthAwarePaginator ? 'offset' : 'simple',
+            'per_page' => $paginator->perPage(),
+            'has_more' => $paginator->hasMorePages(),
+            'current_page' => $paginator->currentPage(),
+            'from' => $paginator->firstItem(),
+            'to' => $paginator->lastItem(),
+        ];
+
+        if ($paginator instanceof LengthAwarePaginator) {
+            $meta['last_page'] = $paginator->lastPage();
+            $meta['total'] = $paginator->total();
+        }
+
+        return $meta;
+    }
+
+    public static function paginationLinks(Paginator|CursorPaginator $paginator): array
+    {
+        if ($paginator instanceof CursorPaginator) {
+            return [
+                'prev' => $paginator->previousPageUrl(),
+                'next' => $paginator->nextPageUrl(),
+            ];
+        }
+
+        $links = [
+            'first' => $paginator->url(1),
+            'prev' => $paginator->previousPageUrl(),
+            'next' => $paginator->nextPageUrl(),
+        ];
+
+        if ($paginator instanceof LengthAwarePaginator) {
+            $links['last'] = $paginator->url($paginator->lastPage());
+        }
+
+        return $links;
+    }
+
+    /**
+     * An empty 204 response.
+     */
+    public static function noContent(): JsonResponse
+    {
+        return new JsonResponse(null, 204);
+    }
+}
+`
+
+const customSuccess = `
+    /**
+     * {"success": true, "message": ..., "data": ..., "meta": ..., "links": ...}
+     */
+    public static function success(mixed $data = null, string $message = 'Success', int $status = 200, array $meta = [], array $links = []): JsonResponse
+    {
+        $body = [
+            'success' => true,
+            'message' => $message,
+            'data' => $data,
+        ];
+
+        if ($meta !== []) {
+            $body['meta'] = $meta;
+        }
+        if ($links !== []) {
+            $body['links'] = $links;
+        }
+
+        return new JsonResponse($body, $status);
+    }
+`
+
+const customError = `
+    /**
+     * {"success": false, "message": ..., "errors": ...}
+     */
+    public static function error(string $message = 'Error', int $status = 400, mixed $errors = null): JsonResponse
+    {
+        $body = [
+            'success' => false,
+            'message' => $message,
+        ];
+
+        if ($errors !== null && $errors !== []) {
+            $body['errors'] = $errors;
+        }
+
+        return new JsonResponse($body, $status);
+    }
+`
+
+const problemError = `
+    /**
+     * RFC 9457 problem details, served as application/problem+json.
+     * Validation messages go in the "errors" extension member.
+     */
+    public static function error(string $message = 'Error', int $status = 400, mixed $errors = null): JsonResponse
+    {
+        $body = [
+            'type' => 'about:blank',
+            'title' => Response::$statusTexts[$status] ?? 'Error',
+            'status' => $status,
+            'detail' => $message,
+            'instance' => request()->getPathInfo(),
+        ];
+
+        if ($errors !== null && $errors !== []) {
+            $body['errors'] = $errors;
+        }
+
+        return new JsonResponse($body, $status, ['Content-Type' => 'application/problem+json']);
+    }
+`
+
+const jsonAPISuccess = `
+    public const MEDIA_TYPE = 'application/vnd.api+json';
+
+    /**
+     * A JSON:API document: {"data": resource objects, "meta": ..., "links": ...}.
+     * The message travels in meta.
+     */
+    public static function success(mixed $data = null, string $message = 'Success', int $status = 200, array $meta = [], array $links = []): JsonResponse
+    {
+        $document = [
+            'jsonapi' => ['version' => '1.1'],
+            'data' => static::resources($data),
+            'meta' => ['message' => $message] + $meta,
+        ];
+
+        if ($links !== []) {
+            $document['links'] = $links;
+        }
+
+        return new JsonResponse($document, $status, ['Content-Type' => static::MEDIA_TYPE]);
+    }
+
+    /**
+     * Turns models, API resources and lists of them into resource objects;
+     * anything else is passed through as it is. A resource's attributes are
+     * its toArray(), so fields it leaves out stay out.
+     */
+    protected static function resources(mixed $data): mixed
+    {
+        if ($data instanceof ResourceCollection) {
+            return $data->collection->map(fn ($item) => static::resources($item))->all();
+        }
+
+        if ($data instanceof JsonResource) {
+            $attributes = $data->resolve();
+
+            return $data->resource instanceof Model ? static::resource($data->resource, $attributes) : $attributes;
+        }
+
+        if ($data instanceof Model) {
+            return static::resource($data, $data->attributesToArray());
+        }
+
+        if ($data instanceof Collection || (is_array($data) && array_is_list($data))) {
+            return collect($data)->map(fn ($item) => $item instanceof Model || $item instanceof JsonResource ? static::resources($item) : $item)->all();
+        }
+
+        return $data;
+    }
+
+    protected static function resource(Model $model, array $attributes): array
+    {
+        unset($attributes['id'], $attributes[$model->getKeyName()]);
+
+        return [
+            'type' => $model->getTable(),
+            'id' => (string) $model->getKey(),
+            'attributes' => $attributes,
+        ];
+    }
+`
+
+const jsonAPIError = `
+    /**
+     * A JSON:API error document. Validation messages become one error
+     * each, pointing at the attribute.
+     */
+    public static function error(string $message = 'Error', int $status = 400, mixed $errors = null): JsonResponse
+    {
+        $list = [];
+
+        if (is_array($errors) && ! array_is_list($errors)) {
+            foreach ($errors as $field => $messages) {
+                foreach ((array) $messages as $detail) {
+                    $list[] = [
+                        'status' => (string) $status,
+                        'title' => $message,
+                        'detail' => $detail,
+                        'source' => ['pointer' => '/data/attributes/'.str_replace('.', '/', $field)],
+                    ];
+                }
+            }
+        }
+
+        if ($list === []) {
+            $list[] = [
+                'status' => (string) $status,
+                'title' => Response::$statusTexts[$status] ?? 'Error',
+                'detail' => $message,
+            ];
+        }
+
+        return new JsonResponse([
+            'jsonapi' => ['version' => '1.1'],
+            'errors' => $list,
+        ], $status, ['Content-Type' => static::MEDIA_TYPE]);
+    }
+`
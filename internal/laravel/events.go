@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewEventsSetup(projectPath string, dryRun bool) *EventsSetup {
 
 func (e *EventsSetup) Setup() error {
 	if e.DryRun {
-		fmt.Printf("[Dry Run] Would setup events and listeners\n")
+		ui.Printf("[Dry Run] Would setup events and listeners\n")
 		return nil
 	}
 
-	fmt.Println("📡 Setting up events and listeners...")
+	ui.Println("📡 Setting up events and listeners...")
 
 	eventsDir := filepath.Join(e.ProjectPath, "app/Events")
 	listenersDir := filepath.Join(e.ProjectPath, "app/Listeners")
@@ -85,7 +85,7 @@ abstract class BaseEvent
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Events/BaseEvent.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (e *EventsSetup) createBaseListener() error {
@@ -136,7 +136,7 @@ abstract class BaseListener implements ShouldQueue
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Listeners/BaseListener.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (e *EventsSetup) createUserRegisteredEvent() error {
@@ -182,7 +182,7 @@ class UserRegistered extends BaseEvent
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Events/UserRegistered.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (e *EventsSetup) createSendWelcomeEmailListener() error {
@@ -224,5 +224,5 @@ class SendWelcomeEmail extends BaseListener
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Listeners/SendWelcomeEmail.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
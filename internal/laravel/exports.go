@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewExportsSetup(projectPath string, dryRun bool) *ExportsSetup {
 
 func (e *ExportsSetup) Setup() error {
 	if e.DryRun {
-		fmt.Printf("[Dry Run] Would create app/Exports and app/Imports directories with base classes\n")
+		ui.Printf("[Dry Run] Would create app/Exports and app/Imports directories with base classes\n")
 		return nil
 	}
 
-	fmt.Println("📤 Setting up Exports/Imports structure...")
+	ui.Println("📤 Setting up Exports/Imports structure...")
 
 	// Create directories
 	exportsDir := filepath.Join(e.ProjectPath, "app/Exports")
@@ -72,7 +72,7 @@ abstract class BaseExport implements FromCollection, WithHeadings, WithMapping,
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Exports/BaseExport.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (e *ExportsSetup) createBaseImport() error {
@@ -103,5 +103,5 @@ abstract class BaseImport implements ToModel, WithHeadingRow, WithValidation, Sk
 }
 `
 	path := filepath.Join(e.ProjectPath, "app/Imports/BaseImport.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
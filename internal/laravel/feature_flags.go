@@ -0,0 +1,479 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultFeatureFlags are generated when options.feature-flags.flags is
+// empty.
+var DefaultFeatureFlags = []string{"new-dashboard"}
+
+type FeatureFlagsSetup struct {
+	ProjectPath string
+	DryRun      bool
+	Flags       []string
+}
+
+func NewFeatureFlagsSetup(projectPath string, dryRun bool) *FeatureFlagsSetup {
+	return &FeatureFlagsSetup{ProjectPath: projectPath, DryRun: dryRun, Flags: DefaultFeatureFlags}
+}
+
+func (f *FeatureFlagsSetup) Setup() error {
+	if f.DryRun {
+		ui.Printf("[Dry Run] Would install laravel/pennant and create flags: %s\n", strings.Join(f.Flags, ", "))
+		return nil
+	}
+
+	if err := composerRequire(f.ProjectPath, false, "laravel/pennant"); err != nil {
+		return err
+	}
+
+	ui.Println("⚙️ Publishing Pennant config and migration...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Pennant\\PennantServiceProvider")
+	cmd.Dir = f.ProjectPath
+	_, _ = ui.Run(cmd)
+
+	ui.Println("🚩 Setting up feature flags...")
+
+	steps := []func() error{
+		f.createConfig,
+		f.createFlags,
+		f.createScope,
+		f.createMiddleware,
+		f.createController,
+		f.createServiceProvider,
+		f.createTestHelpers,
+		f.registerRoutes,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if err := registerProvider(f.ProjectPath, "App\\Providers\\FeatureFlagServiceProvider"); err != nil {
+		return err
+	}
+	return aliasMiddleware(f.ProjectPath, "feature", "App\\Http\\Middleware\\RequireFeature")
+}
+
+func (f *FeatureFlagsSetup) createConfig() error {
+	// With the tenancy feature, flags default to the current tenant.
+	defaultScope, tenantScope := "user", "        // 'tenant' => \\App\\Models\\Team::class,\n"
+	if _, err := os.Stat(filepath.Join(f.ProjectPath, "config/tenancy.php")); err == nil {
+		defaultScope, tenantScope = "tenant", "        'tenant' => \\Stancl\\Tenancy\\Database\\Models\\Tenant::class,\n"
+	}
+
+	content := fmt.Sprintf(`<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Scopes
+    |--------------------------------------------------------------------------
+    |
+    | Models a flag can be set for. The admin API names a scope as
+    | "<type>:<id>", e.g. "user:5" or "tenant:acme".
+    |
+    */
+
+    'scopes' => [
+        'user' => \App\Models\User::class,
+%s    ],
+
+    // What feature checks without an explicit scope use: "user" (the
+    // signed-in user) or "tenant" (tenant() from stancl/tenancy).
+    'default_scope' => env('FEATURE_FLAGS_DEFAULT_SCOPE', '%s'),
+
+    // Status for routes whose flag is inactive; 404 hides them.
+    'inactive_status' => 404,
+
+    // Users with this role may use the admin API (manage-feature-flags gate).
+    'admin_role' => env('FEATURE_FLAGS_ADMIN_ROLE', 'admin'),
+
+];
+`, tenantScope, defaultScope)
+	dir := filepath.Join(f.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "feature-flags.php"), []byte(content), 0644)
+}
+
+func (f *FeatureFlagsSetup) createFlags() error {
+	dir := filepath.Join(f.ProjectPath, "app/Features")
+	os.MkdirAll(dir, 0755)
+
+	for _, flag := range f.Flags {
+		class := studly(flag)
+		path := filepath.Join(dir, class+".php")
+		// Definitions hold rollout rules; never overwrite one.
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		content := fmt.Sprintf(`<?php
+
+namespace App\Features;
+
+use Illuminate\Support\Lottery;
+
+/**
+ * resolve() decides the flag's value the first time a scope checks it;
+ * Pennant stores that value, so changing the rule only affects scopes
+ * that have not checked yet, or after "php artisan pennant:purge %[2]s".
+ */
+class %[1]s
+{
+    public string $name = '%[2]s';
+
+    public function resolve(mixed $scope): mixed
+    {
+        // Roll out to one in ten: return Lottery::odds(1, 10)->choose();
+        return false;
+    }
+}
+`, class, flag)
+		if err := ui.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FeatureFlagsSetup) createScope() error {
+	content := `<?php
+
+namespace App\Support\FeatureFlags;
+
+use Illuminate\Validation\ValidationException;
+
+/**
+ * Converts between the admin API's "<type>:<id>" scopes and the models
+ * Pennant checks flags for.
+ */
+class FeatureScope
+{
+    public static function parse(string $scope): mixed
+    {
+        [$type, $id] = array_pad(explode(':', $scope, 2), 2, null);
+        $class = config("feature-flags.scopes.{$type}");
+
+        if ($class === null || $id === null || $id === '') {
+            $types = implode(', ', array_keys(config('feature-flags.scopes', [])));
+
+            throw ValidationException::withMessages(['scope' => "The scope must be <type>:<id> with a type of {$types}."]);
+        }
+
+        return $class::findOrFail($id);
+    }
+
+    /**
+     * A scope as Pennant stores it ("App\Models\User|5") as "user:5".
+     */
+    public static function describe(string $stored): string
+    {
+        if ($stored === '__laravel_null') {
+            return 'global';
+        }
+
+        [$class, $id] = array_pad(explode('|', $stored, 2), 2, null);
+        $type = array_search($class, config('feature-flags.scopes', []), true);
+
+        return $type === false || $id === null ? $stored : "{$type}:{$id}";
+    }
+}
+`
+	dir := filepath.Join(f.ProjectPath, "app/Support/FeatureFlags")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "FeatureScope.php"), []byte(content), 0644)
+}
+
+func (f *FeatureFlagsSetup) createMiddleware() error {
+	content := `<?php
+
+namespace App\Http\Middleware;
+
+use Closure;
+use Illuminate\Http\Request;
+use Laravel\Pennant\Feature;
+use Symfony\Component\HttpFoundation\Response;
+
+/**
+ * Gates a route on one or more flags, checked for the default scope:
+ *
+ *   Route::get('/dashboard', DashboardController::class)->middleware('feature:new-dashboard');
+ *
+ * With several flags all must be active.
+ */
+class RequireFeature
+{
+    public function handle(Request $request, Closure $next, string ...$flags): Response
+    {
+        abort_unless(Feature::allAreActive($flags), config('feature-flags.inactive_status', 404));
+
+        return $next($request);
+    }
+}
+`
+	dir := filepath.Join(f.ProjectPath, "app/Http/Middleware")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "RequireFeature.php"), []byte(content), 0644)
+}
+
+func (f *FeatureFlagsSetup) createController() error {
+	content := `<?php
+
+namespace App\Http\Controllers\Api;
+
+use App\Http\Controllers\Controller;
+use App\Support\FeatureFlags\FeatureScope;
+use App\Traits\ApiResponse;
+use Illuminate\Http\Request;
+use Illuminate\Support\Facades\DB;
+use Laravel\Pennant\Feature;
+
+class FeatureFlagController extends Controller
+{
+    use ApiResponse;
+
+    /**
+     * Every defined flag, with its value for ?scope=<type>:<id> when given.
+     */
+    public function index(Request $request)
+    {
+        $flags = Feature::defined();
+
+        if ($request->filled('scope')) {
+            return $this->success(Feature::for(FeatureScope::parse($request->query('scope')))->values($flags));
+        }
+
+        return $this->success(array_map(fn (string $flag) => ['name' => $flag], $flags));
+    }
+
+    /**
+     * One flag with the scopes it has a stored value for, and its value
+     * for ?scope= when given.
+     */
+    public function show(Request $request, string $flag)
+    {
+        $this->ensureDefined($flag);
+
+        $data = ['name' => $flag, 'scopes' => $this->storedScopes($flag)];
+        if ($request->filled('scope')) {
+            $data['value'] = Feature::for(FeatureScope::parse($request->query('scope')))->value($flag);
+        }
+
+        return $this->success($data);
+    }
+
+    /**
+     * Set a flag for one scope, or for everyone:
+     * {"scope": "user:5", "value": true} or {"everyone": true, "value": false}.
+     */
+    public function update(Request $request, string $flag)
+    {
+        $this->ensureDefined($flag);
+
+        $validated = $request->validate([
+            'scope' => ['required_without:everyone', 'string'],
+            'everyone' => ['sometimes', 'boolean'],
+            'value' => ['present'],
+        ]);
+        $value = $validated['value'];
+
+        if ($request->boolean('everyone')) {
+            if ($value === false) {
+                Feature::deactivateForEveryone($flag);
+            } else {
+                Feature::activateForEveryone($flag, $value);
+            }
+
+            return $this->success(['name' => $flag, 'scope' => 'everyone', 'value' => $value], 'Feature flag updated');
+        }
+
+        $scope = FeatureScope::parse($validated['scope']);
+        if ($value === false) {
+            Feature::for($scope)->deactivate($flag);
+        } else {
+            Feature::for($scope)->activate($flag, $value);
+        }
+
+        return $this->success(['name' => $flag, 'scope' => $validated['scope'], 'value' => $value], 'Feature flag updated');
+    }
+
+    /**
+     * Forget the stored value for ?scope=, or for every scope without one,
+     * so the flag's definition decides again.
+     */
+    public function destroy(Request $request, string $flag)
+    {
+        $this->ensureDefined($flag);
+
+        if ($request->filled('scope')) {
+            Feature::for(FeatureScope::parse($request->query('scope')))->forget($flag);
+        } else {
+            Feature::purge($flag);
+        }
+
+        return $this->noContent();
+    }
+
+    protected function ensureDefined(string $flag): void
+    {
+        abort_unless(in_array($flag, Feature::defined(), true), 404, "Feature flag [{$flag}] is not defined.");
+    }
+
+    /**
+     * Stored values are only listable with Pennant's database store.
+     */
+    protected function storedScopes(string $flag): ?array
+    {
+        $store = config('pennant.default');
+        if (config("pennant.stores.{$store}.driver") !== 'database') {
+            return null;
+        }
+
+        return DB::connection(config("pennant.stores.{$store}.connection"))
+            ->table(config("pennant.stores.{$store}.table", 'features'))
+            ->where('name', $flag)
+            ->orderBy('scope')
+            ->get(['scope', 'value'])
+            ->map(fn ($row) => ['scope' => FeatureScope::describe($row->scope), 'value' => json_decode($row->value, true)])
+            ->all();
+    }
+}
+`
+	dir := filepath.Join(f.ProjectPath, "app/Http/Controllers/Api")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "FeatureFlagController.php"), []byte(content), 0644)
+}
+
+func (f *FeatureFlagsSetup) createServiceProvider() error {
+	content := `<?php
+
+namespace App\Providers;
+
+use Illuminate\Support\Facades\Gate;
+use Illuminate\Support\ServiceProvider;
+use Laravel\Pennant\Feature;
+
+class FeatureFlagServiceProvider extends ServiceProvider
+{
+    public function boot(): void
+    {
+        // Register every class in app/Features under its $name.
+        Feature::discover();
+
+        if (config('feature-flags.default_scope') === 'tenant') {
+            Feature::resolveScopeUsing(fn () => function_exists('tenant') ? tenant() : null);
+        }
+
+        // Who may use the admin API; adjust to your authorization rules.
+        Gate::define('manage-feature-flags', fn ($user) => method_exists($user, 'hasRole')
+            && $user->hasRole(config('feature-flags.admin_role', 'admin')));
+    }
+}
+`
+	dir := filepath.Join(f.ProjectPath, "app/Providers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "FeatureFlagServiceProvider.php"), []byte(content), 0644)
+}
+
+func (f *FeatureFlagsSetup) createTestHelpers() error {
+	content := `<?php
+
+use Laravel\Pennant\Feature;
+
+/*
+|--------------------------------------------------------------------------
+| Feature Flag Helpers
+|--------------------------------------------------------------------------
+|
+| Force flag state in tests. Set PENNANT_STORE=array in phpunit.xml so
+| every test starts from the flag definitions.
+|
+|   activateFeature('new-dashboard');                   // default scope
+|   activateFeature('new-dashboard', for: $user);
+|   activateFeature('checkout', 'variant-b', $tenant);  // rich values
+|   deactivateFeature(['new-dashboard', 'beta']);
+|   expect($user)->toHaveFeature('new-dashboard');
+|
+*/
+
+if (! function_exists('activateFeature')) {
+    function activateFeature(string|array $flags, mixed $value = true, mixed $for = null): void
+    {
+        $for === null ? Feature::activate($flags, $value) : Feature::for($for)->activate($flags, $value);
+    }
+}
+
+if (! function_exists('deactivateFeature')) {
+    function deactivateFeature(string|array $flags, mixed $for = null): void
+    {
+        $for === null ? Feature::deactivate($flags) : Feature::for($for)->deactivate($flags);
+    }
+}
+
+if (function_exists('expect')) {
+    expect()->extend('toHaveFeature', function (string $flag) {
+        expect(Feature::for($this->value)->active($flag))->toBeTrue("Expected feature [{$flag}] to be active.");
+
+        return $this;
+    });
+}
+`
+	dir := filepath.Join(f.ProjectPath, "tests/Support")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "FeatureFlags.php"), []byte(content), 0644); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(f.ProjectPath, "tests/Pest.php")); err != nil {
+		ui.Println("ℹ️ tests/Pest.php not found; require tests/Support/FeatureFlags.php from your test bootstrap to use the flag helpers")
+		return nil
+	}
+	return linkTestSupport(f.ProjectPath)
+}
+
+// linkTestSupport requires the generated test helpers from tests/Pest.php.
+// quality calls it too, since it may create Pest.php after the helpers.
+func linkTestSupport(projectPath string) error {
+	pest := filepath.Join(projectPath, "tests/Pest.php")
+	data, err := os.ReadFile(pest)
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "tests/Support/FeatureFlags.php")); err != nil {
+		return nil
+	}
+	if strings.Contains(string(data), "Support/FeatureFlags.php") {
+		return nil
+	}
+	line := "\nrequire_once __DIR__.'/Support/FeatureFlags.php';\n"
+	return ui.WriteFile(pest, append(data, line...), 0644)
+}
+
+func (f *FeatureFlagsSetup) registerRoutes() error {
+	path := filepath.Join(f.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping feature flag routes\n")
+		return nil
+	}
+	if strings.Contains(string(content), "FeatureFlagController") {
+		return nil
+	}
+
+	routes := `
+Route::middleware(['auth:sanctum', 'can:manage-feature-flags'])->prefix('feature-flags')->group(function () {
+    Route::get('/', [\App\Http\Controllers\Api\FeatureFlagController::class, 'index']);
+    Route::get('/{flag}', [\App\Http\Controllers\Api\FeatureFlagController::class, 'show']);
+    Route::put('/{flag}', [\App\Http\Controllers\Api\FeatureFlagController::class, 'update']);
+    Route::delete('/{flag}', [\App\Http\Controllers\Api\FeatureFlagController::class, 'destroy']);
+});
+`
+	return ui.WriteFile(path, append(content, routes...), 0644)
+}
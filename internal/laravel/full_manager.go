@@ -1,10 +1,16 @@
 package laravel
 
-import "fmt"
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+)
 
 type FullStackManager struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
+	Policy      Policy
 }
 
 func NewFullStackManager(projectPath string, dryRun bool) *FullStackManager {
@@ -12,28 +18,35 @@ func NewFullStackManager(projectPath string, dryRun bool) *FullStackManager {
 }
 
 func (m *FullStackManager) AddAll() error {
-	fmt.Println("🌟 Installing the COMPLETE LaravelBoot Stack...")
+	ui.Println("🌟 Installing the COMPLETE LaravelBoot Stack...")
 
-	// 1. Auth & Database
-	if err := NewAuthManager(m.ProjectPath, m.DryRun).AddAuth(); err != nil {
-		return err
+	// The summary is printed here unless the caller keeps its own report.
+	policy := m.Policy
+	if policy.Report == nil {
+		policy.Report = NewReport("add all")
+		defer policy.Report.Print()
 	}
 
-	// 2. Platform (Roles, Media, Search, Activity)
-	if err := NewPlatformManager(m.ProjectPath, m.DryRun).RunStep("platform"); err != nil {
-		return err
+	// 1. Auth & Database
+	auth := NewAuthManager(m.ProjectPath, m.DryRun)
+	auth.Options = m.Options
+	authErr := policy.track("auth", auth.AddAuth)
+	if authErr != nil && policy.FailFast {
+		return authErr
 	}
 
-	// 3. Infrastructure (Docker, Security, Rate Limit, Health)
-	if err := NewInfraManager(m.ProjectPath, m.DryRun).RunStep("infra"); err != nil {
+	// 2-4. Platform, infrastructure and enterprise, with one composer
+	// batch and independent setups running in parallel
+	o := NewOrchestrator(m.ProjectPath, m.DryRun)
+	o.Options = m.Options
+	o.Policy = policy
+	if err := o.Run(ExpandFeatures([]string{"platform", "infra", "enterprise"})); err != nil {
 		return err
 	}
-
-	// 4. Enterprise (Quality, Pro-Arch, Docs, CI, monitoring)
-	if err := NewEnterpriseManager(m.ProjectPath, m.DryRun).RunStep("enterprise"); err != nil {
-		return err
+	if authErr != nil {
+		return fmt.Errorf("auth: %v", authErr)
 	}
 
-	fmt.Println("\n🏆 CONGRATULATIONS! Your project is now fully loaded and production-ready.")
+	ui.Println("\n🏆 CONGRATULATIONS! Your project is now fully loaded and production-ready.")
 	return nil
 }
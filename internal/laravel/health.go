@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -59,7 +60,7 @@ class HealthController extends Controller
 `
 	path := filepath.Join(h.ProjectPath, "app/Http/Controllers/Api/HealthController.php")
 	if h.DryRun {
-		fmt.Printf("[Dry Run] Would create HealthController: %s\n", path)
+		ui.Printf("[Dry Run] Would create HealthController: %s\n", path)
 		return nil
 	}
 
@@ -68,22 +69,22 @@ class HealthController extends Controller
 		return fmt.Errorf("failed to create directory %s: %v", dir, err)
 	}
 
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (h *HealthSetup) registerRoute() error {
 	path := filepath.Join(h.ProjectPath, "routes/api.php")
 	if h.DryRun {
-		fmt.Printf("[Dry Run] Would add health route to %s\n", path)
+		ui.Printf("[Dry Run] Would add health route to %s\n", path)
 		return nil
 	}
 
 	// Check if api.php exists (Laravel 11+ might not have it by default)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		fmt.Println("📍 routes/api.php not found. Running php artisan install:api...")
+		ui.Println("📍 routes/api.php not found. Running php artisan install:api...")
 		cmd := exec.Command("php", "artisan", "install:api", "--no-interaction")
 		cmd.Dir = h.ProjectPath
-		if output, err := cmd.CombinedOutput(); err != nil {
+		if output, err := ui.Run(cmd); err != nil {
 			return fmt.Errorf("failed to run install:api: %v\nOutput: %s", err, string(output))
 		}
 	}
@@ -94,9 +95,9 @@ func (h *HealthSetup) registerRoute() error {
 	}
 
 	route := "\nRoute::get('/health', [\\App\\Http\\Controllers\\Api\\HealthController::class, 'check']);\n"
-	if !strings.Contains(string(content), "/health") {
+	if !strings.Contains(string(content), "Api\\HealthController::class") {
 		newContent := string(content) + route
-		return os.WriteFile(path, []byte(newContent), 0644)
+		return ui.WriteFile(path, []byte(newContent), 0644)
 	}
 	return nil
 }
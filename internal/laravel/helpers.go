@@ -3,6 +3,7 @@ package laravel
 import (
 	"encoding/json"
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -18,11 +19,11 @@ func NewHelpersSetup(projectPath string, dryRun bool) *HelpersSetup {
 
 func (h *HelpersSetup) Setup() error {
 	if h.DryRun {
-		fmt.Printf("[Dry Run] Would create app/helpers.php and register it in composer.json\n")
+		ui.Printf("[Dry Run] Would create app/helpers.php and register it in composer.json\n")
 		return nil
 	}
 
-	fmt.Println("🤝 Setting up global helpers...")
+	ui.Println("🤝 Setting up global helpers...")
 
 	// 1. Create app/helpers.php
 	content := `<?php
@@ -51,7 +52,7 @@ if (!function_exists('api_response')) {
 }
 `
 	helperPath := filepath.Join(h.ProjectPath, "app/helpers.php")
-	if err := os.WriteFile(helperPath, []byte(content), 0644); err != nil {
+	if err := ui.WriteFile(helperPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("failed to create app/helpers.php: %v", err)
 	}
 
@@ -96,7 +97,7 @@ if (!function_exists('api_response')) {
 			return fmt.Errorf("failed to marshal composer.json: %v", err)
 		}
 
-		if err := os.WriteFile(composerPath, newData, 0644); err != nil {
+		if err := ui.WriteFile(composerPath, newData, 0644); err != nil {
 			return fmt.Errorf("failed to update composer.json: %v", err)
 		}
 	}
@@ -0,0 +1,285 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type IdempotencySetup struct {
+	ProjectPath string
+	DryRun      bool
+	Store       string // cache store; empty means the app's cache store
+}
+
+func NewIdempotencySetup(projectPath string, dryRun bool) *IdempotencySetup {
+	return &IdempotencySetup{ProjectPath: projectPath, DryRun: dryRun}
+}
+
+func (i *IdempotencySetup) Setup() error {
+	if i.DryRun {
+		ui.Printf("[Dry Run] Would create Idempotency-Key middleware, config and prune command\n")
+		return nil
+	}
+
+	// PruneIdempotencyKeysCommand extends BaseCommand.
+	if err := requireFeatures(i.ProjectPath, "idempotency", "scheduler"); err != nil {
+		return err
+	}
+
+	ui.Println("🔁 Setting up idempotency keys...")
+
+	if err := i.createConfig(); err != nil {
+		return err
+	}
+	if err := i.createMiddleware(); err != nil {
+		return err
+	}
+	if err := i.createPruneCommand(); err != nil {
+		return err
+	}
+	if err := aliasMiddleware(i.ProjectPath, "idempotent", "App\\Http\\Middleware\\EnsureIdempotency"); err != nil {
+		return err
+	}
+	return scheduleCommand(i.ProjectPath, "Schedule::command('idempotency:prune')->hourly();")
+}
+
+func (i *IdempotencySetup) createConfig() error {
+	content := `<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Cache Store
+    |--------------------------------------------------------------------------
+    |
+    | Stored responses and in-flight locks live in this cache store. It must
+    | support atomic locks (redis, database, memcached, dynamodb, file or
+    | array). Defaults to the application's cache store.
+    |
+    */
+
+    'store' => env('IDEMPOTENCY_STORE'),
+
+    // How long a stored response is replayed for, in seconds.
+    'ttl' => (int) env('IDEMPOTENCY_TTL', 86400),
+
+    // How long a request may hold its key before a duplicate may run it again.
+    'lock_seconds' => (int) env('IDEMPOTENCY_LOCK_SECONDS', 30),
+
+    'header' => 'Idempotency-Key',
+
+    // Methods the middleware applies to; other requests pass straight through.
+    'methods' => ['POST', 'PUT', 'PATCH', 'DELETE'],
+
+    // Reject requests to idempotent routes that have no key.
+    'required' => (bool) env('IDEMPOTENCY_REQUIRED', false),
+
+    'prefix' => 'idempotency',
+
+];
+`
+	if i.Store != "" {
+		content = strings.Replace(content, "env('IDEMPOTENCY_STORE')", fmt.Sprintf("env('IDEMPOTENCY_STORE', '%s')", i.Store), 1)
+	}
+	dir := filepath.Join(i.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "idempotency.php"), []byte(content), 0644)
+}
+
+func (i *IdempotencySetup) createMiddleware() error {
+	content := `<?php
+
+namespace App\Http\Middleware;
+
+use Closure;
+use Illuminate\Contracts\Cache\Repository;
+use Illuminate\Http\Request;
+use Illuminate\Support\Facades\Cache;
+use Symfony\Component\HttpFoundation\Response;
+
+/**
+ * Makes retried requests safe: the first response for an Idempotency-Key is
+ * stored and replayed for every retry with the same key and payload.
+ *
+ *   Route::post('/orders', [OrderController::class, 'store'])->middleware('idempotent');
+ *
+ * A retry that arrives while the first request is still running gets 409
+ * with Retry-After. Reusing a key with a different payload gets 422.
+ * Server errors (5xx) are not stored, so the client can retry them.
+ */
+class EnsureIdempotency
+{
+    public function handle(Request $request, Closure $next): Response
+    {
+        $header = config('idempotency.header', 'Idempotency-Key');
+
+        if (! in_array($request->method(), config('idempotency.methods', ['POST']), true)) {
+            return $next($request);
+        }
+
+        $key = $request->header($header);
+        if ($key === null || $key === '') {
+            abort_if(config('idempotency.required', false), 400, "The {$header} header is required.");
+
+            return $next($request);
+        }
+        abort_if(strlen($key) > 255, 400, "The {$header} header must not exceed 255 characters.");
+
+        $store = $this->store();
+        $cacheKey = $this->cacheKey($request, $key);
+        $fingerprint = $this->fingerprint($request);
+
+        if ($stored = $store->get($cacheKey)) {
+            return $this->replay($stored, $fingerprint, $header);
+        }
+
+        $lock = $store->lock($cacheKey.':lock', config('idempotency.lock_seconds', 30));
+        if (! $lock->get()) {
+            abort(409, "A request with this {$header} is still being processed.", ['Retry-After' => 1]);
+        }
+
+        try {
+            // The first request may have finished between the lookup and the lock.
+            if ($stored = $store->get($cacheKey)) {
+                return $this->replay($stored, $fingerprint, $header);
+            }
+
+            $response = $next($request);
+
+            if ($response->getStatusCode() < 500) {
+                $store->put($cacheKey, [
+                    'fingerprint' => $fingerprint,
+                    'status' => $response->getStatusCode(),
+                    'headers' => $response->headers->all(),
+                    'content' => $response->getContent(),
+                    'created_at' => now()->toIso8601String(),
+                ], config('idempotency.ttl', 86400));
+            }
+
+            return $response;
+        } finally {
+            $lock->release();
+        }
+    }
+
+    protected function replay(array $stored, string $fingerprint, string $header): Response
+    {
+        abort_if(! hash_equals($stored['fingerprint'], $fingerprint), 422, "This {$header} was already used with a different request payload.");
+
+        $response = new Response($stored['content'], $stored['status'], $stored['headers']);
+        $response->headers->set('Idempotent-Replayed', 'true');
+
+        return $response;
+    }
+
+    protected function store(): Repository
+    {
+        return Cache::store(config('idempotency.store'));
+    }
+
+    /**
+     * Keys are scoped to the user (or IP for guests) so clients cannot see
+     * each other's responses.
+     */
+    protected function cacheKey(Request $request, string $key): string
+    {
+        $scope = $request->user()?->getAuthIdentifier() ?? $request->ip();
+
+        return config('idempotency.prefix', 'idempotency').':'.hash('sha256', $scope.'|'.$key);
+    }
+
+    protected function fingerprint(Request $request): string
+    {
+        return hash('sha256', implode('|', [$request->method(), $request->path(), $request->getContent()]));
+    }
+}
+`
+	dir := filepath.Join(i.ProjectPath, "app/Http/Middleware")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "EnsureIdempotency.php"), []byte(content), 0644)
+}
+
+func (i *IdempotencySetup) createPruneCommand() error {
+	content := `<?php
+
+namespace App\Console\Commands;
+
+use Illuminate\Support\Facades\Cache;
+use Illuminate\Support\Facades\DB;
+
+/**
+ * Removes expired idempotency records. Redis and memcached expire them on
+ * their own; the database and file stores only drop expired entries when
+ * they are read, so their tables and directories grow without this. File
+ * store keys are hashed, so that store loses every expired entry, which is
+ * harmless.
+ */
+class PruneIdempotencyKeysCommand extends BaseCommand
+{
+    protected $signature = 'idempotency:prune {--dry-run : Count without deleting}';
+
+    protected $description = 'Delete expired Idempotency-Key records from the cache store';
+
+    public function handle(): int
+    {
+        return $this->executeWithTiming(function () {
+            $store = config('idempotency.store') ?? config('cache.default');
+            $driver = config("cache.stores.{$store}.driver");
+
+            $count = match ($driver) {
+                'database' => $this->pruneDatabase($store),
+                'file' => $this->pruneFiles($store),
+                default => null,
+            };
+
+            if ($count === null) {
+                $this->logInfo("The {$driver} store expires idempotency records itself; nothing to prune");
+            } else {
+                $this->logInfo("Idempotency records: {$count} expired " . ($this->option('dry-run') ? 'would be deleted' : 'deleted'));
+            }
+
+            return self::SUCCESS;
+        });
+    }
+
+    protected function pruneDatabase(string $store): int
+    {
+        $config = config("cache.stores.{$store}");
+        $prefix = ($config['prefix'] ?? config('cache.prefix')) . config('idempotency.prefix', 'idempotency') . ':';
+
+        $query = DB::connection($config['connection'] ?? null)
+            ->table($config['table'] ?? 'cache')
+            ->where('key', 'like', $prefix . '%')
+            ->where('expiration', '<', now()->getTimestamp());
+
+        return $this->option('dry-run') ? $query->count() : $query->delete();
+    }
+
+    protected function pruneFiles(string $store): int
+    {
+        $count = 0;
+        $files = Cache::store($store)->getStore()->getFilesystem();
+        $directory = config("cache.stores.{$store}.path");
+
+        foreach ($files->allFiles($directory) as $file) {
+            $expires = (int) substr($files->get($file->getPathname()), 0, 10);
+            if ($expires > 0 && $expires < now()->getTimestamp()) {
+                $count++;
+                if (! $this->option('dry-run')) {
+                    $files->delete($file->getPathname());
+                }
+            }
+        }
+
+        return $count;
+    }
+}
+`
+	dir := filepath.Join(i.ProjectPath, "app/Console/Commands")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "PruneIdempotencyKeysCommand.php"), []byte(content), 0644)
+}
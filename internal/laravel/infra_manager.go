@@ -2,43 +2,61 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 )
 
 type InfraManager struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
+	Policy      Policy
 }
 
 func NewInfraManager(projectPath string, dryRun bool) *InfraManager {
 	return &InfraManager{ProjectPath: projectPath, DryRun: dryRun}
 }
 
+// RunStep installs one feature, or a whole bundle, and records what it
+// installed in .laravelboot/installed.yaml.
 func (m *InfraManager) RunStep(name string) error {
+	return recordStep(m.ProjectPath, m.DryRun, name, func() error { return m.runStep(name) })
+}
+
+func (m *InfraManager) runStep(name string) error {
 	switch name {
 	case "docker":
-		return NewDockerSetup(m.ProjectPath, m.DryRun).Setup()
+		return m.docker().Setup()
 	case "security":
 		return NewSecuritySetup(m.ProjectPath, m.DryRun).Setup()
 	case "rate-limit":
-		return NewRateLimitSetup(m.ProjectPath, m.DryRun).Setup()
+		return m.rateLimit().Setup()
 	case "health":
 		return NewHealthSetup(m.ProjectPath, m.DryRun).Setup()
 	case "infra":
-		fmt.Println("🚀 Hardening infrastructure and security...")
-		if err := NewDockerSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewSecuritySetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewRateLimitSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewHealthSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		return nil
+		ui.Println("🚀 Hardening infrastructure and security...")
+		o := NewOrchestrator(m.ProjectPath, m.DryRun)
+		o.Options = m.Options
+		o.Policy = m.Policy
+		return o.Run(Bundles["infra"])
 	default:
 		return fmt.Errorf("unknown infra feature: %s", name)
 	}
 }
+
+func (m *InfraManager) docker() *DockerSetup {
+	d := NewDockerSetup(m.ProjectPath, m.DryRun)
+	if v := m.Options.Docker.PHPVersion; v != "" {
+		d.PHPVersion = v
+	}
+	d.Services = installedServices(m.ProjectPath, m.Options)
+	return d
+}
+
+func (m *InfraManager) rateLimit() *RateLimitSetup {
+	r := NewRateLimitSetup(m.ProjectPath, m.DryRun)
+	if n := m.Options.RateLimit.PerMinute; n > 0 {
+		r.PerMinute = n
+	}
+	return r
+}
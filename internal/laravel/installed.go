@@ -0,0 +1,111 @@
+package laravel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// installedRecord lists the catalog features LaravelBoot installed in a
+// project. apply only removes features named here, so packages and files
+// that merely look like a feature (the skeleton's laravel/pint, a
+// hand-written FileController) are never touched.
+type installedRecord struct {
+	Features []string `yaml:"features"`
+}
+
+// installedMu serialises updates from steps running in the same wave.
+var installedMu sync.Mutex
+
+// InstalledPath is where a project records the features LaravelBoot
+// installed.
+func InstalledPath(projectPath string) string {
+	return filepath.Join(projectPath, ".laravelboot", "installed.yaml")
+}
+
+// installedFeatures reads the record; a project without one has none.
+func installedFeatures(projectPath string) (map[string]bool, error) {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+	rec, err := readInstalled(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, name := range rec.Features {
+		names[name] = true
+	}
+	return names, nil
+}
+
+// recordStep runs a feature's setup and, once it succeeds, records the
+// feature as installed. Bundles record each member as it runs.
+func recordStep(projectPath string, dryRun bool, name string, setup func() error) error {
+	if err := setup(); err != nil {
+		return err
+	}
+	f, ok := LookupFeature(name)
+	if dryRun || !ok {
+		return nil
+	}
+	return updateInstalled(projectPath, func(names map[string]bool) { names[f.Name] = true })
+}
+
+// forgetInstalled drops a removed feature from the record.
+func forgetInstalled(projectPath, name string) error {
+	return updateInstalled(projectPath, func(names map[string]bool) { delete(names, name) })
+}
+
+func updateInstalled(projectPath string, change func(map[string]bool)) error {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+	rec, err := readInstalled(projectPath)
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, name := range rec.Features {
+		names[name] = true
+	}
+	change(names)
+
+	rec.Features = rec.Features[:0]
+	for name := range names {
+		rec.Features = append(rec.Features, name)
+	}
+	sort.Strings(rec.Features)
+	data, err := yaml.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	path := InstalledPath(projectPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readInstalled(projectPath string) (*installedRecord, error) {
+	path := InstalledPath(projectPath)
+	rec := &installedRecord{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return rec, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return rec, nil
+}
@@ -2,7 +2,10 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
+	"os"
 	"os/exec"
+	"path/filepath"
 )
 
 type Installer struct {
@@ -13,40 +16,50 @@ func NewInstaller(dryRun bool) *Installer {
 	return &Installer{DryRun: dryRun}
 }
 
-func (i *Installer) CheckDependencies() error {
-	deps := []string{"php", "composer"}
-	for _, dep := range deps {
-		if _, err := exec.LookPath(dep); err != nil {
-			return fmt.Errorf("%s is not installed or not in PATH", dep)
-		}
-	}
-	return nil
-}
-
 func (i *Installer) HasLaravelInstaller() bool {
 	_, err := exec.LookPath("laravel")
 	return err == nil
 }
 
+// CreateProject creates the Laravel project name in the current directory.
+// It is built in a staging directory and moved into place once complete,
+// so an interrupted run never leaves a half-created project behind.
 func (i *Installer) CreateProject(name string) error {
+	staging := StagingDir(name)
 	var cmd *exec.Cmd
 	if i.HasLaravelInstaller() {
-		fmt.Printf("Using Laravel installer to create %s...\n", name)
+		ui.Printf("Using Laravel installer to create %s...\n", name)
 		cmd = exec.Command("laravel", "new", name, "--no-interaction")
 	} else {
-		fmt.Printf("Laravel installer not found. Using composer to create %s...\n", name)
+		ui.Printf("Laravel installer not found. Using composer to create %s...\n", name)
 		cmd = exec.Command("composer", "create-project", "laravel/laravel", name)
 	}
 
 	if i.DryRun {
-		fmt.Printf("[Dry Run] Would run: %s\n", cmd.String())
+		ui.Printf("[Dry Run] Would run: %s\n", cmd.String())
 		return nil
 	}
 
-	output, err := cmd.CombinedOutput()
+	// A staging directory left by an earlier run is only ever ours.
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	cmd.Dir = staging
+
+	output, err := ui.Run(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to create project: %v\nOutput: %s", err, string(output))
 	}
 
-	return nil
+	return os.Rename(filepath.Join(staging, name), name)
+}
+
+// StagingDir is where CreateProject builds the project name before moving
+// it into place.
+func StagingDir(name string) string {
+	return "." + name + ".laravelboot-partial"
 }
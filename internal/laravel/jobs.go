@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewJobsSetup(projectPath string, dryRun bool) *JobsSetup {
 
 func (j *JobsSetup) Setup() error {
 	if j.DryRun {
-		fmt.Printf("[Dry Run] Would create app/Jobs directory with base job class\n")
+		ui.Printf("[Dry Run] Would create app/Jobs directory with base job class\n")
 		return nil
 	}
 
-	fmt.Println("⚙️ Setting up Jobs structure...")
+	ui.Println("⚙️ Setting up Jobs structure...")
 
 	jobsDir := filepath.Join(j.ProjectPath, "app/Jobs")
 	os.MkdirAll(jobsDir, 0755)
@@ -77,5 +77,5 @@ abstract class BaseJob implements ShouldQueue
 }
 `
 	path := filepath.Join(j.ProjectPath, "app/Jobs/BaseJob.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewLoggingSetup(projectPath string, dryRun bool) *LoggingSetup {
 
 func (l *LoggingSetup) Setup() error {
 	if l.DryRun {
-		fmt.Printf("[Dry Run] Would setup logging and debugging\n")
+		ui.Printf("[Dry Run] Would setup logging and debugging\n")
 		return nil
 	}
 
-	fmt.Println("📝 Setting up logging and debugging...")
+	ui.Println("📝 Setting up logging and debugging...")
 
 	if err := l.createRequestLogMiddleware(); err != nil {
 		return err
@@ -117,7 +117,7 @@ class LogRequests
 `
 	dir := filepath.Join(l.ProjectPath, "app/Http/Middleware")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "LogRequests.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "LogRequests.php"), []byte(content), 0644)
 }
 
 func (l *LoggingSetup) createLogService() error {
@@ -233,7 +233,7 @@ class LogService
 `
 	dir := filepath.Join(l.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "LogService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "LogService.php"), []byte(content), 0644)
 }
 
 func (l *LoggingSetup) createSlackLogHandler() error {
@@ -310,5 +310,5 @@ class SlackLogHandler
 `
 	dir := filepath.Join(l.ProjectPath, "app/Logging")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "SlackLogHandler.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "SlackLogHandler.php"), []byte(content), 0644)
 }
@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,33 +18,30 @@ func NewMediaSetup(projectPath string, dryRun bool) *MediaSetup {
 
 func (m *MediaSetup) Setup() error {
 	if m.DryRun {
-		fmt.Printf("[Dry Run] Would install spatie/laravel-medialibrary with config and service\n")
+		ui.Printf("[Dry Run] Would install spatie/laravel-medialibrary with config and service\n")
 		return nil
 	}
 
-	fmt.Println("🖼️ Installing spatie/laravel-medialibrary...")
-	cmd := exec.Command("composer", "require", "spatie/laravel-medialibrary", "--with-all-dependencies")
-	cmd.Dir = m.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install spatie/laravel-medialibrary: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(m.ProjectPath, false, "spatie/laravel-medialibrary"); err != nil {
+		return err
 	}
 
-	fmt.Println("📦 Publishing migrations...")
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\MediaLibrary\\MediaLibraryServiceProvider", "--tag=medialibrary-migrations")
+	ui.Println("📦 Publishing migrations...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\MediaLibrary\\MediaLibraryServiceProvider", "--tag=medialibrary-migrations")
 	cmd.Dir = m.ProjectPath
-	_ = cmd.Run()
+	_, _ = ui.Run(cmd)
 
-	fmt.Println("⚙️ Publishing config...")
+	ui.Println("⚙️ Publishing config...")
 	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\MediaLibrary\\MediaLibraryServiceProvider", "--tag=medialibrary-config")
 	cmd.Dir = m.ProjectPath
-	_ = cmd.Run()
+	_, _ = ui.Run(cmd)
 
-	fmt.Println("🔧 Creating SpatieMediaService...")
+	ui.Println("🔧 Creating SpatieMediaService...")
 	if err := m.createMediaService(); err != nil {
 		return err
 	}
 
-	fmt.Println("🔧 Creating HasMedia trait...")
+	ui.Println("🔧 Creating HasMedia trait...")
 	if err := m.createHasMediaTrait(); err != nil {
 		return err
 	}
@@ -144,7 +141,7 @@ class SpatieMediaService
 `
 	dir := filepath.Join(m.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "SpatieMediaService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "SpatieMediaService.php"), []byte(content), 0644)
 }
 
 func (m *MediaSetup) createHasMediaTrait() error {
@@ -201,5 +198,5 @@ trait HasMedia
 `
 	dir := filepath.Join(m.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "HasMedia.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "HasMedia.php"), []byte(content), 0644)
 }
@@ -0,0 +1,944 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMessagingDriver is the broker used when options.messaging.driver
+// is unset.
+const DefaultMessagingDriver = "rabbitmq"
+
+type messagingDriverInfo struct {
+	class       string   // broker class under App\Messaging\Drivers
+	packages    []string // composer packages for the client
+	devPackages []string
+	extension   string // PHP extension the client needs, if any
+	config      string // the driver's block in config/messaging.php
+}
+
+var messagingDrivers = map[string]messagingDriverInfo{
+	"rabbitmq": {
+		class:     "RabbitMqBroker",
+		packages:  []string{"php-amqplib/php-amqplib"},
+		extension: "sockets",
+		config: `        'rabbitmq' => [
+            'class' => \App\Messaging\Drivers\RabbitMqBroker::class,
+            'host' => env('RABBITMQ_HOST', 'rabbitmq'),
+            'port' => (int) env('RABBITMQ_PORT', 5672),
+            'user' => env('RABBITMQ_USER', 'guest'),
+            'password' => env('RABBITMQ_PASSWORD', 'guest'),
+            'vhost' => env('RABBITMQ_VHOST', '/'),
+            // Topic exchange that topics are routing keys on.
+            'exchange' => env('RABBITMQ_EXCHANGE', 'app'),
+            'prefetch' => 10,
+        ],
+`,
+	},
+	"kafka": {
+		class:       "KafkaBroker",
+		devPackages: []string{"kwn/php-rdkafka-stubs"},
+		extension:   "rdkafka",
+		config: `        'kafka' => [
+            'class' => \App\Messaging\Drivers\KafkaBroker::class,
+            'brokers' => env('KAFKA_BROKERS', 'kafka:9092'),
+            'flush_timeout_ms' => 10000,
+            // Extra librdkafka settings, e.g. security.protocol and sasl.*.
+            'options' => [
+                'enable.idempotence' => 'true',
+            ],
+        ],
+`,
+	},
+	"redis": {
+		class:    "RedisStreamsBroker",
+		packages: []string{"predis/predis"},
+		config: `        'redis' => [
+            'class' => \App\Messaging\Drivers\RedisStreamsBroker::class,
+            // A connection from config/database.php.
+            'connection' => env('MESSAGING_REDIS_CONNECTION', 'default'),
+            // Unique per consumer process that should keep its own pending list.
+            'consumer' => env('MESSAGING_CONSUMER_NAME', gethostname()),
+            // Streams are trimmed to roughly this many entries.
+            'maxlen' => 100000,
+        ],
+`,
+	},
+}
+
+func messagingDriver(driver string) string {
+	if driver == "" {
+		return DefaultMessagingDriver
+	}
+	return driver
+}
+
+type MessagingSetup struct {
+	ProjectPath string
+	DryRun      bool
+	Driver      string // rabbitmq, kafka or redis
+}
+
+func NewMessagingSetup(projectPath string, dryRun bool) *MessagingSetup {
+	return &MessagingSetup{ProjectPath: projectPath, DryRun: dryRun, Driver: DefaultMessagingDriver}
+}
+
+func (m *MessagingSetup) Setup() error {
+	driver, ok := messagingDrivers[m.Driver]
+	if !ok {
+		return fmt.Errorf("unknown messaging driver: %s", m.Driver)
+	}
+
+	if m.DryRun {
+		if pkgs := append(append([]string{}, driver.packages...), driver.devPackages...); len(pkgs) > 0 {
+			ui.Printf("[Dry Run] Would install %s\n", strings.Join(pkgs, ", "))
+		}
+		ui.Printf("[Dry Run] Would create %s broker, base publisher and consumer, and messaging:consume command\n", m.Driver)
+		return nil
+	}
+
+	// messaging:consume extends BaseCommand.
+	if err := requireFeatures(m.ProjectPath, "messaging", "scheduler"); err != nil {
+		return err
+	}
+
+	ui.Printf("📨 Setting up messaging (%s)...\n", m.Driver)
+
+	if len(driver.packages) > 0 {
+		if err := composerRequire(m.ProjectPath, false, driver.packages...); err != nil {
+			return err
+		}
+	}
+	if len(driver.devPackages) > 0 {
+		if err := composerRequire(m.ProjectPath, true, driver.devPackages...); err != nil {
+			return err
+		}
+	}
+	if driver.extension != "" {
+		ui.Printf("ℹ️ The %s driver needs the PHP %s extension; 'laravelboot doctor' checks for it\n", m.Driver, driver.extension)
+	}
+
+	steps := []func() error{
+		m.createConfig,
+		m.createMessage,
+		m.createBroker,
+		m.createDriver,
+		m.createBasePublisher,
+		m.createBaseConsumer,
+		m.createConsumeCommand,
+		m.createServiceProvider,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if err := registerProvider(m.ProjectPath, "App\\Providers\\MessagingServiceProvider"); err != nil {
+		return err
+	}
+	if err := addComposeServices(m.ProjectPath, m.Driver); err != nil {
+		return err
+	}
+	return linkOutboxBroker(m.ProjectPath)
+}
+
+func (m *MessagingSetup) createConfig() error {
+	content := fmt.Sprintf(`<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Broker
+    |--------------------------------------------------------------------------
+    |
+    | The connection publishers and consumers use. Only the %[1]s client is
+    | installed; run "laravelboot add messaging" with another
+    | options.messaging.driver to add a different one.
+    |
+    */
+
+    'driver' => env('MESSAGING_DRIVER', '%[1]s'),
+
+    'connections' => [
+%[2]s    ],
+
+    // A consumer's dead letters go to "<group><suffix>".
+    'dead_letter_suffix' => '.dlq',
+
+];
+`, m.Driver, messagingDrivers[m.Driver].config)
+	dir := filepath.Join(m.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "messaging.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createMessage() error {
+	content := `<?php
+
+namespace App\Messaging;
+
+use Illuminate\Support\Str;
+
+/**
+ * What travels through the broker. Everything is in the JSON body, so
+ * every driver carries the same thing.
+ */
+final class Message
+{
+    public function __construct(
+        public readonly string $id,
+        public readonly string $type,
+        public readonly array $payload,
+        public readonly ?string $key = null,
+        public readonly array $headers = [],
+        public readonly ?string $occurredAt = null,
+    ) {
+    }
+
+    /**
+     * A new message. Messages with the same key stay in order where the
+     * broker can promise it (Kafka partitions).
+     */
+    public static function make(string $type, array $payload, ?string $key = null, array $headers = []): self
+    {
+        return new self((string) Str::uuid(), $type, $payload, $key, $headers, now()->toIso8601String());
+    }
+
+    public function withHeaders(array $headers): self
+    {
+        return new self($this->id, $this->type, $this->payload, $this->key, array_merge($this->headers, $headers), $this->occurredAt);
+    }
+
+    public function withoutHeader(string $name): self
+    {
+        $headers = $this->headers;
+        unset($headers[$name]);
+
+        return new self($this->id, $this->type, $this->payload, $this->key, $headers, $this->occurredAt);
+    }
+
+    public function toJson(): string
+    {
+        return json_encode([
+            'id' => $this->id,
+            'type' => $this->type,
+            'key' => $this->key,
+            'occurred_at' => $this->occurredAt,
+            'headers' => (object) $this->headers,
+            'payload' => $this->payload,
+        ], JSON_UNESCAPED_SLASHES | JSON_THROW_ON_ERROR);
+    }
+
+    /**
+     * @throws \JsonException when the body is not a message
+     */
+    public static function fromJson(string $body): self
+    {
+        $data = json_decode($body, true, 512, JSON_THROW_ON_ERROR);
+        if (! is_array($data) || ! isset($data['id'], $data['type'])) {
+            throw new \JsonException('The body is not a message.');
+        }
+
+        return new self($data['id'], $data['type'], $data['payload'] ?? [], $data['key'] ?? null, $data['headers'] ?? [], $data['occurred_at'] ?? null);
+    }
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Messaging")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "Message.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createBroker() error {
+	content := `<?php
+
+namespace App\Messaging;
+
+use Closure;
+
+/**
+ * A message broker connection. Topics are RabbitMQ routing keys, Kafka
+ * topics or Redis streams; consumers in the same group share a topic's
+ * messages and every group gets all of them.
+ */
+interface Broker
+{
+    /**
+     * Publish a body and return once the broker has accepted it.
+     */
+    public function publish(string $topic, string $body, ?string $key = null): void;
+
+    /**
+     * Pass each body on $topics to $handler(string $body, string $topic),
+     * acknowledging it once $handler returns, until $running() returns
+     * false. $running is checked at least once a second.
+     */
+    public function consume(array $topics, string $group, Closure $handler, Closure $running): void;
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Messaging")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "Broker.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createDriver() error {
+	var content string
+	switch m.Driver {
+	case "rabbitmq":
+		content = `<?php
+
+namespace App\Messaging\Drivers;
+
+use App\Messaging\Broker;
+use Closure;
+use PhpAmqpLib\Channel\AMQPChannel;
+use PhpAmqpLib\Connection\AMQPStreamConnection;
+use PhpAmqpLib\Exception\AMQPTimeoutException;
+use PhpAmqpLib\Message\AMQPMessage;
+use RuntimeException;
+
+/**
+ * Topics are routing keys on one durable topic exchange. Each consumer
+ * group has a durable queue, named after the group, bound to its topics.
+ * Publishes wait for the broker's confirm. RabbitMQ has no partitions, so
+ * message keys are not used.
+ */
+class RabbitMqBroker implements Broker
+{
+    protected ?AMQPStreamConnection $connection = null;
+
+    protected ?AMQPChannel $channel = null;
+
+    public function __construct(protected array $config)
+    {
+    }
+
+    public function publish(string $topic, string $body, ?string $key = null): void
+    {
+        $channel = $this->channel();
+
+        // Nothing consumes a dead-letter topic until it is retried, so give
+        // it a queue of its own to keep the messages.
+        if (str_ends_with($topic, config('messaging.dead_letter_suffix', '.dlq'))) {
+            $this->bind($channel, $topic, [$topic]);
+        }
+
+        $channel->basic_publish(new AMQPMessage($body, [
+            'content_type' => 'application/json',
+            'delivery_mode' => AMQPMessage::DELIVERY_MODE_PERSISTENT,
+        ]), $this->config['exchange'], $topic);
+        $channel->wait_for_pending_acks(5.0);
+    }
+
+    public function consume(array $topics, string $group, Closure $handler, Closure $running): void
+    {
+        $channel = $this->channel();
+        $this->bind($channel, $group, $topics);
+        $channel->basic_qos(0, $this->config['prefetch'] ?? 10, false);
+        $channel->basic_consume($group, '', false, false, false, false, function (AMQPMessage $message) use ($handler) {
+            $handler($message->getBody(), $message->getRoutingKey());
+            $message->ack();
+        });
+
+        while ($running() && $channel->is_consuming()) {
+            try {
+                $channel->wait(null, false, 1);
+            } catch (AMQPTimeoutException) {
+                // Nothing arrived; check $running again.
+            }
+        }
+    }
+
+    protected function bind(AMQPChannel $channel, string $queue, array $topics): void
+    {
+        $channel->queue_declare($queue, false, true, false, false);
+        foreach ($topics as $topic) {
+            $channel->queue_bind($queue, $this->config['exchange'], $topic);
+        }
+    }
+
+    protected function channel(): AMQPChannel
+    {
+        if ($this->channel === null) {
+            $this->connection = new AMQPStreamConnection(
+                $this->config['host'],
+                $this->config['port'],
+                $this->config['user'],
+                $this->config['password'],
+                $this->config['vhost'] ?? '/',
+            );
+            $this->channel = $this->connection->channel();
+            $this->channel->exchange_declare($this->config['exchange'], 'topic', false, true, false);
+            $this->channel->confirm_select();
+            $this->channel->set_nack_handler(fn () => throw new RuntimeException('RabbitMQ did not accept the message.'));
+        }
+
+        return $this->channel;
+    }
+
+    public function __destruct()
+    {
+        $this->channel?->close();
+        $this->connection?->close();
+    }
+}
+`
+	case "kafka":
+		content = `<?php
+
+namespace App\Messaging\Drivers;
+
+use App\Messaging\Broker;
+use Closure;
+use RdKafka\Conf;
+use RdKafka\KafkaConsumer;
+use RdKafka\Producer;
+use RuntimeException;
+
+/**
+ * Topics are Kafka topics and groups are consumer groups. Messages with
+ * the same key go to the same partition and stay in order. Offsets are
+ * committed after each message is handled, and a new group starts at the
+ * earliest offset.
+ */
+class KafkaBroker implements Broker
+{
+    protected ?Producer $producer = null;
+
+    public function __construct(protected array $config)
+    {
+    }
+
+    public function publish(string $topic, string $body, ?string $key = null): void
+    {
+        $producer = $this->producer();
+        $producer->newTopic($topic)->produce(RD_KAFKA_PARTITION_UA, 0, $body, $key);
+
+        $result = $producer->flush($this->config['flush_timeout_ms'] ?? 10000);
+        if ($result !== RD_KAFKA_RESP_ERR_NO_ERROR) {
+            throw new RuntimeException("Kafka did not acknowledge the message to {$topic}: " . rd_kafka_err2str($result));
+        }
+    }
+
+    public function consume(array $topics, string $group, Closure $handler, Closure $running): void
+    {
+        $conf = $this->conf();
+        $conf->set('group.id', $group);
+        $conf->set('auto.offset.reset', 'earliest');
+        $conf->set('enable.auto.commit', 'false');
+
+        $consumer = new KafkaConsumer($conf);
+        $consumer->subscribe($topics);
+
+        try {
+            while ($running()) {
+                $message = $consumer->consume(1000);
+
+                if ($message->err === RD_KAFKA_RESP_ERR_NO_ERROR) {
+                    $handler($message->payload, $message->topic_name);
+                    $consumer->commit($message);
+                } elseif (! in_array($message->err, [RD_KAFKA_RESP_ERR__PARTITION_EOF, RD_KAFKA_RESP_ERR__TIMED_OUT], true)) {
+                    throw new RuntimeException($message->errstr());
+                }
+            }
+        } finally {
+            $consumer->close();
+        }
+    }
+
+    protected function producer(): Producer
+    {
+        return $this->producer ??= new Producer($this->conf());
+    }
+
+    protected function conf(): Conf
+    {
+        $conf = new Conf();
+        $conf->set('metadata.broker.list', $this->config['brokers']);
+        foreach ($this->config['options'] ?? [] as $name => $value) {
+            $conf->set($name, (string) $value);
+        }
+
+        return $conf;
+    }
+}
+`
+	case "redis":
+		content = `<?php
+
+namespace App\Messaging\Drivers;
+
+use App\Messaging\Broker;
+use Closure;
+use Illuminate\Support\Facades\Redis;
+
+/**
+ * Topics are streams and groups are Redis consumer groups; a new group
+ * starts at the beginning of the stream. Each process reads under its own
+ * consumer name and first re-reads what it took but did not acknowledge
+ * before a restart. Commands are sent raw, so the connection's key prefix
+ * does not apply to stream names.
+ */
+class RedisStreamsBroker implements Broker
+{
+    public function __construct(protected array $config)
+    {
+    }
+
+    public function publish(string $topic, string $body, ?string $key = null): void
+    {
+        $this->command('XADD', $topic, 'MAXLEN', '~', (string) ($this->config['maxlen'] ?? 100000), '*', 'body', $body, 'key', (string) $key);
+    }
+
+    public function consume(array $topics, string $group, Closure $handler, Closure $running): void
+    {
+        foreach ($topics as $topic) {
+            // Fails with BUSYGROUP when the group exists, which is fine.
+            $this->command('XGROUP', 'CREATE', $topic, $group, '0', 'MKSTREAM');
+        }
+
+        // "0" reads this consumer's unacknowledged entries, ">" new ones.
+        $from = '0';
+        while ($running()) {
+            $reply = $this->command(
+                'XREADGROUP', 'GROUP', $group, (string) $this->config['consumer'],
+                'COUNT', '10', 'BLOCK', '1000',
+                'STREAMS', ...$topics, ...array_fill(0, count($topics), $from),
+            );
+
+            $read = 0;
+            foreach (is_array($reply) ? $reply : [] as [$stream, $entries]) {
+                foreach ($entries as [$id, $fields]) {
+                    $read++;
+                    // Entries trimmed from the stream come back without fields.
+                    if (is_array($fields)) {
+                        $handler($this->fields($fields)['body'] ?? '', $stream);
+                    }
+                    $this->command('XACK', $stream, $group, $id);
+                }
+            }
+
+            if ($from === '0' && $read === 0) {
+                $from = '>';
+            }
+        }
+    }
+
+    /**
+     * Turn a flat field/value list into a map.
+     */
+    protected function fields(array $list): array
+    {
+        $fields = [];
+        for ($i = 0; $i + 1 < count($list); $i += 2) {
+            $fields[$list[$i]] = $list[$i + 1];
+        }
+
+        return $fields;
+    }
+
+    protected function command(string ...$arguments): mixed
+    {
+        $client = Redis::connection($this->config['connection'] ?? null)->client();
+
+        return $client instanceof \Redis
+            ? $client->rawCommand(...$arguments)
+            : $client->executeRaw($arguments);
+    }
+}
+`
+	}
+
+	dir := filepath.Join(m.ProjectPath, "app/Messaging/Drivers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, messagingDrivers[m.Driver].class+".php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createBasePublisher() error {
+	content := `<?php
+
+namespace App\Messaging;
+
+use Illuminate\Support\Facades\Log;
+
+/**
+ * Publishes to one topic.
+ *
+ *   class OrderPublisher extends BasePublisher
+ *   {
+ *       protected string $topic = 'orders';
+ *   }
+ *
+ *   OrderPublisher::publish('order.shipped', ['id' => $order->id], key: (string) $order->id);
+ *
+ * Publishing happens immediately, even inside a transaction that later
+ * rolls back; use the outbox feature's broker publisher for that.
+ */
+abstract class BasePublisher
+{
+    /**
+     * The topic messages are published to.
+     */
+    protected string $topic;
+
+    public function __construct(protected Broker $broker)
+    {
+    }
+
+    public static function publish(string $type, array $payload, ?string $key = null, array $headers = []): Message
+    {
+        return app(static::class)->send(Message::make($type, $payload, $key, $headers));
+    }
+
+    public function send(Message $message): Message
+    {
+        try {
+            $this->broker->publish($this->topic, $message->toJson(), $message->key);
+        } catch (\Throwable $exception) {
+            Log::error('Publish failed: ' . static::class, [
+                'topic' => $this->topic,
+                'message' => $message->id,
+                'exception' => $exception->getMessage(),
+            ]);
+
+            throw $exception;
+        }
+
+        return $message;
+    }
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Messaging")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "BasePublisher.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createBaseConsumer() error {
+	content := `<?php
+
+namespace App\Messaging;
+
+use Illuminate\Support\Facades\Log;
+use Illuminate\Support\Str;
+
+/**
+ * Handles messages from one or more topics; run it with
+ * "php artisan messaging:consume OrderConsumer" for a class in
+ * App\Messaging\Consumers.
+ *
+ * A message that still fails after $tries attempts goes to the group's
+ * dead-letter topic; "messaging:consume OrderConsumer --dead-letters"
+ * runs those through handle() again.
+ */
+abstract class BaseConsumer
+{
+    /**
+     * The topics to read.
+     */
+    public array $topics = [];
+
+    /**
+     * Consumers in a group share its messages; defaults to the class name
+     * in kebab case.
+     */
+    public ?string $group = null;
+
+    /**
+     * The number of times a message is attempted before it is dead-lettered.
+     */
+    public int $tries = 3;
+
+    /**
+     * Seconds to wait before the next attempt, times the attempt number.
+     * The consumer holds its place meanwhile, so keep it short.
+     */
+    public int $backoff = 2;
+
+    /**
+     * Handle a message that has been dead-lettered.
+     */
+    public function failed(Message $message, \Throwable $exception): void
+    {
+        Log::error('Message failed: ' . static::class, [
+            'message' => $message->id,
+            'type' => $message->type,
+            'exception' => $exception->getMessage(),
+        ]);
+    }
+
+    public function group(): string
+    {
+        return $this->group ?? Str::kebab(class_basename(static::class));
+    }
+
+    public function deadLetterTopic(): string
+    {
+        return $this->group() . config('messaging.dead_letter_suffix', '.dlq');
+    }
+
+    /**
+     * Handle a message. Delivery is at least once, so make this safe to
+     * run twice for the same message id.
+     */
+    abstract public function handle(Message $message): void;
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Messaging")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "BaseConsumer.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createConsumeCommand() error {
+	content := `<?php
+
+namespace App\Console\Commands;
+
+use App\Messaging\BaseConsumer;
+use App\Messaging\Broker;
+use App\Messaging\Message;
+
+/**
+ * Runs a consumer until it is stopped (SIGTERM or SIGINT finish the
+ * current message first) or a limit is reached. Run it under a process
+ * supervisor, like queue:work.
+ */
+class ConsumeMessagesCommand extends BaseCommand
+{
+    protected $signature = 'messaging:consume
+                            {consumer : Consumer class, or its name in App\Messaging\Consumers}
+                            {--dead-letters : Retry the consumer\'s dead letters, then exit}
+                            {--max-messages=0 : Stop after this many messages}
+                            {--max-time=0 : Stop after this many seconds}
+                            {--memory=128 : Stop when memory use exceeds this many megabytes}';
+
+    protected $description = 'Consume messages from the broker';
+
+    protected bool $stop = false;
+
+    protected int $handled = 0;
+
+    protected int $started = 0;
+
+    protected int $lastMessageAt = 0;
+
+    public function handle(Broker $broker): int
+    {
+        $consumer = $this->resolveConsumer($this->argument('consumer'));
+        if ($consumer === null) {
+            $this->logError("No consumer class {$this->argument('consumer')}");
+
+            return self::FAILURE;
+        }
+
+        if (extension_loaded('pcntl')) {
+            $this->trap([SIGTERM, SIGINT], fn () => $this->stop = true);
+        }
+        $this->started = $this->lastMessageAt = time();
+
+        $deadLetters = (bool) $this->option('dead-letters');
+        $topics = $deadLetters ? [$consumer->deadLetterTopic()] : $consumer->topics;
+        $group = $deadLetters ? $consumer->deadLetterTopic() : $consumer->group();
+
+        $this->logInfo('Consuming ' . implode(', ', $topics) . " as {$group}");
+
+        $broker->consume($topics, $group, function (string $body, string $topic) use ($broker, $consumer, $deadLetters) {
+            $this->handled++;
+            $this->lastMessageAt = time();
+            $this->process($broker, $consumer, $body, $topic, $deadLetters);
+        }, fn () => $this->running($deadLetters));
+
+        $this->logInfo("Stopped after {$this->handled} messages");
+
+        return self::SUCCESS;
+    }
+
+    protected function process(Broker $broker, BaseConsumer $consumer, string $body, string $topic, bool $deadLetters): void
+    {
+        try {
+            $message = Message::fromJson($body);
+        } catch (\JsonException $e) {
+            $message = Message::make('unreadable', ['body' => $body]);
+            $this->deadLetter($broker, $consumer, $message, $topic, $e);
+
+            return;
+        }
+
+        if ($deadLetters) {
+            // Stop at the first letter this run sent back; put it back untouched.
+            if (($message->headers['dead_letter']['failed_at'] ?? 0) >= $this->started) {
+                $broker->publish($topic, $body, $message->key);
+                $this->stop = true;
+
+                return;
+            }
+            $topic = $message->headers['dead_letter']['topic'] ?? $topic;
+            $message = $message->withoutHeader('dead_letter');
+        }
+
+        for ($attempt = 1; ; $attempt++) {
+            try {
+                $consumer->handle($message);
+
+                return;
+            } catch (\Throwable $e) {
+                if ($attempt >= $consumer->tries) {
+                    $consumer->failed($message, $e);
+                    $this->deadLetter($broker, $consumer, $message, $topic, $e);
+
+                    return;
+                }
+
+                $this->logWarning("{$message->type} {$message->id} failed attempt {$attempt}: {$e->getMessage()}");
+                sleep($consumer->backoff * $attempt);
+            }
+        }
+    }
+
+    protected function deadLetter(Broker $broker, BaseConsumer $consumer, Message $message, string $topic, \Throwable $e): void
+    {
+        $letter = $message->withHeaders(['dead_letter' => [
+            'topic' => $topic,
+            'error' => $e->getMessage(),
+            'failed_at' => time(),
+        ]]);
+        $broker->publish($consumer->deadLetterTopic(), $letter->toJson(), $message->key);
+
+        $this->logError("{$message->type} {$message->id} moved to {$consumer->deadLetterTopic()}: {$e->getMessage()}");
+    }
+
+    protected function running(bool $deadLetters): bool
+    {
+        $maxMessages = (int) $this->option('max-messages');
+        $maxTime = (int) $this->option('max-time');
+
+        return ! $this->stop
+            && ! ($maxMessages > 0 && $this->handled >= $maxMessages)
+            && ! ($maxTime > 0 && time() - $this->started >= $maxTime)
+            && memory_get_usage(true) < (int) $this->option('memory') * 1024 * 1024
+            // Dead letters are retried until none arrive for a few seconds.
+            && ! ($deadLetters && time() - $this->lastMessageAt >= 5);
+    }
+
+    protected function resolveConsumer(string $name): ?BaseConsumer
+    {
+        foreach ([$name, 'App\\Messaging\\Consumers\\' . $name] as $class) {
+            if (class_exists($class) && is_subclass_of($class, BaseConsumer::class)) {
+                return app($class);
+            }
+        }
+
+        return null;
+    }
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Console/Commands")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "ConsumeMessagesCommand.php"), []byte(content), 0644)
+}
+
+func (m *MessagingSetup) createServiceProvider() error {
+	content := `<?php
+
+namespace App\Providers;
+
+use App\Messaging\Broker;
+use Illuminate\Support\ServiceProvider;
+use InvalidArgumentException;
+
+class MessagingServiceProvider extends ServiceProvider
+{
+    /**
+     * Bind the broker selected by messaging.driver.
+     */
+    public function register(): void
+    {
+        $this->app->singleton(Broker::class, function () {
+            $driver = config('messaging.driver');
+            $config = config("messaging.connections.{$driver}");
+            if ($config === null) {
+                throw new InvalidArgumentException("Messaging driver [{$driver}] is not configured.");
+            }
+
+            return new $config['class']($config);
+        });
+    }
+}
+`
+	dir := filepath.Join(m.ProjectPath, "app/Providers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "MessagingServiceProvider.php"), []byte(content), 0644)
+}
+
+// linkOutboxBroker adds a "broker" publisher to the outbox once both the
+// outbox and messaging features are in the project, whichever came first.
+func linkOutboxBroker(projectPath string) error {
+	configPath := filepath.Join(projectPath, "config/outbox.php")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "app/Messaging/Broker.php")); err != nil {
+		return nil
+	}
+
+	publisher := `<?php
+
+namespace App\Outbox\Publishers;
+
+use App\Messaging\Broker;
+use App\Messaging\Message;
+use App\Models\OutboxMessage;
+
+/**
+ * Publishes outbox messages through the messaging broker, keyed by
+ * aggregate so Kafka keeps each aggregate's messages on one partition.
+ * The topic defaults to the event name.
+ */
+class BrokerPublisher implements Publisher
+{
+    public function __construct(protected Broker $broker, protected array $config = [])
+    {
+    }
+
+    public function publish(OutboxMessage $message): void
+    {
+        $body = new Message(
+            $message->uuid,
+            $message->event,
+            $message->payload,
+            $message->orderingKey(),
+            $message->headers ?? [],
+            $message->created_at->toIso8601String(),
+        );
+
+        $this->broker->publish($this->config['topic'] ?? $message->event, $body->toJson(), $body->key);
+    }
+}
+`
+	dir := filepath.Join(projectPath, "app/Outbox/Publishers")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "BrokerPublisher.php"), []byte(publisher), 0644); err != nil {
+		return err
+	}
+
+	content := string(data)
+	if strings.Contains(content, "BrokerPublisher") {
+		return nil
+	}
+	anchor := "    'publishers' => [\n"
+	if !strings.Contains(content, anchor) {
+		ui.Warnf("⚠️ Could not find the publishers list in config/outbox.php, add the broker publisher manually\n")
+		return nil
+	}
+	entry := `        'broker' => [
+            'class' => \App\Outbox\Publishers\BrokerPublisher::class,
+            'topic' => env('OUTBOX_BROKER_TOPIC'),
+        ],
+
+`
+	content = strings.Replace(content, anchor, anchor+entry, 1)
+	return ui.WriteFile(configPath, []byte(content), 0644)
+}
@@ -2,8 +2,10 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type MiddlewareSetup struct {
@@ -17,11 +19,11 @@ func NewMiddlewareSetup(projectPath string, dryRun bool) *MiddlewareSetup {
 
 func (m *MiddlewareSetup) Setup() error {
 	if m.DryRun {
-		fmt.Printf("[Dry Run] Would create common middleware\n")
+		ui.Printf("[Dry Run] Would create common middleware\n")
 		return nil
 	}
 
-	fmt.Println("🛡️ Creating common middleware...")
+	ui.Println("🛡️ Creating common middleware...")
 
 	if err := m.createDBTransactionMiddleware(); err != nil {
 		return err
@@ -67,7 +69,7 @@ class DBTransaction
 `
 	dir := filepath.Join(m.ProjectPath, "app/Http/Middleware")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "DBTransaction.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "DBTransaction.php"), []byte(content), 0644)
 }
 
 func (m *MiddlewareSetup) createForceJsonMiddleware() error {
@@ -94,5 +96,36 @@ class ForceJson
 `
 	dir := filepath.Join(m.ProjectPath, "app/Http/Middleware")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "ForceJson.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "ForceJson.php"), []byte(content), 0644)
+}
+
+// aliasMiddleware registers class under alias in the withMiddleware()
+// callback of bootstrap/app.php. It warns instead of failing when the file
+// has been restructured and the callback cannot be found.
+func aliasMiddleware(projectPath, alias, class string) error {
+	path := filepath.Join(projectPath, "bootstrap/app.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	text := string(content)
+	entry := fmt.Sprintf("'%s' => \\%s::class", alias, class)
+	if strings.Contains(text, entry) {
+		return nil
+	}
+
+	start := strings.Index(text, "->withMiddleware(function (Middleware $middleware)")
+	brace := -1
+	if start >= 0 {
+		brace = strings.Index(text[start:], "{")
+	}
+	if brace < 0 {
+		ui.Warnf("⚠️ Could not register the '%s' middleware in bootstrap/app.php; add $middleware->alias([%s]) by hand\n", alias, entry)
+		return nil
+	}
+
+	at := start + brace + 1
+	text = text[:at] + fmt.Sprintf("\n        $middleware->alias([%s]);", entry) + text[at:]
+	return ui.WriteFile(path, []byte(text), 0644)
 }
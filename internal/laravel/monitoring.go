@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os/exec"
 )
 
@@ -16,20 +17,17 @@ func NewMonitoringSetup(projectPath string, dryRun bool) *MonitoringSetup {
 
 func (m *MonitoringSetup) Setup() error {
 	if m.DryRun {
-		fmt.Printf("[Dry Run] Would install Laravel Pulse\n")
+		ui.Printf("[Dry Run] Would install Laravel Pulse\n")
 		return nil
 	}
 
-	fmt.Println("📈 Installing Laravel Pulse...")
-	cmd := exec.Command("composer", "require", "laravel/pulse", "--with-all-dependencies")
-	cmd.Dir = m.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install Pulse: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(m.ProjectPath, false, "laravel/pulse"); err != nil {
+		return err
 	}
 
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Pulse\\PulseServiceProvider")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Pulse\\PulseServiceProvider")
 	cmd.Dir = m.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(cmd); err != nil {
 		return fmt.Errorf("failed to initialize Pulse: %v\nOutput: %s", err, string(output))
 	}
 
@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,16 +18,16 @@ func NewNotificationsSetup(projectPath string, dryRun bool) *NotificationsSetup
 
 func (n *NotificationsSetup) Setup() error {
 	if n.DryRun {
-		fmt.Printf("[Dry Run] Would setup notifications system\n")
+		ui.Printf("[Dry Run] Would setup notifications system\n")
 		return nil
 	}
 
-	fmt.Println("🔔 Setting up notifications system...")
+	ui.Println("🔔 Setting up notifications system...")
 
 	// Create notifications table
 	cmd := exec.Command("php", "artisan", "notifications:table")
 	cmd.Dir = n.ProjectPath
-	_ = cmd.Run()
+	_, _ = ui.Run(cmd)
 
 	// Create notifications directory
 	notifDir := filepath.Join(n.ProjectPath, "app/Notifications")
@@ -88,7 +88,7 @@ abstract class BaseNotification extends Notification implements ShouldQueue
 }
 `
 	path := filepath.Join(n.ProjectPath, "app/Notifications/BaseNotification.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (n *NotificationsSetup) createWelcomeNotification() error {
@@ -130,7 +130,7 @@ class WelcomeNotification extends BaseNotification
 }
 `
 	path := filepath.Join(n.ProjectPath, "app/Notifications/WelcomeNotification.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (n *NotificationsSetup) createNotificationService() error {
@@ -206,5 +206,5 @@ class NotificationService
 `
 	dir := filepath.Join(n.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "NotificationService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "NotificationService.php"), []byte(content), 0644)
 }
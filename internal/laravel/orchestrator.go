@@ -0,0 +1,274 @@
+package laravel
+
+import (
+	"errors"
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"strings"
+	"sync"
+)
+
+// Orchestrator installs a set of catalog features. All their composer
+// packages are required up front in one batch (plus one for dev packages),
+// then the setups run in waves: steps in a wave touch disjoint files and
+// run concurrently.
+type Orchestrator struct {
+	ProjectPath string
+	DryRun      bool
+	Options     config.Options
+
+	// Step wraps each feature step, e.g. with plugin hooks and timing.
+	Step func(name string, fn func() error) error
+
+	// Parallel caps how many steps of a wave run at once.
+	Parallel int
+
+	// Policy decides whether later waves still run after a failure, and
+	// records every feature step in its report.
+	Policy Policy
+}
+
+func NewOrchestrator(projectPath string, dryRun bool) *Orchestrator {
+	return &Orchestrator{
+		ProjectPath: projectPath,
+		DryRun:      dryRun,
+		Step:        func(name string, fn func() error) error { return fn() },
+		Parallel:    4,
+	}
+}
+
+// Require installs the packages of the named features, plus any extra
+// production packages, with one composer require per group. If a batch
+// fails, the setups fall back to installing their own packages.
+func (o *Orchestrator) Require(names []string, extra ...string) error {
+	prod := append([]string{}, extra...)
+	var dev []string
+	for _, name := range names {
+		if f, ok := LookupFeature(name); ok {
+			prod = append(prod, f.Packages...)
+			dev = append(dev, f.DevPackages...)
+		}
+	}
+
+	if o.DryRun {
+		if len(prod) > 0 {
+			ui.Printf("[Dry Run] Would run: composer require %s --with-all-dependencies\n", strings.Join(prod, " "))
+		}
+		if len(dev) > 0 {
+			ui.Printf("[Dry Run] Would run: composer require --dev %s --with-all-dependencies\n", strings.Join(dev, " "))
+		}
+		return nil
+	}
+
+	if err := composerRequire(o.ProjectPath, false, prod...); err != nil {
+		return err
+	}
+	return composerRequire(o.ProjectPath, true, dev...)
+}
+
+// Run requires the packages of the named features and installs them,
+// returning the first failure in the order given. Skipped features are
+// only returned when nothing else failed.
+func (o *Orchestrator) Run(names []string) error {
+	if err := o.Require(names); err != nil {
+		ui.Warnf("⚠️ Warning: batched composer require failed, each feature will install its own packages: %v\n", err)
+	}
+	failed := o.Install(names)
+	var skipped error
+	for _, name := range names {
+		err, ok := failed[name]
+		switch {
+		case !ok:
+		case errors.Is(err, ErrSkipped):
+			if skipped == nil {
+				skipped = fmt.Errorf("%s: %v", name, err)
+			}
+		default:
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return skipped
+}
+
+// Install runs the setups of the named catalog features and returns the
+// failures by feature name. A feature whose requirement failed is skipped,
+// and with a fail-fast policy so is everything that had not started when
+// the first failure happened.
+func (o *Orchestrator) Install(names []string) map[string]error {
+	failed := map[string]error{}
+	var mu sync.Mutex
+	// stopped returns why no new step may start, if that is the case.
+	stopped := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case interrupted(o.Policy.Context):
+			return fmt.Errorf("%w: %w", ErrSkipped, ErrInterrupted)
+		case o.Policy.FailFast && len(failed) > 0:
+			return fmt.Errorf("%w after an earlier failure (--fail-fast)", ErrSkipped)
+		}
+		return nil
+	}
+	skip := func(name string, err error) {
+		mu.Lock()
+		failed[name] = err
+		mu.Unlock()
+		o.Policy.skip(stepName(name), strings.TrimLeft(strings.TrimPrefix(err.Error(), ErrSkipped.Error()), " :"))
+	}
+
+	for _, wave := range o.waves(names) {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, max(o.Parallel, 1))
+
+		for _, name := range wave {
+			if err := stopped(); err != nil {
+				skip(name, err)
+				continue
+			}
+			if dep := o.failedRequirement(name, failed); dep != "" {
+				skip(name, fmt.Errorf("%w because %s failed", ErrSkipped, dep))
+				continue
+			}
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				if err := stopped(); err != nil {
+					skip(name, err)
+					return
+				}
+				if err := o.run(name); err != nil {
+					mu.Lock()
+					failed[name] = err
+					mu.Unlock()
+				}
+			}(name)
+		}
+		wg.Wait()
+	}
+	return failed
+}
+
+func (o *Orchestrator) failedRequirement(name string, failed map[string]error) string {
+	f, _ := LookupFeature(name)
+	for _, dep := range f.Requires {
+		if failed[dep] != nil {
+			return dep
+		}
+	}
+	return ""
+}
+
+// run installs one feature through the manager for its group, under its
+// step name.
+func (o *Orchestrator) run(name string) error {
+	f, ok := LookupFeature(name)
+	if !ok {
+		return fmt.Errorf("unknown feature: %s", name)
+	}
+
+	var setup func() error
+	switch f.Group {
+	case "platform":
+		setup = func() error {
+			ui.Printf("📦 Adding feature: %s\n", name)
+			m := NewPlatformManager(o.ProjectPath, o.DryRun)
+			m.Options = o.Options
+			return m.RunStep(name)
+		}
+	case "infra":
+		m := NewInfraManager(o.ProjectPath, o.DryRun)
+		m.Options = o.Options
+		setup = func() error {
+			ui.Printf("🛡️ Adding infra: %s\n", name)
+			return m.RunStep(name)
+		}
+	case "enterprise":
+		m := NewEnterpriseManager(o.ProjectPath, o.DryRun)
+		m.Options = o.Options
+		setup = func() error {
+			ui.Printf("👑 Adding enterprise feature: %s\n", name)
+			return m.RunStep(name)
+		}
+	case "auth":
+		m := NewAuthManager(o.ProjectPath, o.DryRun)
+		m.Options = o.Options
+		setup = m.AddAuth
+	default:
+		return fmt.Errorf("unknown feature group: %s", f.Group)
+	}
+
+	step := stepName(name)
+	return o.Policy.track(step, func() error { return o.Step(step, setup) })
+}
+
+// stepName is the step a catalog feature runs under, the name the creator
+// has always used (feature:roles, infra:docker...).
+func stepName(name string) string {
+	f, _ := LookupFeature(name)
+	switch f.Group {
+	case "platform":
+		return "feature:" + name
+	case "infra", "enterprise":
+		return f.Group + ":" + name
+	}
+	return name
+}
+
+// waves splits names, in order, into groups that can run together: no two
+// features in a wave share a file, a feature comes after the features it
+// requires or runs after, and exclusive features run alone.
+func (o *Orchestrator) waves(names []string) [][]string {
+	remaining := append([]string{}, names...)
+	var waves [][]string
+
+	for len(remaining) > 0 {
+		var wave, later []string
+		touched := map[string]bool{}
+		waiting := map[string]bool{}
+		for _, name := range remaining {
+			waiting[name] = true
+		}
+
+		for _, name := range remaining {
+			f, _ := LookupFeature(name)
+			ok := !(f.Exclusive && len(wave) > 0) && !(len(wave) == 1 && isExclusive(wave[0]))
+			for _, dep := range append(append([]string{}, f.Requires...), f.After...) {
+				if waiting[dep] {
+					ok = false
+				}
+			}
+			for _, file := range append(append([]string{}, f.Files...), f.Edits...) {
+				if touched[file] {
+					ok = false
+				}
+			}
+
+			if !ok {
+				later = append(later, name)
+				continue
+			}
+			wave = append(wave, name)
+			for _, file := range append(append([]string{}, f.Files...), f.Edits...) {
+				touched[file] = true
+			}
+		}
+
+		// Only a requirement cycle can leave the wave empty; break it by
+		// running the first feature on its own.
+		if len(wave) == 0 {
+			wave, later = later[:1], later[1:]
+		}
+		waves = append(waves, wave)
+		remaining = later
+	}
+	return waves
+}
+
+func isExclusive(name string) bool {
+	f, _ := LookupFeature(name)
+	return f.Exclusive
+}
@@ -0,0 +1,655 @@
+package laravel
+
+import (
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+)
+
+type OutboxSetup struct {
+	ProjectPath string
+	DryRun      bool
+}
+
+func NewOutboxSetup(projectPath string, dryRun bool) *OutboxSetup {
+	return &OutboxSetup{ProjectPath: projectPath, DryRun: dryRun}
+}
+
+func (o *OutboxSetup) Setup() error {
+	if o.DryRun {
+		ui.Printf("[Dry Run] Would create outbox table, model, recording trait, relay command and publishers\n")
+		return nil
+	}
+
+	// The relay command extends BaseCommand.
+	if err := requireFeatures(o.ProjectPath, "outbox", "scheduler"); err != nil {
+		return err
+	}
+
+	ui.Println("📮 Setting up transactional outbox...")
+
+	steps := []func() error{
+		o.createConfig,
+		o.createMigration,
+		o.createModel,
+		o.createRecorder,
+		o.createPublishers,
+		o.createRelayCommand,
+		o.createServiceProvider,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if err := registerProvider(o.ProjectPath, "App\\Providers\\OutboxServiceProvider"); err != nil {
+		return err
+	}
+	if err := scheduleCommand(o.ProjectPath, "Schedule::command('outbox:relay --once')->everyMinute()->withoutOverlapping();"); err != nil {
+		return err
+	}
+	if err := scheduleCommand(o.ProjectPath, "Schedule::command('model:prune', ['--model' => [\\App\\Models\\OutboxMessage::class]])->daily();"); err != nil {
+		return err
+	}
+	return linkOutboxBroker(o.ProjectPath)
+}
+
+func (o *OutboxSetup) createConfig() error {
+	content := `<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Publisher
+    |--------------------------------------------------------------------------
+    |
+    | Where the relay sends recorded messages: "queue" pushes them as JSON
+    | onto a queue, "http" POSTs them to a signed endpoint and "log" writes
+    | them to the log. With the messaging feature, "broker" publishes through
+    | its broker. Add a class implementing App\Outbox\Publishers\Publisher
+    | to publish to anything else.
+    |
+    */
+
+    'publisher' => env('OUTBOX_PUBLISHER', 'queue'),
+
+    'publishers' => [
+        'queue' => [
+            'class' => \App\Outbox\Publishers\QueuePublisher::class,
+            'connection' => env('OUTBOX_QUEUE_CONNECTION'),
+            'queue' => env('OUTBOX_QUEUE', 'outbox'),
+        ],
+
+        'http' => [
+            'class' => \App\Outbox\Publishers\HttpPublisher::class,
+            'url' => env('OUTBOX_HTTP_URL'),
+            'secret' => env('OUTBOX_HTTP_SECRET'),
+            'timeout' => 10,
+        ],
+
+        'log' => [
+            'class' => \App\Outbox\Publishers\LogPublisher::class,
+            'channel' => env('OUTBOX_LOG_CHANNEL'),
+        ],
+    ],
+
+    // Throw when a message is recorded outside a database transaction.
+    'require_transaction' => (bool) env('OUTBOX_REQUIRE_TRANSACTION', false),
+
+    // Attempts before a message is marked failed; retries wait
+    // backoff * 2^(attempt - 1) seconds, at most max_backoff.
+    'max_attempts' => (int) env('OUTBOX_MAX_ATTEMPTS', 10),
+    'backoff' => 5,
+    'max_backoff' => 3600,
+
+    // Published messages older than this are pruned daily.
+    'keep_days' => (int) env('OUTBOX_KEEP_DAYS', 7),
+
+];
+`
+	dir := filepath.Join(o.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "outbox.php"), []byte(content), 0644)
+}
+
+func (o *OutboxSetup) createMigration() error {
+	content := `<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('outbox_messages', function (Blueprint $table) {
+            // The auto-increment id is the publish order.
+            $table->id();
+            $table->uuid('uuid')->unique();
+            $table->string('event');
+            $table->string('aggregate_type')->nullable();
+            $table->string('aggregate_id')->nullable();
+            $table->json('payload');
+            $table->json('headers')->nullable();
+            $table->string('status')->default('pending');
+            $table->unsignedInteger('attempts')->default(0);
+            $table->timestamp('available_at')->useCurrent();
+            $table->timestamp('published_at')->nullable();
+            $table->text('last_error')->nullable();
+            $table->timestamps();
+
+            $table->index(['status', 'available_at', 'id']);
+            $table->index(['aggregate_type', 'aggregate_id', 'id']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('outbox_messages');
+    }
+};
+`
+	dir := filepath.Join(o.ProjectPath, "database/migrations")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(o.ProjectPath, migrationFile(120, "create_outbox_messages_table")), []byte(content), 0644)
+}
+
+func (o *OutboxSetup) createModel() error {
+	content := `<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Builder;
+use Illuminate\Database\Eloquent\MassPrunable;
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Support\Str;
+
+class OutboxMessage extends Model
+{
+    use MassPrunable;
+
+    public const PENDING = 'pending';
+    public const PUBLISHED = 'published';
+    public const FAILED = 'failed';
+
+    protected $fillable = [
+        'event', 'aggregate_type', 'aggregate_id', 'payload', 'headers',
+        'status', 'attempts', 'available_at', 'published_at', 'last_error',
+    ];
+
+    protected function casts(): array
+    {
+        return [
+            'payload' => 'array',
+            'headers' => 'array',
+            'available_at' => 'datetime',
+            'published_at' => 'datetime',
+        ];
+    }
+
+    protected static function booted(): void
+    {
+        static::creating(function (OutboxMessage $message) {
+            $message->uuid ??= (string) Str::uuid();
+        });
+    }
+
+    /**
+     * Messages for the same aggregate are published in order; messages
+     * without one are independent.
+     */
+    public function orderingKey(): ?string
+    {
+        return $this->aggregate_type === null ? null : $this->aggregate_type . ':' . $this->aggregate_id;
+    }
+
+    /**
+     * The body every publisher sends.
+     */
+    public function toMessage(): array
+    {
+        return [
+            'id' => $this->uuid,
+            'event' => $this->event,
+            'aggregate' => $this->aggregate_type === null ? null : ['type' => $this->aggregate_type, 'id' => $this->aggregate_id],
+            'occurred_at' => $this->created_at->toIso8601String(),
+            'headers' => $this->headers ?? [],
+            'data' => $this->payload,
+        ];
+    }
+
+    public function prunable(): Builder
+    {
+        return static::where('status', self::PUBLISHED)
+            ->where('published_at', '<', now()->subDays(config('outbox.keep_days', 7)));
+    }
+}
+`
+	dir := filepath.Join(o.ProjectPath, "app/Models")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "OutboxMessage.php"), []byte(content), 0644)
+}
+
+func (o *OutboxSetup) createRecorder() error {
+	outbox := `<?php
+
+namespace App\Outbox;
+
+use App\Models\OutboxMessage;
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Support\Facades\DB;
+use LogicException;
+
+/**
+ * Records messages in the outbox table on the default connection, so they
+ * commit or roll back with the surrounding transaction (including the one
+ * opened by the DBTransaction middleware). The outbox:relay command
+ * publishes them after the commit.
+ */
+class Outbox
+{
+    public static function record(string $event, array $payload, ?Model $aggregate = null, array $headers = []): OutboxMessage
+    {
+        if (config('outbox.require_transaction', false) && DB::transactionLevel() === 0) {
+            throw new LogicException("Outbox message {$event} recorded outside a database transaction.");
+        }
+
+        return OutboxMessage::create([
+            'event' => $event,
+            'aggregate_type' => $aggregate?->getMorphClass(),
+            'aggregate_id' => $aggregate?->getKey(),
+            'payload' => $payload,
+            'headers' => $headers ?: null,
+        ]);
+    }
+}
+`
+	recordsTrait := `<?php
+
+namespace App\Outbox;
+
+use App\Models\OutboxMessage;
+
+/**
+ * For models: record a message about this model as its aggregate, so
+ * messages about the same record are published in order.
+ *
+ *   DB::transaction(function () use ($order) {
+ *       $order->update(['status' => 'shipped']);
+ *       $order->recordToOutbox('order.shipped', ['tracking' => $order->tracking]);
+ *   });
+ */
+trait RecordsToOutbox
+{
+    public function recordToOutbox(string $event, ?array $payload = null, array $headers = []): OutboxMessage
+    {
+        return Outbox::record($event, $payload ?? $this->toArray(), $this, $headers);
+    }
+}
+`
+	contract := `<?php
+
+namespace App\Outbox;
+
+use Illuminate\Database\Eloquent\Model;
+
+/**
+ * Events implementing this are written to the outbox when they are
+ * dispatched, in the caller's transaction, instead of reaching the broker
+ * directly. Events extending BaseEvent get the methods from
+ * PublishesViaOutbox.
+ */
+interface ShouldPublishViaOutbox
+{
+    public function outboxEvent(): string;
+
+    public function outboxPayload(): array;
+
+    /**
+     * The model whose messages must stay in order, if any.
+     */
+    public function outboxAggregate(): ?Model;
+}
+`
+	eventTrait := `<?php
+
+namespace App\Outbox;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Support\Str;
+
+/**
+ * Defaults for ShouldPublishViaOutbox on events extending BaseEvent: the
+ * name is the class in dot case, the payload is getEventData() and there
+ * is no aggregate. Override outboxAggregate() to order per model.
+ */
+trait PublishesViaOutbox
+{
+    public function outboxEvent(): string
+    {
+        return Str::of($this->getEventName())->snake('.')->toString();
+    }
+
+    public function outboxPayload(): array
+    {
+        return $this->getEventData();
+    }
+
+    public function outboxAggregate(): ?Model
+    {
+        return null;
+    }
+}
+`
+	dir := filepath.Join(o.ProjectPath, "app/Outbox")
+	os.MkdirAll(dir, 0755)
+	files := map[string]string{
+		"Outbox.php":                 outbox,
+		"RecordsToOutbox.php":        recordsTrait,
+		"ShouldPublishViaOutbox.php": contract,
+		"PublishesViaOutbox.php":     eventTrait,
+	}
+	for name, content := range files {
+		if err := ui.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OutboxSetup) createPublishers() error {
+	files := map[string]string{
+		"Publisher.php": `<?php
+
+namespace App\Outbox\Publishers;
+
+use App\Models\OutboxMessage;
+
+/**
+ * Sends one outbox message. Throw to have the relay retry it later; the
+ * relay may deliver a message more than once, so consumers should
+ * deduplicate on its id.
+ */
+interface Publisher
+{
+    public function publish(OutboxMessage $message): void;
+}
+`,
+		"QueuePublisher.php": `<?php
+
+namespace App\Outbox\Publishers;
+
+use App\Models\OutboxMessage;
+use Illuminate\Support\Facades\Queue;
+
+/**
+ * Pushes the message as raw JSON onto a queue, for consumers that read
+ * the queue directly (another service, or a worker of this app).
+ */
+class QueuePublisher implements Publisher
+{
+    public function __construct(protected array $config = [])
+    {
+    }
+
+    public function publish(OutboxMessage $message): void
+    {
+        Queue::connection($this->config['connection'] ?? null)
+            ->pushRaw(json_encode($message->toMessage(), JSON_THROW_ON_ERROR), $this->config['queue'] ?? 'outbox');
+    }
+}
+`,
+		"HttpPublisher.php": `<?php
+
+namespace App\Outbox\Publishers;
+
+use App\Models\OutboxMessage;
+use Illuminate\Support\Facades\Http;
+
+/**
+ * POSTs the message to one endpoint, signed like the webhooks feature:
+ * Webhook-Signature is "sha256=" . HMAC-SHA256("{timestamp}.{body}").
+ */
+class HttpPublisher implements Publisher
+{
+    public function __construct(protected array $config = [])
+    {
+    }
+
+    public function publish(OutboxMessage $message): void
+    {
+        $body = json_encode($message->toMessage(), JSON_UNESCAPED_SLASHES | JSON_THROW_ON_ERROR);
+        $timestamp = now()->getTimestamp();
+
+        Http::timeout($this->config['timeout'] ?? 10)
+            ->withHeaders([
+                'Webhook-Id' => $message->uuid,
+                'Webhook-Event' => $message->event,
+                'Webhook-Timestamp' => $timestamp,
+                'Webhook-Signature' => 'sha256=' . hash_hmac('sha256', $timestamp . '.' . $body, (string) ($this->config['secret'] ?? '')),
+            ])
+            ->withBody($body, 'application/json')
+            ->post($this->config['url'])
+            ->throw();
+    }
+}
+`,
+		"LogPublisher.php": `<?php
+
+namespace App\Outbox\Publishers;
+
+use App\Models\OutboxMessage;
+use Illuminate\Support\Facades\Log;
+
+class LogPublisher implements Publisher
+{
+    public function __construct(protected array $config = [])
+    {
+    }
+
+    public function publish(OutboxMessage $message): void
+    {
+        Log::channel($this->config['channel'] ?? null)->info('Outbox message published', $message->toMessage());
+    }
+}
+`,
+	}
+
+	dir := filepath.Join(o.ProjectPath, "app/Outbox/Publishers")
+	os.MkdirAll(dir, 0755)
+	for name, content := range files {
+		if err := ui.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OutboxSetup) createRelayCommand() error {
+	content := `<?php
+
+namespace App\Console\Commands;
+
+use App\Models\OutboxMessage;
+use App\Outbox\Publishers\Publisher;
+use Illuminate\Support\Facades\Cache;
+
+/**
+ * Publishes pending outbox messages in id order.
+ *
+ * Ordering: one relay runs at a time (a cache lock), and a message whose
+ * aggregate has an earlier message waiting for a retry is held back until
+ * that one is published. A message that fails max_attempts times is marked
+ * failed and no longer holds its aggregate back; --retry-failed requeues
+ * failed messages.
+ *
+ * Delivery is at least once: a crash between publishing and marking a
+ * message published sends it again.
+ */
+class OutboxRelayCommand extends BaseCommand
+{
+    protected $signature = 'outbox:relay
+                            {--once : Relay one batch and exit}
+                            {--batch=100 : Messages per batch}
+                            {--sleep=1 : Seconds to wait when there is nothing to publish}
+                            {--retry-failed : Requeue failed messages first}';
+
+    protected $description = 'Publish pending outbox messages';
+
+    public function handle(Publisher $publisher): int
+    {
+        if ($this->option('retry-failed')) {
+            $count = OutboxMessage::where('status', OutboxMessage::FAILED)
+                ->update(['status' => OutboxMessage::PENDING, 'attempts' => 0, 'available_at' => now()]);
+            $this->logInfo("Requeued {$count} failed messages");
+        }
+
+        do {
+            $lock = Cache::lock('outbox:relay', 300);
+            if (! $lock->get()) {
+                $this->logWarning('Another relay is running');
+
+                return self::SUCCESS;
+            }
+
+            try {
+                $published = $this->relayBatch($publisher, (int) $this->option('batch'));
+            } finally {
+                $lock->release();
+            }
+
+            if ($published === 0 && ! $this->option('once')) {
+                sleep((int) $this->option('sleep'));
+            }
+        } while (! $this->option('once'));
+
+        return self::SUCCESS;
+    }
+
+    protected function relayBatch(Publisher $publisher, int $size): int
+    {
+        $messages = OutboxMessage::where('status', OutboxMessage::PENDING)
+            ->where('available_at', '<=', now())
+            ->orderBy('id')
+            ->limit($size)
+            ->get();
+
+        if ($messages->isEmpty()) {
+            return 0;
+        }
+
+        $blocked = $this->waitingAggregates($messages->last()->id);
+        $published = 0;
+
+        foreach ($messages as $message) {
+            $key = $message->orderingKey();
+            if ($key !== null && isset($blocked[$key]) && $blocked[$key] < $message->id) {
+                continue;
+            }
+
+            try {
+                $publisher->publish($message);
+            } catch (\Throwable $e) {
+                $this->retryLater($message, $e);
+                if ($key !== null) {
+                    $blocked[$key] = min($blocked[$key] ?? PHP_INT_MAX, $message->id);
+                }
+
+                continue;
+            }
+
+            $message->update(['status' => OutboxMessage::PUBLISHED, 'published_at' => now(), 'last_error' => null]);
+            $published++;
+        }
+
+        $this->logInfo("Published {$published} of {$messages->count()} messages");
+
+        return $published;
+    }
+
+    /**
+     * Aggregates with a pending message waiting for its retry, mapped to
+     * that message's id. Later messages of those aggregates wait too.
+     */
+    protected function waitingAggregates(int $upTo): array
+    {
+        return OutboxMessage::where('status', OutboxMessage::PENDING)
+            ->where('available_at', '>', now())
+            ->whereNotNull('aggregate_type')
+            ->where('id', '<=', $upTo)
+            ->groupBy('aggregate_type', 'aggregate_id')
+            ->selectRaw('aggregate_type, aggregate_id, min(id) as first_id')
+            ->get()
+            ->mapWithKeys(fn ($row) => [$row->aggregate_type . ':' . $row->aggregate_id => (int) $row->first_id])
+            ->all();
+    }
+
+    protected function retryLater(OutboxMessage $message, \Throwable $e): void
+    {
+        $attempts = $message->attempts + 1;
+        $delay = min(config('outbox.backoff', 5) * (2 ** ($attempts - 1)), config('outbox.max_backoff', 3600));
+        $failed = $attempts >= config('outbox.max_attempts', 10);
+
+        $message->update([
+            'attempts' => $attempts,
+            'status' => $failed ? OutboxMessage::FAILED : OutboxMessage::PENDING,
+            'available_at' => now()->addSeconds($delay),
+            'last_error' => $e->getMessage(),
+        ]);
+
+        $this->logWarning("Message {$message->id} ({$message->event}) failed attempt {$attempts}: {$e->getMessage()}");
+    }
+}
+`
+	dir := filepath.Join(o.ProjectPath, "app/Console/Commands")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "OutboxRelayCommand.php"), []byte(content), 0644)
+}
+
+func (o *OutboxSetup) createServiceProvider() error {
+	content := `<?php
+
+namespace App\Providers;
+
+use App\Outbox\Outbox;
+use App\Outbox\Publishers\Publisher;
+use App\Outbox\ShouldPublishViaOutbox;
+use Illuminate\Support\Facades\Event;
+use Illuminate\Support\ServiceProvider;
+
+class OutboxServiceProvider extends ServiceProvider
+{
+    /**
+     * Bind the publisher selected by outbox.publisher.
+     */
+    public function register(): void
+    {
+        $this->app->bind(Publisher::class, function ($app) {
+            $config = config('outbox.publishers.' . config('outbox.publisher', 'queue'));
+
+            return $app->make($config['class'], ['config' => $config]);
+        });
+    }
+
+    /**
+     * Events implementing ShouldPublishViaOutbox are recorded when they are
+     * dispatched, inside the caller's transaction.
+     */
+    public function boot(): void
+    {
+        Event::listen('*', function (string $name, array $payload) {
+            $event = $payload[0] ?? null;
+
+            if ($event instanceof ShouldPublishViaOutbox) {
+                Outbox::record($event->outboxEvent(), $event->outboxPayload(), $event->outboxAggregate());
+            }
+        });
+    }
+}
+`
+	dir := filepath.Join(o.ProjectPath, "app/Providers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "OutboxServiceProvider.php"), []byte(content), 0644)
+}
@@ -1,14 +1,18 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 type PaginationSetup struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewPaginationSetup(projectPath string, dryRun bool) *PaginationSetup {
@@ -16,6 +20,9 @@ func NewPaginationSetup(projectPath string, dryRun bool) *PaginationSetup {
 }
 
 func (p *PaginationSetup) Setup() error {
+	if err := writeApiSupport(p.ProjectPath, p.Options, p.DryRun); err != nil {
+		return err
+	}
 	if err := p.createApiResponseSupport(); err != nil {
 		return err
 	}
@@ -30,60 +37,35 @@ func (p *PaginationSetup) createApiResponseSupport() error {
 
 namespace App\Support\Api;
 
+use Illuminate\Contracts\Pagination\CursorPaginator;
+use Illuminate\Contracts\Pagination\Paginator;
 use Illuminate\Http\JsonResponse;
-use Illuminate\Pagination\LengthAwarePaginator;
 
 trait ApiResponse
 {
     public function ok($data, string $message = 'Success'): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => $data,
-        ]);
+        return Envelope::success($data, $message);
     }
 
     public function created($data, string $message = 'Resource created successfully'): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => $data,
-        ], 201);
+        return Envelope::success($data, $message, 201);
     }
 
     public function deleted(string $message = 'Resource deleted successfully'): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => null,
-        ], 200);
+        return Envelope::success(null, $message);
     }
 
-    public function paginate(LengthAwarePaginator $paginator, string $message = 'Success'): JsonResponse
+    public function paginate(Paginator|CursorPaginator $paginator, string $message = 'Success'): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => $paginator->items(),
-            'meta' => [
-                'current_page' => $paginator->currentPage(),
-                'last_page' => $paginator->lastPage(),
-                'per_page' => $paginator->perPage(),
-                'total' => $paginator->total(),
-            ],
-        ]);
+        return Envelope::paginated($paginator, $message);
     }
 
     public function error(string $message = 'Error', int $code = 400, array $errors = []): JsonResponse
     {
-        return response()->json([
-            'success' => false,
-            'message' => $message,
-            'errors' => $errors,
-        ], $code);
+        return Envelope::error($message, $code, $errors);
     }
 
     public function unauthorized(string $message = 'Unauthorized', array $errors = []): JsonResponse
@@ -108,10 +90,10 @@ trait ApiResponse
 	}
 	path := filepath.Join(dir, "ApiResponse.php")
 	if p.DryRun {
-		fmt.Printf("[Dry Run] Would create support file: %s\n", path)
+		ui.Printf("[Dry Run] Would create support file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (p *PaginationSetup) createQuerySupport() error {
@@ -144,8 +126,109 @@ trait AppliesQueryBuilder
 	}
 	path := filepath.Join(dir, "AppliesQueryBuilder.php")
 	if p.DryRun {
-		fmt.Printf("[Dry Run] Would create support file: %s\n", path)
+		ui.Printf("[Dry Run] Would create support file: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
+}
+
+func paginationClass(opts config.PaginationOptions) string {
+	size, max := opts.Sizes()
+	return strings.NewReplacer(
+		"{{strategy}}", paginationStrategy(opts.Strategy),
+		"{{size}}", strconv.Itoa(size),
+		"{{max}}", strconv.Itoa(max),
+	).Replace(paginationTemplate)
+}
+
+const paginationTemplate = `<?php
+
+namespace App\Support\Api;
+
+use Illuminate\Contracts\Pagination\CursorPaginator;
+use Illuminate\Contracts\Pagination\Paginator;
+use Illuminate\Http\Request;
+use Illuminate\Pagination\Cursor;
+use Illuminate\Support\Arr;
+use Symfony\Component\HttpKernel\Exception\BadRequestHttpException;
+
+/**
+ * Paginates a query from the query string:
+ *
+ *   page[size]      items per page, capped at MAX_SIZE (per_page also works)
+ *   page[number]    page for the offset and simple strategies (or ?page=2)
+ *   page[cursor]    opaque cursor for the cursor strategy
+ *   page[strategy]  offset, simple or cursor; STRATEGY when absent
+ *
+ * offset counts the total, simple skips the count query, and cursor is
+ * keyset pagination on the query's ORDER BY, which must end on a unique
+ * column such as id. Envelope::paginated() renders all three.
+ */
+class Pagination
+{
+    public const STRATEGIES = ['offset', 'simple', 'cursor'];
+
+    public const STRATEGY = '{{strategy}}';
+
+    public const DEFAULT_SIZE = {{size}};
+
+    public const MAX_SIZE = {{max}};
+
+    /**
+     * @param  \Illuminate\Database\Eloquent\Builder|\Illuminate\Database\Eloquent\Relations\Relation|\Spatie\QueryBuilder\QueryBuilder  $query
+     */
+    public static function apply($query, ?string $strategy = null, ?Request $request = null): Paginator|CursorPaginator
+    {
+        $request ??= request();
+        $strategy ??= static::strategy($request);
+        $size = static::size($request);
+
+        $page = ['size' => $size];
+        if (static::param($request, 'strategy') !== null) {
+            $page['strategy'] = $strategy;
+        }
+        $appends = Arr::except($request->query(), ['page', 'per_page']) + ['page' => $page];
+
+        $paginator = match ($strategy) {
+            'cursor' => $query->cursorPaginate($size, ['*'], 'page[cursor]', Cursor::fromEncoded(static::param($request, 'cursor'))),
+            'simple' => $query->simplePaginate($size, ['*'], 'page[number]', static::number($request)),
+            default => $query->paginate($size, ['*'], 'page[number]', static::number($request)),
+        };
+
+        return $paginator->appends($appends);
+    }
+
+    public static function strategy(Request $request): string
+    {
+        $strategy = static::param($request, 'strategy') ?? static::STRATEGY;
+
+        if (! in_array($strategy, static::STRATEGIES, true)) {
+            throw new BadRequestHttpException('page[strategy] must be one of '.implode(', ', static::STRATEGIES));
+        }
+
+        return $strategy;
+    }
+
+    public static function size(Request $request): int
+    {
+        $size = (int) (static::param($request, 'size') ?? $request->query('per_page', static::DEFAULT_SIZE));
+
+        return max(1, min($size, static::MAX_SIZE));
+    }
+
+    public static function number(Request $request): int
+    {
+        $page = $request->query('page');
+        $number = is_array($page) ? ($page['number'] ?? 1) : ($page ?? 1);
+
+        return max(1, (int) $number);
+    }
+
+    protected static function param(Request $request, string $key): ?string
+    {
+        $page = $request->query('page');
+
+        return is_array($page) && isset($page[$key]) && is_scalar($page[$key]) ? (string) $page[$key] : null;
+    }
 }
+`
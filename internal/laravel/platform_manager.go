@@ -2,18 +2,28 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 )
 
 type PlatformManager struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
+	Policy      Policy
 }
 
 func NewPlatformManager(projectPath string, dryRun bool) *PlatformManager {
 	return &PlatformManager{ProjectPath: projectPath, DryRun: dryRun}
 }
 
+// RunStep installs one feature, or a whole bundle, and records what it
+// installed in .laravelboot/installed.yaml.
 func (m *PlatformManager) RunStep(name string) error {
+	return recordStep(m.ProjectPath, m.DryRun, name, func() error { return m.runStep(name) })
+}
+
+func (m *PlatformManager) runStep(name string) error {
 	switch name {
 	case "roles":
 		return NewRolesSetup(m.ProjectPath, m.DryRun).Setup()
@@ -26,7 +36,9 @@ func (m *PlatformManager) RunStep(name string) error {
 	case "reporting":
 		return NewReportingSetup(m.ProjectPath, m.DryRun).Setup()
 	case "traits":
-		return NewTraitsSetup(m.ProjectPath, m.DryRun).Setup()
+		traits := NewTraitsSetup(m.ProjectPath, m.DryRun)
+		traits.Options = m.Options
+		return traits.Setup()
 	case "middleware":
 		return NewMiddlewareSetup(m.ProjectPath, m.DryRun).Setup()
 	case "exports":
@@ -36,7 +48,9 @@ func (m *PlatformManager) RunStep(name string) error {
 	case "rules":
 		return NewRulesSetup(m.ProjectPath, m.DryRun).Setup()
 	case "responses":
-		return NewResponsesSetup(m.ProjectPath, m.DryRun).Setup()
+		responses := NewResponsesSetup(m.ProjectPath, m.DryRun)
+		responses.Options = m.Options
+		return responses.Setup()
 	case "notifications":
 		return NewNotificationsSetup(m.ProjectPath, m.DryRun).Setup()
 	case "scheduler":
@@ -53,67 +67,59 @@ func (m *PlatformManager) RunStep(name string) error {
 		return NewEventsSetup(m.ProjectPath, m.DryRun).Setup()
 	case "logging":
 		return NewLoggingSetup(m.ProjectPath, m.DryRun).Setup()
+	case "idempotency":
+		return m.idempotency().Setup()
+	case "webhooks":
+		return NewWebhooksSetup(m.ProjectPath, m.DryRun).Setup()
+	case "webhook-receiver":
+		return m.webhookReceiver().Setup()
+	case "outbox":
+		return NewOutboxSetup(m.ProjectPath, m.DryRun).Setup()
+	case "messaging":
+		return m.messaging().Setup()
+	case "realtime":
+		return NewRealtimeSetup(m.ProjectPath, m.DryRun).Setup()
+	case "feature-flags":
+		return m.featureFlags().Setup()
+	case "audit":
+		return NewAuditSetup(m.ProjectPath, m.DryRun).Setup()
 	case "platform":
-		fmt.Println("🚀 Installing complete platform stack...")
-		if err := NewRolesSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewMediaSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewActivityLogSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewSearchSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewReportingSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewTraitsSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewMiddlewareSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewExportsSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewJobsSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewRulesSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewResponsesSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewNotificationsSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewSchedulerSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewCacheSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewVersioningSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewSoftDeletesSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewStorageSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewEventsSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		if err := NewLoggingSetup(m.ProjectPath, m.DryRun).Setup(); err != nil {
-			return err
-		}
-		return nil
+		ui.Println("🚀 Installing complete platform stack...")
+		o := NewOrchestrator(m.ProjectPath, m.DryRun)
+		o.Options = m.Options
+		o.Policy = m.Policy
+		return o.Run(Bundles["platform"])
 	default:
 		return fmt.Errorf("unknown platform feature: %s", name)
 	}
 }
+
+func (m *PlatformManager) webhookReceiver() *WebhookReceiverSetup {
+	w := NewWebhookReceiverSetup(m.ProjectPath, m.DryRun)
+	if sources := m.Options.WebhookReceiver.Sources; len(sources) > 0 {
+		w.Sources = sources
+	}
+	return w
+}
+
+func (m *PlatformManager) messaging() *MessagingSetup {
+	s := NewMessagingSetup(m.ProjectPath, m.DryRun)
+	if driver := m.Options.Messaging.Driver; driver != "" {
+		s.Driver = driver
+	}
+	return s
+}
+
+func (m *PlatformManager) idempotency() *IdempotencySetup {
+	i := NewIdempotencySetup(m.ProjectPath, m.DryRun)
+	i.Store = m.Options.Idempotency.Store
+	return i
+}
+
+func (m *PlatformManager) featureFlags() *FeatureFlagsSetup {
+	f := NewFeatureFlagsSetup(m.ProjectPath, m.DryRun)
+	if flags := m.Options.FeatureFlags.Flags; len(flags) > 0 {
+		f.Flags = flags
+	}
+	return f
+}
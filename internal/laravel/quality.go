@@ -2,94 +2,65 @@ package laravel
 
 import (
 	"fmt"
-	"os"
+	"laravelboot/internal/ui"
 	"os/exec"
 	"path/filepath"
-	"strings"
 )
 
 type QualitySetup struct {
-	ProjectPath string
-	DryRun      bool
+	ProjectPath  string
+	DryRun       bool
+	PHPStanLevel int
 }
 
 func NewQualitySetup(projectPath string, dryRun bool) *QualitySetup {
-	return &QualitySetup{ProjectPath: projectPath, DryRun: dryRun}
+	return &QualitySetup{ProjectPath: projectPath, DryRun: dryRun, PHPStanLevel: 5}
 }
 
 func (q *QualitySetup) Setup() error {
 	if q.DryRun {
-		fmt.Printf("[Dry Run] Would install Pint, Larastan, and Pest\n")
+		ui.Printf("[Dry Run] Would install Pint, Larastan, and Pest\n")
 		return nil
 	}
 
-	fmt.Println("🧹 Installing Laravel Pint...")
-	if err := q.runComposerRequire("laravel/pint", true); err != nil {
-		return err
-	}
-
-	fmt.Println("🔬 Installing Larastan...")
-	if err := q.runComposerRequire("phpstan/phpstan nunomaduro/larastan", true); err != nil {
+	if err := composerRequire(q.ProjectPath, true, "laravel/pint", "phpstan/phpstan", "nunomaduro/larastan", "pestphp/pest", "pestphp/pest-plugin-laravel"); err != nil {
 		return err
 	}
 	if err := q.createPhpStanConfig(); err != nil {
 		return err
 	}
 
-	fmt.Println("🧪 Installing Pest...")
-	if err := q.runComposerRequire("pestphp/pest pestphp/pest-plugin-laravel", true); err != nil {
-		return err
-	}
-
 	// Run dump-autoload to ensure Pest commands are discovered
 	dumpCmd := exec.Command("composer", "dump-autoload")
 	dumpCmd.Dir = q.ProjectPath
-	_ = dumpCmd.Run()
+	_, _ = ui.Run(dumpCmd)
 
 	cmd := exec.Command("php", "artisan", "pest:install", "--no-interaction")
 	cmd.Dir = q.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(cmd); err != nil {
 		// If artisan fails, try vendor/bin/pest --init
-		fmt.Printf("⚠️ artisan pest:install failed, trying fallback: %v\n", err)
+		ui.Warnf("⚠️ artisan pest:install failed, trying fallback: %v\n", err)
 		fallback := exec.Command("./vendor/bin/pest", "--init")
 		fallback.Dir = q.ProjectPath
-		if fOutput, fErr := fallback.CombinedOutput(); fErr != nil {
+		if fOutput, fErr := ui.Run(fallback); fErr != nil {
 			return fmt.Errorf("failed to initialize Pest: %v\nOutput: %s\nFallback Output: %s", fErr, string(output), string(fOutput))
 		}
 	}
 
-	return nil
-}
-
-func (q *QualitySetup) runComposerRequire(packages string, dev bool) error {
-	args := []string{"require"}
-	if dev {
-		args = append(args, "--dev")
-	}
-	args = append(args, "--with-all-dependencies")
-	// Split packages if multiple are provided
-	packageList := strings.Fields(packages)
-
-	args = append(args, packageList...)
-	cmd := exec.Command("composer", args...)
-	cmd.Dir = q.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install %s: %v\nOutput: %s", packages, err, string(output))
-	}
-	return nil
+	return linkTestSupport(q.ProjectPath)
 }
 
 func (q *QualitySetup) createPhpStanConfig() error {
-	content := `includes:
+	content := fmt.Sprintf(`includes:
     - ./vendor/nunomaduro/larastan/extension.neon
 
 parameters:
     paths:
         - app/
-    level: 5
+    level: %d
     ignoreErrors:
     excludePaths:
-`
+`, q.PHPStanLevel)
 	path := filepath.Join(q.ProjectPath, "phpstan.neon")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
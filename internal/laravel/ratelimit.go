@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,16 +11,17 @@ import (
 type RateLimitSetup struct {
 	ProjectPath string
 	DryRun      bool
+	PerMinute   int
 }
 
 func NewRateLimitSetup(projectPath string, dryRun bool) *RateLimitSetup {
-	return &RateLimitSetup{ProjectPath: projectPath, DryRun: dryRun}
+	return &RateLimitSetup{ProjectPath: projectPath, DryRun: dryRun, PerMinute: 60}
 }
 
 func (r *RateLimitSetup) Setup() error {
 	path := filepath.Join(r.ProjectPath, "app/Providers/AppServiceProvider.php")
 	if r.DryRun {
-		fmt.Printf("[Dry Run] Would configure Rate Limiting in %s\n", path)
+		ui.Printf("[Dry Run] Would configure Rate Limiting (%d/min) in %s\n", r.PerMinute, path)
 		return nil
 	}
 
@@ -35,13 +37,13 @@ func (r *RateLimitSetup) Setup() error {
 		sContent = strings.Replace(sContent, "use Illuminate\\Support\\ServiceProvider;", "use Illuminate\\Support\\ServiceProvider;\n"+importLine, 1)
 
 		// Add rate limiter block in boot method
-		rateLimitBlock := `
+		rateLimitBlock := fmt.Sprintf(`
         RateLimiter::for('api', function (Request $request) {
-            return Limit::perMinute(60)->by($request->user()?->id ?: $request->ip());
-        });`
+            return Limit::perMinute(%d)->by($request->user()?->id ?: $request->ip());
+        });`, r.PerMinute)
 
 		sContent = strings.Replace(sContent, "public function boot(): void\n    {", "public function boot(): void\n    {"+rateLimitBlock, 1)
 	}
 
-	return os.WriteFile(path, []byte(sContent), 0644)
+	return ui.WriteFile(path, []byte(sContent), 0644)
 }
@@ -0,0 +1,263 @@
+package laravel
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type RealtimeSetup struct {
+	ProjectPath string
+	DryRun      bool
+}
+
+func NewRealtimeSetup(projectPath string, dryRun bool) *RealtimeSetup {
+	return &RealtimeSetup{ProjectPath: projectPath, DryRun: dryRun}
+}
+
+func (r *RealtimeSetup) Setup() error {
+	if r.DryRun {
+		ui.Printf("[Dry Run] Would install laravel/reverb, configure broadcasting with Sanctum channel auth and add a websocket health probe\n")
+		return nil
+	}
+
+	if err := composerRequire(r.ProjectPath, false, "laravel/reverb"); err != nil {
+		return err
+	}
+
+	ui.Println("⚙️ Publishing broadcasting and Reverb config...")
+	cmd := exec.Command("php", "artisan", "config:publish", "broadcasting")
+	cmd.Dir = r.ProjectPath
+	_, _ = ui.Run(cmd)
+	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Reverb\\ReverbServiceProvider", "--tag=reverb-config")
+	cmd.Dir = r.ProjectPath
+	_, _ = ui.Run(cmd)
+
+	ui.Println("📡 Setting up real-time broadcasting...")
+
+	steps := []func() error{
+		r.createChannels,
+		r.enableBroadcasting,
+		r.configureEnv,
+		r.createHealthProbe,
+		r.registerHealthRoute,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return addComposeServices(r.ProjectPath, "reverb")
+}
+
+func (r *RealtimeSetup) createChannels() error {
+	content := `<?php
+
+use App\Models\User;
+use Illuminate\Support\Facades\Broadcast;
+
+/*
+|--------------------------------------------------------------------------
+| Broadcast Channels
+|--------------------------------------------------------------------------
+|
+| Authorization for private and presence channels. Clients authorize
+| through POST /api/broadcasting/auth with their Sanctum token:
+|
+|   Authorization: Bearer <token>
+|
+| Return true to allow a private channel. For a presence channel return
+| the member data other subscribers see; false or null denies.
+|
+*/
+
+// UserRegistered broadcasts here.
+Broadcast::channel('users.{id}', fn (User $user, int $id) => $user->id === $id);
+
+// Broadcast notifications go to the notifiable's default channel.
+Broadcast::channel('App.Models.User.{id}', fn (User $user, int $id) => $user->id === $id);
+
+// Who is online: every signed-in user may join.
+Broadcast::channel('online', fn (User $user) => [
+    'id' => $user->id,
+    'name' => $user->name,
+]);
+`
+	dir := filepath.Join(r.ProjectPath, "routes")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "channels.php"), []byte(content), 0644)
+}
+
+// enableBroadcasting loads routes/channels.php and registers the channel
+// auth route under /api with Sanctum, so API clients authorize with their
+// token instead of a session.
+func (r *RealtimeSetup) enableBroadcasting() error {
+	path := filepath.Join(r.ProjectPath, "bootstrap/app.php")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ bootstrap/app.php not found, skipping broadcasting routes\n")
+		return nil
+	}
+	content := string(data)
+	if strings.Contains(content, "withBroadcasting(") {
+		return nil
+	}
+	if strings.Contains(content, "channels:") {
+		ui.Warnf("⚠️ bootstrap/app.php already loads routes/channels.php from withRouting; replace it with ->withBroadcasting(__DIR__.'/../routes/channels.php', ['prefix' => 'api', 'middleware' => ['api', 'auth:sanctum']]) for token auth\n")
+		return nil
+	}
+
+	anchor := "->withMiddleware("
+	i := strings.Index(content, anchor)
+	if i < 0 {
+		ui.Warnf("⚠️ Could not find withMiddleware in bootstrap/app.php, add ->withBroadcasting(...) manually\n")
+		return nil
+	}
+	call := "->withBroadcasting(\n        __DIR__.'/../routes/channels.php',\n        ['prefix' => 'api', 'middleware' => ['api', 'auth:sanctum']],\n    )\n    "
+	content = content[:i] + call + content[i:]
+	return ui.WriteFile(path, []byte(content), 0644)
+}
+
+func (r *RealtimeSetup) configureEnv() error {
+	id, key, secret := randomHex(4), randomHex(16), randomHex(16)
+	vars := []envVar{
+		{"BROADCAST_CONNECTION", "reverb", true},
+		{"REVERB_APP_ID", id, false},
+		{"REVERB_APP_KEY", key, false},
+		{"REVERB_APP_SECRET", secret, false},
+		{"REVERB_HOST", "localhost", false},
+		{"REVERB_PORT", "8080", false},
+		{"REVERB_SCHEME", "http", false},
+		{"VITE_REVERB_APP_KEY", "\"${REVERB_APP_KEY}\"", false},
+		{"VITE_REVERB_HOST", "\"${REVERB_HOST}\"", false},
+		{"VITE_REVERB_PORT", "\"${REVERB_PORT}\"", false},
+		{"VITE_REVERB_SCHEME", "\"${REVERB_SCHEME}\"", false},
+	}
+	if err := ensureEnv(filepath.Join(r.ProjectPath, ".env"), vars); err != nil {
+		return err
+	}
+
+	// The example file gets the names, not this app's credentials.
+	for i := range vars {
+		if strings.HasPrefix(vars[i].key, "REVERB_APP_") {
+			vars[i].value = ""
+		}
+	}
+	return ensureEnv(filepath.Join(r.ProjectPath, ".env.example"), vars)
+}
+
+func (r *RealtimeSetup) createHealthProbe() error {
+	content := `<?php
+
+namespace App\Http\Controllers\Api;
+
+use App\Http\Controllers\Controller;
+use Illuminate\Http\JsonResponse;
+
+class RealtimeHealthController extends Controller
+{
+    /**
+     * Checks that the Reverb server accepts connections on the host and
+     * port the app broadcasts to.
+     */
+    public function check(): JsonResponse
+    {
+        $options = config('broadcasting.connections.reverb.options', []);
+        $host = $options['host'] ?? '127.0.0.1';
+        $port = (int) ($options['port'] ?? 8080);
+
+        $started = microtime(true);
+        $socket = @fsockopen($host, $port, $errno, $error, 2);
+
+        if ($socket === false) {
+            return response()->json([
+                'status' => 'error',
+                'websocket' => 'unreachable',
+                'message' => "{$host}:{$port}: {$error}",
+            ], 503);
+        }
+        fclose($socket);
+
+        return response()->json([
+            'status' => 'ok',
+            'websocket' => 'reachable',
+            'latency_ms' => (int) round((microtime(true) - $started) * 1000),
+            'timestamp' => now()->toIso8601String(),
+        ]);
+    }
+}
+`
+	dir := filepath.Join(r.ProjectPath, "app/Http/Controllers/Api")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+	return ui.WriteFile(filepath.Join(dir, "RealtimeHealthController.php"), []byte(content), 0644)
+}
+
+func (r *RealtimeSetup) registerHealthRoute() error {
+	path := filepath.Join(r.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping the realtime health route\n")
+		return nil
+	}
+	if strings.Contains(string(content), "RealtimeHealthController") {
+		return nil
+	}
+	route := "\nRoute::get('/health/realtime', [\\App\\Http\\Controllers\\Api\\RealtimeHealthController::class, 'check']);\n"
+	return ui.WriteFile(path, append(content, route...), 0644)
+}
+
+type envVar struct {
+	key       string
+	value     string
+	overwrite bool // replace a value that is already set
+}
+
+// ensureEnv adds the variables missing from an env file and replaces the
+// ones marked overwrite. A missing file is left alone.
+func ensureEnv(path string, vars []envVar) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	changed := false
+	var added []string
+	for _, v := range vars {
+		found := false
+		for i, line := range lines {
+			if strings.HasPrefix(line, v.key+"=") {
+				found = true
+				if v.overwrite && line != v.key+"="+v.value {
+					lines[i] = v.key + "=" + v.value
+					changed = true
+				}
+				break
+			}
+		}
+		if !found {
+			added = append(added, v.key+"="+v.value)
+		}
+	}
+	if len(added) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, added...)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return ui.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,236 @@
+package laravel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSkipped marks a step that did not run.
+var ErrSkipped = errors.New("skipped")
+
+// Policy is how a run reacts to a failed step. With FailFast the run stops
+// at the first failure; otherwise the remaining steps still run and the
+// failures are reported at the end. Report, when set, records every step.
+// Checkpoint, when set, skips the steps an earlier run finished and
+// records the new ones; once Context is cancelled no new step starts.
+type Policy struct {
+	FailFast   bool
+	Report     *Report
+	Checkpoint *Checkpoint
+	Context    context.Context
+}
+
+// track runs fn as a step, recording it in the report and checkpoint.
+func (p Policy) track(name string, fn func() error) error {
+	if p.Checkpoint != nil && p.Checkpoint.IsDone(name) {
+		p.skip(name, "done in an earlier run")
+		return nil
+	}
+	if interrupted(p.Context) {
+		p.skip(name, "interrupted")
+		return ErrInterrupted
+	}
+
+	var err error
+	if p.Report == nil {
+		err = ui.Step(name, fn)
+	} else {
+		err = p.Report.Track(name, fn)
+	}
+	if err == nil && p.Checkpoint != nil {
+		if cerr := p.Checkpoint.MarkDone(name); cerr != nil {
+			ui.Warnf("⚠️ Warning: could not save the checkpoint: %v\n", cerr)
+		}
+	}
+	return err
+}
+
+func (p Policy) skip(name, reason string) {
+	if p.Report == nil {
+		ui.Skipped(name, reason)
+		return
+	}
+	p.Report.Skip(name, reason)
+}
+
+// Report records every step of a run with its status, duration and error,
+// for the summary printed at the end and the report file. It is safe for
+// concurrent steps.
+type Report struct {
+	Command string
+
+	mu    sync.Mutex
+	start time.Time
+	steps []StepResult
+}
+
+// StepResult is one line of a report.
+type StepResult struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"-"`
+	Error    string        `json:"error,omitempty"`
+}
+
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+func NewReport(command string) *Report {
+	return &Report{Command: command, start: time.Now()}
+}
+
+// Track runs fn as a step and records its outcome under name.
+func (r *Report) Track(name string, fn func() error) error {
+	start := time.Now()
+	err := ui.Step(name, fn)
+
+	result := StepResult{Name: name, Status: StatusOK, Duration: time.Since(start)}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	r.add(result)
+	return err
+}
+
+// Skip records a step that did not run.
+func (r *Report) Skip(name, reason string) {
+	ui.Skipped(name, reason)
+	r.add(StepResult{Name: name, Status: StatusSkipped, Error: reason})
+}
+
+func (r *Report) add(result StepResult) {
+	r.mu.Lock()
+	r.steps = append(r.steps, result)
+	r.mu.Unlock()
+}
+
+// Steps returns the recorded steps in the order they finished.
+func (r *Report) Steps() []StepResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]StepResult{}, r.steps...)
+}
+
+// Err returns an error naming the failed steps, or nil when none failed.
+func (r *Report) Err() error {
+	var failed []string
+	steps := r.Steps()
+	for _, s := range steps {
+		if s.Status == StatusFailed {
+			failed = append(failed, s.Name)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d steps failed: %s", len(failed), len(steps), strings.Join(failed, ", "))
+}
+
+// Print shows every step in the order it finished with its status,
+// duration and error, then the totals. The difference between the time
+// spent in steps and the wall-clock time is what parallel steps saved.
+func (r *Report) Print() {
+	steps := r.Steps()
+	if len(steps) == 0 {
+		return
+	}
+
+	var sum time.Duration
+	counts := map[string]int{}
+	for _, s := range steps {
+		sum += s.Duration
+		counts[s.Status]++
+	}
+	wall := time.Since(r.start)
+
+	if ui.JSON() {
+		ui.Timing(sum, wall)
+		ui.Emit("summary", map[string]any{"succeeded": counts[StatusOK], "failed": counts[StatusFailed], "skipped": counts[StatusSkipped]})
+		return
+	}
+
+	ui.Println("\n📋 Summary:")
+	for _, s := range steps {
+		mark, duration := "✅", round(s.Duration).String()
+		switch s.Status {
+		case StatusFailed:
+			mark = "❌"
+		case StatusSkipped:
+			mark, duration = "⏩", "-"
+		}
+		ui.Printf("  %s %-28s %8s  %s\n", mark, s.Name, duration, firstLine(s.Error, 60))
+	}
+	ui.Printf("  %-31s %8s\n", "steps total", round(sum))
+	ui.Printf("  %-31s %8s\n", "wall clock", round(wall))
+	if saved := sum - wall; saved > time.Second {
+		ui.Printf("  %-31s %8s\n", "saved by running in parallel", round(saved))
+	}
+	ui.Printf("  %d succeeded, %d failed, %d skipped\n", counts[StatusOK], counts[StatusFailed], counts[StatusSkipped])
+}
+
+// Save writes the report as JSON to path, creating its directory.
+func (r *Report) Save(path string) error {
+	type step struct {
+		StepResult
+		DurationMS int64 `json:"duration_ms"`
+	}
+	steps := r.Steps()
+	status := StatusOK
+	out := struct {
+		Command    string    `json:"command"`
+		Status     string    `json:"status"`
+		StartedAt  time.Time `json:"started_at"`
+		FinishedAt time.Time `json:"finished_at"`
+		Steps      []step    `json:"steps"`
+	}{Command: r.Command, StartedAt: r.start, FinishedAt: time.Now()}
+	for _, s := range steps {
+		if s.Status == StatusFailed {
+			status = StatusFailed
+		}
+		out.Steps = append(out.Steps, step{StepResult: s, DurationMS: s.Duration.Milliseconds()})
+	}
+	out.Status = status
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReportPath is where a run in projectPath saves its report by default.
+func ReportPath(projectPath string) string {
+	return filepath.Join(projectPath, ".laravelboot", "report.json")
+}
+
+// firstLine cuts an error message to its first line and at most n runes,
+// for the summary table; the report file keeps the whole message.
+func firstLine(s string, n int) string {
+	s, _, _ = strings.Cut(s, "\n")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+func round(d time.Duration) time.Duration {
+	if d < time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(100 * time.Millisecond)
+}
@@ -1,7 +1,8 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -9,6 +10,7 @@ import (
 type ResponsesSetup struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewResponsesSetup(projectPath string, dryRun bool) *ResponsesSetup {
@@ -17,12 +19,15 @@ func NewResponsesSetup(projectPath string, dryRun bool) *ResponsesSetup {
 
 func (r *ResponsesSetup) Setup() error {
 	if r.DryRun {
-		fmt.Printf("[Dry Run] Would create API response helpers\n")
+		ui.Printf("[Dry Run] Would create API response helpers\n")
 		return nil
 	}
 
-	fmt.Println("📤 Creating API response helpers...")
+	ui.Println("📤 Creating API response helpers...")
 
+	if err := writeApiSupport(r.ProjectPath, r.Options, r.DryRun); err != nil {
+		return err
+	}
 	if err := r.createApiResponseTrait(); err != nil {
 		return err
 	}
@@ -38,10 +43,11 @@ func (r *ResponsesSetup) createApiResponseTrait() error {
 
 namespace App\Traits;
 
+use App\Support\Api\Envelope;
+use Illuminate\Contracts\Pagination\CursorPaginator;
+use Illuminate\Contracts\Pagination\Paginator;
 use Illuminate\Http\JsonResponse;
 use Illuminate\Http\Resources\Json\JsonResource;
-use Illuminate\Http\Resources\Json\ResourceCollection;
-use Illuminate\Pagination\LengthAwarePaginator;
 
 trait ApiResponse
 {
@@ -50,11 +56,7 @@ trait ApiResponse
      */
     protected function success(mixed $data = null, string $message = 'Success', int $code = 200): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => $data,
-        ], $code);
+        return Envelope::success($data, $message, $code);
     }
 
     /**
@@ -70,7 +72,7 @@ trait ApiResponse
      */
     protected function noContent(): JsonResponse
     {
-        return response()->json(null, 204);
+        return Envelope::noContent();
     }
 
     /**
@@ -78,16 +80,7 @@ trait ApiResponse
      */
     protected function error(string $message = 'Error', int $code = 400, mixed $errors = null): JsonResponse
     {
-        $response = [
-            'success' => false,
-            'message' => $message,
-        ];
-
-        if ($errors !== null) {
-            $response['errors'] = $errors;
-        }
-
-        return response()->json($response, $code);
+        return Envelope::error($message, $code, $errors);
     }
 
     /**
@@ -133,33 +126,15 @@ trait ApiResponse
     /**
      * Paginated response
      */
-    protected function paginated(LengthAwarePaginator $paginator, string $message = 'Success'): JsonResponse
+    protected function paginated(Paginator|CursorPaginator $paginator, string $message = 'Success', ?JsonResource $resource = null): JsonResponse
     {
-        return response()->json([
-            'success' => true,
-            'message' => $message,
-            'data' => $paginator->items(),
-            'meta' => [
-                'current_page' => $paginator->currentPage(),
-                'last_page' => $paginator->lastPage(),
-                'per_page' => $paginator->perPage(),
-                'total' => $paginator->total(),
-                'from' => $paginator->firstItem(),
-                'to' => $paginator->lastItem(),
-            ],
-            'links' => [
-                'first' => $paginator->url(1),
-                'last' => $paginator->url($paginator->lastPage()),
-                'prev' => $paginator->previousPageUrl(),
-                'next' => $paginator->nextPageUrl(),
-            ],
-        ]);
+        return Envelope::paginated($paginator, $message, $resource);
     }
 }
 `
 	dir := filepath.Join(r.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "ApiResponse.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "ApiResponse.php"), []byte(content), 0644)
 }
 
 func (r *ResponsesSetup) createExceptionHandler() error {
@@ -167,6 +142,7 @@ func (r *ResponsesSetup) createExceptionHandler() error {
 
 namespace App\Exceptions;
 
+use App\Support\Api\Envelope;
 use Illuminate\Auth\AuthenticationException;
 use Illuminate\Database\Eloquent\ModelNotFoundException;
 use Illuminate\Foundation\Exceptions\Handler as ExceptionHandler;
@@ -215,32 +191,19 @@ class Handler extends ExceptionHandler
     protected function handleApiException(Throwable $e): JsonResponse
     {
         if ($e instanceof ValidationException) {
-            return response()->json([
-                'success' => false,
-                'message' => 'Validation failed',
-                'errors' => $e->errors(),
-            ], 422);
+            return Envelope::error('Validation failed', 422, $e->errors());
         }
 
         if ($e instanceof ModelNotFoundException || $e instanceof NotFoundHttpException) {
-            return response()->json([
-                'success' => false,
-                'message' => 'Resource not found',
-            ], 404);
+            return Envelope::error('Resource not found', 404);
         }
 
         if ($e instanceof AuthenticationException) {
-            return response()->json([
-                'success' => false,
-                'message' => 'Unauthenticated',
-            ], 401);
+            return Envelope::error('Unauthenticated', 401);
         }
 
         if ($e instanceof HttpException) {
-            return response()->json([
-                'success' => false,
-                'message' => $e->getMessage() ?: 'HTTP Error',
-            ], $e->getStatusCode());
+            return Envelope::error($e->getMessage() ?: 'HTTP Error', $e->getStatusCode());
         }
 
         // Log the error for debugging
@@ -253,14 +216,11 @@ class Handler extends ExceptionHandler
 
         $message = config('app.debug') ? $e->getMessage() : 'Internal server error';
 
-        return response()->json([
-            'success' => false,
-            'message' => $message,
-        ], 500);
+        return Envelope::error($message, 500);
     }
 }
 `
 	dir := filepath.Join(r.ProjectPath, "app/Exceptions")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "Handler.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "Handler.php"), []byte(content), 0644)
 }
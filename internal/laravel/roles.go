@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,21 +20,18 @@ func NewRolesSetup(projectPath string, dryRun bool) *RolesSetup {
 
 func (r *RolesSetup) Setup() error {
 	if r.DryRun {
-		fmt.Printf("[Dry Run] Would install spatie/laravel-permission\n")
+		ui.Printf("[Dry Run] Would install spatie/laravel-permission\n")
 		return nil
 	}
 
-	fmt.Println("🔑 Installing spatie/laravel-permission...")
-	cmd := exec.Command("composer", "require", "spatie/laravel-permission", "--with-all-dependencies")
-	cmd.Dir = r.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install spatie/laravel-permission: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(r.ProjectPath, false, "spatie/laravel-permission"); err != nil {
+		return err
 	}
 
-	fmt.Println("📦 Publishing configuration...")
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\Permission\\PermissionServiceProvider")
+	ui.Println("📦 Publishing configuration...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\Permission\\PermissionServiceProvider")
 	cmd.Dir = r.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(cmd); err != nil {
 		return fmt.Errorf("failed to publish permission config: %v\nOutput: %s", err, string(output))
 	}
 
@@ -57,5 +55,5 @@ func (r *RolesSetup) modifyUserModel() error {
 		sContent = strings.Replace(sContent, "use Laravel\\Sanctum\\HasApiTokens;", "use Laravel\\Sanctum\\HasApiTokens;\nuse Spatie\\Permission\\Traits\\HasRoles;", 1)
 	}
 
-	return os.WriteFile(path, []byte(sContent), 0644)
+	return ui.WriteFile(path, []byte(sContent), 0644)
 }
@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewRulesSetup(projectPath string, dryRun bool) *RulesSetup {
 
 func (r *RulesSetup) Setup() error {
 	if r.DryRun {
-		fmt.Printf("[Dry Run] Would create custom validation rules\n")
+		ui.Printf("[Dry Run] Would create custom validation rules\n")
 		return nil
 	}
 
-	fmt.Println("📏 Creating custom validation rules...")
+	ui.Println("📏 Creating custom validation rules...")
 
 	rulesDir := filepath.Join(r.ProjectPath, "app/Rules")
 	os.MkdirAll(rulesDir, 0755)
@@ -94,7 +94,7 @@ class Base64Image implements ValidationRule
 }
 `
 	path := filepath.Join(r.ProjectPath, "app/Rules/Base64Image.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (r *RulesSetup) createPhoneNumberRule() error {
@@ -142,7 +142,7 @@ class PhoneNumber implements ValidationRule
 }
 `
 	path := filepath.Join(r.ProjectPath, "app/Rules/PhoneNumber.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (r *RulesSetup) createScopedUniqueRule() error {
@@ -188,7 +188,7 @@ class ScopedUnique implements ValidationRule
 }
 `
 	path := filepath.Join(r.ProjectPath, "app/Rules/ScopedUnique.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (r *RulesSetup) createTimeFormatRule() error {
@@ -219,7 +219,7 @@ class TimeFormat implements ValidationRule
 }
 `
 	path := filepath.Join(r.ProjectPath, "app/Rules/TimeFormat.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (r *RulesSetup) createStrongPasswordRule() error {
@@ -273,5 +273,5 @@ class StrongPassword implements ValidationRule
 }
 `
 	path := filepath.Join(r.ProjectPath, "app/Rules/StrongPassword.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
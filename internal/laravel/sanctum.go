@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os/exec"
 )
 
@@ -16,13 +17,13 @@ func NewSanctumInstaller(projectPath string, dryRun bool) *SanctumInstaller {
 
 func (s *SanctumInstaller) Install() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would run: php artisan install:api\n")
+		ui.Printf("[Dry Run] Would run: php artisan install:api\n")
 		return nil
 	}
 
 	cmd := exec.Command("php", "artisan", "install:api", "--no-interaction")
 	cmd.Dir = s.ProjectPath
-	output, err := cmd.CombinedOutput()
+	output, err := ui.Run(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to install API (Sanctum): %v\nOutput: %s", err, string(output))
 	}
@@ -1,9 +1,10 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type SchedulerSetup struct {
@@ -17,11 +18,11 @@ func NewSchedulerSetup(projectPath string, dryRun bool) *SchedulerSetup {
 
 func (s *SchedulerSetup) Setup() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would setup scheduler and console commands\n")
+		ui.Printf("[Dry Run] Would setup scheduler and console commands\n")
 		return nil
 	}
 
-	fmt.Println("⏰ Setting up scheduler and console commands...")
+	ui.Println("⏰ Setting up scheduler and console commands...")
 
 	commandsDir := filepath.Join(s.ProjectPath, "app/Console/Commands")
 	os.MkdirAll(commandsDir, 0755)
@@ -97,7 +98,7 @@ abstract class BaseCommand extends Command
 }
 `
 	path := filepath.Join(s.ProjectPath, "app/Console/Commands/BaseCommand.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (s *SchedulerSetup) createCleanupCommand() error {
@@ -189,7 +190,7 @@ class CleanupCommand extends BaseCommand
 }
 `
 	path := filepath.Join(s.ProjectPath, "app/Console/Commands/CleanupCommand.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (s *SchedulerSetup) createHealthCheckCommand() error {
@@ -294,5 +295,34 @@ class HealthCheckCommand extends BaseCommand
 }
 `
 	path := filepath.Join(s.ProjectPath, "app/Console/Commands/HealthCheckCommand.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
+}
+
+// scheduleCommand adds a Schedule:: entry to routes/console.php, the
+// scheduler of Laravel 11 and later, unless the same entry is there.
+func scheduleCommand(projectPath, entry string) error {
+	path := filepath.Join(projectPath, "routes/console.php")
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	text := string(content)
+	if text == "" {
+		text = "<?php\n"
+	}
+	if strings.Contains(text, entry) {
+		return nil
+	}
+
+	const use = "use Illuminate\\Support\\Facades\\Schedule;"
+	if !strings.Contains(text, use) {
+		if i := strings.Index(text, "\nuse "); i >= 0 {
+			text = text[:i+1] + use + "\n" + text[i+1:]
+		} else {
+			text = strings.Replace(text, "<?php\n", "<?php\n\n"+use+"\n", 1)
+		}
+	}
+	text = strings.TrimRight(text, "\n") + "\n\n" + entry + "\n"
+	return ui.WriteFile(path, []byte(text), 0644)
 }
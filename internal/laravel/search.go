@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os/exec"
 )
 
@@ -16,28 +17,18 @@ func NewSearchSetup(projectPath string, dryRun bool) *SearchSetup {
 
 func (s *SearchSetup) Setup() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would install laravel/scout and typesense/typesense-php\n")
+		ui.Printf("[Dry Run] Would install laravel/scout and typesense/typesense-php\n")
 		return nil
 	}
 
-	fmt.Println("🔍 Installing laravel/scout...")
-	cmd := exec.Command("composer", "require", "laravel/scout", "--with-all-dependencies")
-	cmd.Dir = s.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install laravel/scout: %v\nOutput: %s", err, string(output))
-	}
-
-	fmt.Println("🔍 Installing typesense/typesense-php and typesense/laravel-scout-typesense-driver...")
-	cmd = exec.Command("composer", "require", "typesense/typesense-php", "typesense/laravel-scout-typesense-driver", "--with-all-dependencies")
-	cmd.Dir = s.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install typesense driver: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(s.ProjectPath, false, "laravel/scout", "typesense/typesense-php", "typesense/laravel-scout-typesense-driver"); err != nil {
+		return err
 	}
 
-	fmt.Println("📦 Publishing scout configuration...")
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Scout\\ScoutServiceProvider")
+	ui.Println("📦 Publishing scout configuration...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Laravel\\Scout\\ScoutServiceProvider")
 	cmd.Dir = s.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(cmd); err != nil {
 		return fmt.Errorf("failed to publish scout config: %v\nOutput: %s", err, string(output))
 	}
 
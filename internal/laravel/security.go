@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -50,10 +50,10 @@ class ForceJsonResponse
 	}
 	path := filepath.Join(dir, "ForceJsonResponse.php")
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would create middleware: %s\n", path)
+		ui.Printf("[Dry Run] Would create middleware: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (s *SecuritySetup) createEnvValidator() error {
@@ -89,8 +89,8 @@ class EnvValidator
 	}
 	path := filepath.Join(dir, "EnvValidator.php")
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would create environment validator: %s\n", path)
+		ui.Printf("[Dry Run] Would create environment validator: %s\n", path)
 		return nil
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
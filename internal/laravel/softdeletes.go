@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -17,11 +17,11 @@ func NewSoftDeletesSetup(projectPath string, dryRun bool) *SoftDeletesSetup {
 
 func (s *SoftDeletesSetup) Setup() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would setup soft deletes and trash management\n")
+		ui.Printf("[Dry Run] Would setup soft deletes and trash management\n")
 		return nil
 	}
 
-	fmt.Println("🗑️ Setting up soft deletes and trash management...")
+	ui.Println("🗑️ Setting up soft deletes and trash management...")
 
 	if err := s.createHasSoftDeletesTrait(); err != nil {
 		return err
@@ -114,7 +114,7 @@ trait HasSoftDeletes
 `
 	dir := filepath.Join(s.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "HasSoftDeletes.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "HasSoftDeletes.php"), []byte(content), 0644)
 }
 
 func (s *SoftDeletesSetup) createTrashService() error {
@@ -235,5 +235,5 @@ class TrashService
 `
 	dir := filepath.Join(s.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "TrashService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "TrashService.php"), []byte(content), 0644)
 }
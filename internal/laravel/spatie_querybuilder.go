@@ -1,7 +1,8 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 type SpatieQueryBuilder struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewSpatieQueryBuilder(projectPath string, dryRun bool) *SpatieQueryBuilder {
@@ -18,28 +20,28 @@ func NewSpatieQueryBuilder(projectPath string, dryRun bool) *SpatieQueryBuilder
 
 func (s *SpatieQueryBuilder) Install() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would install spatie/laravel-query-builder with config and service\n")
+		ui.Printf("[Dry Run] Would install spatie/laravel-query-builder with config and service\n")
 		return nil
 	}
 
-	fmt.Println("🔍 Installing spatie/laravel-query-builder...")
-	cmd := exec.Command("composer", "require", "spatie/laravel-query-builder", "--with-all-dependencies")
-	cmd.Dir = s.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install spatie/laravel-query-builder: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(s.ProjectPath, false, "spatie/laravel-query-builder"); err != nil {
+		return err
 	}
 
-	fmt.Println("⚙️ Publishing config...")
-	cmd = exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\QueryBuilder\\QueryBuilderServiceProvider", "--tag=query-builder-config")
+	ui.Println("⚙️ Publishing config...")
+	cmd := exec.Command("php", "artisan", "vendor:publish", "--provider=Spatie\\QueryBuilder\\QueryBuilderServiceProvider", "--tag=query-builder-config")
 	cmd.Dir = s.ProjectPath
-	_ = cmd.Run()
+	_, _ = ui.Run(cmd)
 
-	fmt.Println("🔧 Creating QueryBuilderService...")
+	ui.Println("🔧 Creating QueryBuilderService...")
+	if err := writeApiSupport(s.ProjectPath, s.Options, s.DryRun); err != nil {
+		return err
+	}
 	if err := s.createQueryBuilderService(); err != nil {
 		return err
 	}
 
-	fmt.Println("🔧 Creating example QueryBuilder...")
+	ui.Println("🔧 Creating example QueryBuilder...")
 	if err := s.CreateExample(); err != nil {
 		return err
 	}
@@ -52,6 +54,7 @@ func (s *SpatieQueryBuilder) createQueryBuilderService() error {
 
 namespace App\Services;
 
+use App\Support\Api\Pagination;
 use Illuminate\Database\Eloquent\Builder;
 use Illuminate\Http\Request;
 use Spatie\QueryBuilder\QueryBuilder;
@@ -96,23 +99,22 @@ class QueryBuilderService
     }
 
     /**
-     * Apply pagination to a query builder.
+     * Apply pagination to a query builder. The strategy and page size come
+     * from the query string (page[strategy], page[size]); see Pagination.
      */
-    public static function paginate(QueryBuilder $query, ?int $perPage = null)
+    public static function paginate(QueryBuilder $query, ?string $strategy = null)
     {
-        $perPage = $perPage ?? request()->input('per_page', 15);
-        
         if (request()->input('paginate', true) === 'false') {
             return $query->get();
         }
 
-        return $query->paginate($perPage);
+        return Pagination::apply($query, $strategy);
     }
 }
 `
 	dir := filepath.Join(s.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "QueryBuilderService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "QueryBuilderService.php"), []byte(content), 0644)
 }
 
 func (s *SpatieQueryBuilder) CreateExample() error {
@@ -121,6 +123,9 @@ func (s *SpatieQueryBuilder) CreateExample() error {
 namespace App\Domain\Users\QueryBuilders;
 
 use App\Models\User;
+use App\Support\Api\Pagination;
+use Illuminate\Contracts\Pagination\CursorPaginator;
+use Illuminate\Contracts\Pagination\Paginator;
 use Spatie\QueryBuilder\QueryBuilder;
 use Spatie\QueryBuilder\AllowedFilter;
 
@@ -135,18 +140,30 @@ class UserQueryBuilder extends QueryBuilder
             AllowedFilter::exact('email'),
             AllowedFilter::exact('id'),
         ])
-        ->allowedSorts(['name', 'email', 'created_at'])
+        ->allowedSorts(['id', 'name', 'email', 'created_at'])
         ->allowedIncludes(['posts', 'roles'])
-        ->defaultSort('-created_at');
+        // id breaks ties so cursor pagination has a unique order
+        ->defaultSort('-created_at', '-id');
+    }
+
+    /**
+     * One page of users, e.g. GET /users?filter[name]=ann&page[size]=50
+     * or ?page[strategy]=cursor&page[cursor]=...
+     *
+     * Usage: return Envelope::paginated((new UserQueryBuilder)->page());
+     */
+    public function page(?string $strategy = null): Paginator|CursorPaginator
+    {
+        return Pagination::apply($this, $strategy);
     }
 }
 `
 	dir := filepath.Join(s.ProjectPath, "app/Domain/Users/QueryBuilders")
 	os.MkdirAll(dir, 0755)
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would create example QueryBuilder: %s\n", filepath.Join(dir, "UserQueryBuilder.php"))
+		ui.Printf("[Dry Run] Would create example QueryBuilder: %s\n", filepath.Join(dir, "UserQueryBuilder.php"))
 		return nil
 	}
 
-	return os.WriteFile(filepath.Join(dir, "UserQueryBuilder.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "UserQueryBuilder.php"), []byte(content), 0644)
 }
@@ -1,9 +1,10 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type StorageSetup struct {
@@ -17,11 +18,11 @@ func NewStorageSetup(projectPath string, dryRun bool) *StorageSetup {
 
 func (s *StorageSetup) Setup() error {
 	if s.DryRun {
-		fmt.Printf("[Dry Run] Would setup file storage service\n")
+		ui.Printf("[Dry Run] Would setup file storage service\n")
 		return nil
 	}
 
-	fmt.Println("📁 Setting up file storage service...")
+	ui.Println("📁 Setting up file storage service...")
 
 	if err := s.createFileService(); err != nil {
 		return err
@@ -29,6 +30,9 @@ func (s *StorageSetup) Setup() error {
 	if err := s.createFileController(); err != nil {
 		return err
 	}
+	if err := s.registerRoutes(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -225,7 +229,7 @@ class FileService
 `
 	dir := filepath.Join(s.ProjectPath, "app/Services")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "FileService.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "FileService.php"), []byte(content), 0644)
 }
 
 func (s *StorageSetup) createFileController() error {
@@ -328,5 +332,28 @@ class FileController extends Controller
 `
 	dir := filepath.Join(s.ProjectPath, "app/Http/Controllers/Api")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "FileController.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "FileController.php"), []byte(content), 0644)
+}
+
+func (s *StorageSetup) registerRoutes() error {
+	path := filepath.Join(s.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping file routes\n")
+		return nil
+	}
+
+	if strings.Contains(string(content), "FileController") {
+		return nil
+	}
+
+	routes := `
+Route::middleware('auth:sanctum')->prefix('files')->group(function () {
+    Route::post('/upload', [\App\Http\Controllers\Api\FileController::class, 'upload']);
+    Route::post('/upload-multiple', [\App\Http\Controllers\Api\FileController::class, 'uploadMultiple']);
+    Route::get('/download', [\App\Http\Controllers\Api\FileController::class, 'download']);
+    Route::delete('/', [\App\Http\Controllers\Api\FileController::class, 'destroy']);
+});
+`
+	return ui.WriteFile(path, []byte(string(content)+routes), 0644)
 }
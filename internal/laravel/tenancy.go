@@ -2,6 +2,7 @@ package laravel
 
 import (
 	"fmt"
+	"laravelboot/internal/ui"
 	"os/exec"
 )
 
@@ -16,21 +17,18 @@ func NewTenancySetup(projectPath string, dryRun bool) *TenancySetup {
 
 func (t *TenancySetup) Setup() error {
 	if t.DryRun {
-		fmt.Printf("[Dry Run] Would install Multi-Tenancy support (stancl/tenancy)\n")
+		ui.Printf("[Dry Run] Would install Multi-Tenancy support (stancl/tenancy)\n")
 		return nil
 	}
 
-	fmt.Println("🏢 Installing stancl/tenancy...")
-	cmd := exec.Command("composer", "require", "stancl/tenancy", "--with-all-dependencies")
-	cmd.Dir = t.ProjectPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to install stancl/tenancy: %v\nOutput: %s", err, string(output))
+	if err := composerRequire(t.ProjectPath, false, "stancl/tenancy"); err != nil {
+		return err
 	}
 
-	fmt.Println("⚙️ Initializing tenancy...")
+	ui.Println("⚙️ Initializing tenancy...")
 	initCmd := exec.Command("php", "artisan", "tenancy:install")
 	initCmd.Dir = t.ProjectPath
-	if output, err := initCmd.CombinedOutput(); err != nil {
+	if output, err := ui.Run(initCmd); err != nil {
 		return fmt.Errorf("failed to initialize tenancy: %v\nOutput: %s", err, string(output))
 	}
 
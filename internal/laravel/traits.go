@@ -1,7 +1,8 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 )
@@ -9,6 +10,7 @@ import (
 type TraitsSetup struct {
 	ProjectPath string
 	DryRun      bool
+	Options     config.Options
 }
 
 func NewTraitsSetup(projectPath string, dryRun bool) *TraitsSetup {
@@ -17,12 +19,15 @@ func NewTraitsSetup(projectPath string, dryRun bool) *TraitsSetup {
 
 func (t *TraitsSetup) Setup() error {
 	if t.DryRun {
-		fmt.Printf("[Dry Run] Would create common API traits\n")
+		ui.Printf("[Dry Run] Would create common API traits\n")
 		return nil
 	}
 
-	fmt.Println("🧬 Creating common traits...")
+	ui.Println("🧬 Creating common traits...")
 
+	if err := writeApiSupport(t.ProjectPath, t.Options, t.DryRun); err != nil {
+		return err
+	}
 	if err := t.createApiTrait(); err != nil {
 		return err
 	}
@@ -89,7 +94,7 @@ trait Api
 `
 	dir := filepath.Join(t.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "Api.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "Api.php"), []byte(content), 0644)
 }
 
 func (t *TraitsSetup) createHandlesPaginationTrait() error {
@@ -97,16 +102,18 @@ func (t *TraitsSetup) createHandlesPaginationTrait() error {
 
 namespace App\Traits;
 
+use App\Support\Api\Envelope;
+use App\Support\Api\Pagination;
 use Illuminate\Database\Eloquent\Builder;
+use Illuminate\Http\JsonResponse;
 use Illuminate\Http\Request;
-use Illuminate\Http\Resources\Json\ResourceCollection;
 
 trait HandlesPagination
 {
     /**
      * Handle pagination for queries
      */
-    public function handlePagination(Builder $query, Request $request, string $collectionClass): ResourceCollection
+    public function handlePagination(Builder $query, Request $request, string $collectionClass): JsonResponse
     {
         $searchQuery = $request->query('search');
 
@@ -114,22 +121,22 @@ trait HandlesPagination
             $query->search($searchQuery);
         }
 
-        if ($request->query('paginate', true) !== 'false') {
-            $data = $query->paginate($request->query('per_page', 15));
-        } else {
-            $data = $query->get();
+        if ($request->query('paginate', true) === 'false') {
+            return Envelope::success(new $collectionClass($query->get()));
         }
 
-        return new $collectionClass($data);
+        $paginator = Pagination::apply($query, request: $request);
+
+        return Envelope::paginated($paginator, resource: new $collectionClass($paginator));
     }
 
     /**
      * Paginate a collection
      */
-    public function paginateCollection(\Illuminate\Support\Collection $collection, Request $request, string $collectionClass): ResourceCollection
+    public function paginateCollection(\Illuminate\Support\Collection $collection, Request $request, string $collectionClass): JsonResponse
     {
-        $page = (int) $request->query('page', 1);
-        $perPage = (int) $request->query('per_page', 15);
+        $page = Pagination::number($request);
+        $perPage = Pagination::size($request);
 
         $items = $collection->slice(($page - 1) * $perPage, $perPage)->values();
 
@@ -141,13 +148,13 @@ trait HandlesPagination
             ['path' => $request->url(), 'query' => $request->query()]
         );
 
-        return new $collectionClass($paginated);
+        return Envelope::paginated($paginated, resource: new $collectionClass($paginated));
     }
 }
 `
 	dir := filepath.Join(t.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "HandlesPagination.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "HandlesPagination.php"), []byte(content), 0644)
 }
 
 func (t *TraitsSetup) createAuditableTrait() error {
@@ -189,5 +196,5 @@ trait Auditable
 `
 	dir := filepath.Join(t.ProjectPath, "app/Traits")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "Auditable.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "Auditable.php"), []byte(content), 0644)
 }
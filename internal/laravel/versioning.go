@@ -1,7 +1,7 @@
 package laravel
 
 import (
-	"fmt"
+	"laravelboot/internal/ui"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,11 +18,11 @@ func NewVersioningSetup(projectPath string, dryRun bool) *VersioningSetup {
 
 func (v *VersioningSetup) Setup() error {
 	if v.DryRun {
-		fmt.Printf("[Dry Run] Would setup API versioning app structure\n")
+		ui.Printf("[Dry Run] Would setup API versioning app structure\n")
 		return nil
 	}
 
-	fmt.Println("🔢 Setting up API versioning...")
+	ui.Println("🔢 Setting up API versioning...")
 
 	// Create versioned controller directories
 	v1Dir := filepath.Join(v.ProjectPath, "app/Http/Controllers/Api/V1")
@@ -69,7 +69,7 @@ abstract class BaseApiController extends Controller
 `
 	dir := filepath.Join(v.ProjectPath, "app/Http/Controllers/Api")
 	os.MkdirAll(dir, 0755)
-	return os.WriteFile(filepath.Join(dir, "BaseApiController.php"), []byte(content), 0644)
+	return ui.WriteFile(filepath.Join(dir, "BaseApiController.php"), []byte(content), 0644)
 }
 
 func (v *VersioningSetup) createV1Controller() error {
@@ -88,7 +88,7 @@ abstract class V1Controller extends BaseApiController
 }
 `
 	path := filepath.Join(v.ProjectPath, "app/Http/Controllers/Api/V1/V1Controller.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (v *VersioningSetup) createV2Controller() error {
@@ -107,7 +107,7 @@ abstract class V2Controller extends BaseApiController
 }
 `
 	path := filepath.Join(v.ProjectPath, "app/Http/Controllers/Api/V2/V2Controller.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
 
 func (v *VersioningSetup) updateBootstrapApp() error {
@@ -132,9 +132,9 @@ func (v *VersioningSetup) updateBootstrapApp() error {
 		}
 
 		if newText != text {
-			return os.WriteFile(path, []byte(newText), 0644)
+			return ui.WriteFile(path, []byte(newText), 0644)
 		}
-		fmt.Println("⚠️ Could not automatically inject apiPrefix in bootstrap/app.php")
+		ui.Warnf("⚠️ Could not automatically inject apiPrefix in bootstrap/app.php\n")
 	}
 
 	return nil
@@ -173,5 +173,5 @@ Route::get('/health', function () {
 });
 `
 	path := filepath.Join(v.ProjectPath, "routes/api.php")
-	return os.WriteFile(path, []byte(content), 0644)
+	return ui.WriteFile(path, []byte(content), 0644)
 }
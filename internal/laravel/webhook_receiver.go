@@ -0,0 +1,551 @@
+package laravel
+
+import (
+	"fmt"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultWebhookSources are generated when options.webhook-receiver.sources
+// is empty.
+var DefaultWebhookSources = []string{"stripe", "github"}
+
+type WebhookReceiverSetup struct {
+	ProjectPath string
+	DryRun      bool
+	Sources     []string
+}
+
+func NewWebhookReceiverSetup(projectPath string, dryRun bool) *WebhookReceiverSetup {
+	return &WebhookReceiverSetup{ProjectPath: projectPath, DryRun: dryRun, Sources: DefaultWebhookSources}
+}
+
+func (w *WebhookReceiverSetup) Setup() error {
+	if w.DryRun {
+		ui.Printf("[Dry Run] Would create webhook receiver routes for: %s\n", strings.Join(w.Sources, ", "))
+		return nil
+	}
+
+	// WebhookHandlerJob extends BaseJob.
+	if err := requireFeatures(w.ProjectPath, "webhook-receiver", "jobs"); err != nil {
+		return err
+	}
+
+	ui.Println("📥 Setting up webhook receiver...")
+
+	steps := []func() error{
+		w.createConfig,
+		w.createMigration,
+		w.createModel,
+		w.createVerifiers,
+		w.createHandlerJobs,
+		w.createController,
+		w.registerRoutes,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return scheduleCommand(w.ProjectPath, "Schedule::command('model:prune', ['--model' => [\\App\\Models\\WebhookCall::class]])->daily();")
+}
+
+// webhookVerifier picks the signature scheme for a source name.
+func webhookVerifier(source string) string {
+	switch source {
+	case "stripe":
+		return "StripeSignature"
+	case "github":
+		return "GitHubSignature"
+	default:
+		return "HmacSignature"
+	}
+}
+
+// studly turns a source name into a class name part: "billing-api" => "BillingApi".
+func studly(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+func webhookHandlerClass(source string) string {
+	return "Handle" + studly(source) + "Webhook"
+}
+
+func (w *WebhookReceiverSetup) createConfig() error {
+	var sources strings.Builder
+	for _, source := range w.Sources {
+		env := "WEBHOOK_" + strings.ToUpper(strings.ReplaceAll(source, "-", "_")) + "_SECRET"
+		fmt.Fprintf(&sources, `
+        '%s' => [
+            'verifier' => \App\Webhooks\Receiving\%s::class,
+            'secret' => env('%s'),
+            // Handler jobs by event name; "*" catches the rest.
+            'handlers' => [
+                '*' => \App\Jobs\Webhooks\%s::class,
+            ],
+        ],
+`, source, webhookVerifier(source), env, webhookHandlerClass(source))
+	}
+
+	content := `<?php
+
+return [
+
+    /*
+    |--------------------------------------------------------------------------
+    | Sources
+    |--------------------------------------------------------------------------
+    |
+    | Each source is received on POST /api/webhooks/in/{source}. Calls with a
+    | bad signature are rejected, duplicates (same source and id) are
+    | acknowledged but not processed again, and the rest are stored raw and
+    | handed to the handler job for their event.
+    |
+    */
+
+    'sources' => [` + sources.String() + `
+    ],
+
+    // Oldest accepted signature timestamp, in seconds, for verifiers that sign one.
+    'tolerance' => (int) env('WEBHOOK_TOLERANCE', 300),
+
+    'queue' => env('WEBHOOK_RECEIVER_QUEUE', 'webhooks'),
+
+    // Stored calls older than this are pruned daily.
+    'keep_days' => (int) env('WEBHOOK_RECEIVER_KEEP_DAYS', 30),
+
+];
+`
+	dir := filepath.Join(w.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "webhook-receiver.php"), []byte(content), 0644)
+}
+
+func (w *WebhookReceiverSetup) createMigration() error {
+	content := `<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('webhook_calls', function (Blueprint $table) {
+            $table->id();
+            $table->string('source');
+            $table->string('external_id')->nullable();
+            $table->string('event')->nullable();
+            $table->json('headers');
+            $table->longText('payload');
+            $table->string('status')->default('received');
+            $table->text('exception')->nullable();
+            $table->timestamp('processed_at')->nullable();
+            $table->timestamps();
+
+            $table->unique(['source', 'external_id']);
+            $table->index(['source', 'event']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('webhook_calls');
+    }
+};
+`
+	dir := filepath.Join(w.ProjectPath, "database/migrations")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(w.ProjectPath, migrationFile(110, "create_webhook_calls_table")), []byte(content), 0644)
+}
+
+func (w *WebhookReceiverSetup) createModel() error {
+	content := `<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Builder;
+use Illuminate\Database\Eloquent\MassPrunable;
+use Illuminate\Database\Eloquent\Model;
+
+/**
+ * One received webhook, with its raw body exactly as it was signed.
+ */
+class WebhookCall extends Model
+{
+    use MassPrunable;
+
+    public const RECEIVED = 'received';
+    public const PROCESSING = 'processing';
+    public const PROCESSED = 'processed';
+    public const FAILED = 'failed';
+
+    protected $fillable = ['source', 'external_id', 'event', 'headers', 'payload', 'status', 'exception', 'processed_at'];
+
+    protected function casts(): array
+    {
+        return [
+            'headers' => 'array',
+            'processed_at' => 'datetime',
+        ];
+    }
+
+    /**
+     * The payload decoded as JSON.
+     */
+    public function json(?string $key = null, mixed $default = null): mixed
+    {
+        return data_get(json_decode($this->payload, true), $key, $default);
+    }
+
+    public function prunable(): Builder
+    {
+        return static::where('created_at', '<', now()->subDays(config('webhook-receiver.keep_days', 30)));
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Models")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "WebhookCall.php"), []byte(content), 0644)
+}
+
+func (w *WebhookReceiverSetup) createVerifiers() error {
+	files := map[string]string{
+		"SignatureVerifier.php": `<?php
+
+namespace App\Webhooks\Receiving;
+
+use Illuminate\Http\Request;
+
+/**
+ * A source's signature scheme, plus where it puts the delivery id (used
+ * for deduplication) and the event name (used to pick the handler).
+ */
+interface SignatureVerifier
+{
+    public function verify(Request $request, string $secret): bool;
+
+    public function id(Request $request): ?string;
+
+    public function event(Request $request): ?string;
+}
+`,
+		"HmacSignature.php": `<?php
+
+namespace App\Webhooks\Receiving;
+
+use Illuminate\Http\Request;
+
+/**
+ * The scheme of the webhooks feature: Webhook-Signature is
+ * "sha256=" . HMAC-SHA256("{Webhook-Timestamp}.{body}"), and the timestamp
+ * must be within webhook-receiver.tolerance seconds.
+ */
+class HmacSignature implements SignatureVerifier
+{
+    public function verify(Request $request, string $secret): bool
+    {
+        $timestamp = (int) $request->header('Webhook-Timestamp');
+        $signature = (string) $request->header('Webhook-Signature');
+
+        if (abs(now()->getTimestamp() - $timestamp) > config('webhook-receiver.tolerance', 300)) {
+            return false;
+        }
+
+        $expected = 'sha256=' . hash_hmac('sha256', $timestamp . '.' . $request->getContent(), $secret);
+
+        return hash_equals($expected, $signature);
+    }
+
+    public function id(Request $request): ?string
+    {
+        return $request->header('Webhook-Id') ?? $request->json('id');
+    }
+
+    public function event(Request $request): ?string
+    {
+        return $request->header('Webhook-Event') ?? $request->json('event');
+    }
+}
+`,
+		"StripeSignature.php": `<?php
+
+namespace App\Webhooks\Receiving;
+
+use Illuminate\Http\Request;
+
+/**
+ * Stripe-Signature: "t=<timestamp>,v1=<hex HMAC-SHA256 of "{t}.{body}">".
+ * Stripe may send several v1 signatures while a secret is being rolled.
+ */
+class StripeSignature implements SignatureVerifier
+{
+    public function verify(Request $request, string $secret): bool
+    {
+        $timestamp = null;
+        $signatures = [];
+
+        foreach (explode(',', (string) $request->header('Stripe-Signature')) as $part) {
+            [$key, $value] = array_pad(explode('=', trim($part), 2), 2, '');
+            if ($key === 't') {
+                $timestamp = (int) $value;
+            } elseif ($key === 'v1') {
+                $signatures[] = $value;
+            }
+        }
+
+        if ($timestamp === null || abs(now()->getTimestamp() - $timestamp) > config('webhook-receiver.tolerance', 300)) {
+            return false;
+        }
+
+        $expected = hash_hmac('sha256', $timestamp . '.' . $request->getContent(), $secret);
+
+        foreach ($signatures as $signature) {
+            if (hash_equals($expected, $signature)) {
+                return true;
+            }
+        }
+
+        return false;
+    }
+
+    public function id(Request $request): ?string
+    {
+        return $request->json('id');
+    }
+
+    public function event(Request $request): ?string
+    {
+        return $request->json('type');
+    }
+}
+`,
+		"GitHubSignature.php": `<?php
+
+namespace App\Webhooks\Receiving;
+
+use Illuminate\Http\Request;
+
+/**
+ * X-Hub-Signature-256: "sha256=<hex HMAC-SHA256 of the body>". GitHub signs
+ * no timestamp; X-GitHub-Delivery deduplicates redeliveries instead.
+ */
+class GitHubSignature implements SignatureVerifier
+{
+    public function verify(Request $request, string $secret): bool
+    {
+        $expected = 'sha256=' . hash_hmac('sha256', $request->getContent(), $secret);
+
+        return hash_equals($expected, (string) $request->header('X-Hub-Signature-256'));
+    }
+
+    public function id(Request $request): ?string
+    {
+        return $request->header('X-GitHub-Delivery');
+    }
+
+    public function event(Request $request): ?string
+    {
+        $event = $request->header('X-GitHub-Event');
+        $action = $request->json('action');
+
+        return $event && $action ? "{$event}.{$action}" : $event;
+    }
+}
+`,
+	}
+
+	dir := filepath.Join(w.ProjectPath, "app/Webhooks/Receiving")
+	os.MkdirAll(dir, 0755)
+	for name, content := range files {
+		if err := ui.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *WebhookReceiverSetup) createHandlerJobs() error {
+	base := `<?php
+
+namespace App\Jobs\Webhooks;
+
+use App\Jobs\BaseJob;
+use App\Models\WebhookCall;
+
+/**
+ * Processes one stored webhook call. Extend it and implement process();
+ * the call's status and any exception are recorded for you.
+ */
+abstract class WebhookHandlerJob extends BaseJob
+{
+    public function __construct(public WebhookCall $call)
+    {
+    }
+
+    abstract protected function process(WebhookCall $call): void;
+
+    public function handle(): void
+    {
+        $this->call->update(['status' => WebhookCall::PROCESSING]);
+
+        $this->process($this->call);
+
+        $this->call->update(['status' => WebhookCall::PROCESSED, 'exception' => null, 'processed_at' => now()]);
+    }
+
+    public function failed(\Throwable $exception): void
+    {
+        $this->call->update(['status' => WebhookCall::FAILED, 'exception' => $exception->getMessage()]);
+
+        parent::failed($exception);
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Jobs/Webhooks")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "WebhookHandlerJob.php"), []byte(base), 0644); err != nil {
+		return err
+	}
+
+	for _, source := range w.Sources {
+		class := webhookHandlerClass(source)
+		path := filepath.Join(dir, class+".php")
+		// Handlers hold application code; never overwrite one.
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		content := fmt.Sprintf(`<?php
+
+namespace App\Jobs\Webhooks;
+
+use App\Models\WebhookCall;
+use Illuminate\Support\Facades\Log;
+
+class %s extends WebhookHandlerJob
+{
+    protected function process(WebhookCall $call): void
+    {
+        match ($call->event) {
+            // 'invoice.paid' => $this->invoicePaid($call->json('data.object')),
+            default => Log::info('Unhandled %s webhook', ['event' => $call->event, 'id' => $call->external_id]),
+        };
+    }
+}
+`, class, source)
+		if err := ui.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *WebhookReceiverSetup) createController() error {
+	content := `<?php
+
+namespace App\Http\Controllers\Api;
+
+use App\Http\Controllers\Controller;
+use App\Models\WebhookCall;
+use App\Support\Api\Envelope;
+use App\Webhooks\Receiving\SignatureVerifier;
+use Illuminate\Database\UniqueConstraintViolationException;
+use Illuminate\Http\Request;
+use Illuminate\Support\Str;
+
+class WebhookReceiverController extends Controller
+{
+    public function __invoke(Request $request, string $source)
+    {
+        $config = config("webhook-receiver.sources.{$source}");
+        abort_if($config === null, 404, 'Unknown webhook source');
+        abort_if(empty($config['secret']), 500, "No secret configured for the {$source} webhook source");
+
+        /** @var SignatureVerifier $verifier */
+        $verifier = app($config['verifier']);
+        abort_unless($verifier->verify($request, $config['secret']), 401, 'Invalid webhook signature');
+
+        $id = $verifier->id($request);
+        $event = $verifier->event($request);
+
+        if ($id !== null && WebhookCall::where('source', $source)->where('external_id', $id)->exists()) {
+            return Envelope::success(['duplicate' => true], 'Webhook already received');
+        }
+
+        try {
+            $call = WebhookCall::create([
+                'source' => $source,
+                'external_id' => $id,
+                'event' => $event,
+                'headers' => collect($request->headers->all())->except(['cookie', 'authorization'])->all(),
+                'payload' => $request->getContent(),
+            ]);
+        } catch (UniqueConstraintViolationException) {
+            // A concurrent retry of the same delivery got there first.
+            return Envelope::success(['duplicate' => true], 'Webhook already received');
+        }
+
+        $handler = $this->handlerFor($config['handlers'] ?? [], $event);
+        if ($handler !== null) {
+            $handler::dispatch($call)->onQueue(config('webhook-receiver.queue', 'webhooks'));
+        }
+
+        return Envelope::success(['id' => $call->id], 'Webhook received', 202);
+    }
+
+    /**
+     * The exact event first, then the longest matching pattern such as
+     * "invoice.*", then "*".
+     */
+    protected function handlerFor(array $handlers, ?string $event): ?string
+    {
+        if ($event !== null && isset($handlers[$event])) {
+            return $handlers[$event];
+        }
+
+        $patterns = collect($handlers)
+            ->filter(fn ($handler, $pattern) => $pattern !== '*' && $event !== null && Str::is($pattern, $event))
+            ->sortKeysUsing(fn ($a, $b) => strlen($b) <=> strlen($a));
+
+        return $patterns->first() ?? $handlers['*'] ?? null;
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Http/Controllers/Api")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "WebhookReceiverController.php"), []byte(content), 0644)
+}
+
+// registerRoutes adds one route per source, including sources added to the
+// config since the last run.
+func (w *WebhookReceiverSetup) registerRoutes() error {
+	path := filepath.Join(w.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping webhook receiver routes\n")
+		return nil
+	}
+
+	text := string(content)
+	var routes strings.Builder
+	for _, source := range w.Sources {
+		name := "'webhooks.in." + source + "'"
+		if strings.Contains(text, name) {
+			continue
+		}
+		fmt.Fprintf(&routes, "Route::post('/webhooks/in/%s', \\App\\Http\\Controllers\\Api\\WebhookReceiverController::class)->defaults('source', '%s')->name(%s);\n", source, source, name)
+	}
+	if routes.Len() == 0 {
+		return nil
+	}
+	return ui.WriteFile(path, []byte(text+"\n"+routes.String()), 0644)
+}
@@ -0,0 +1,689 @@
+package laravel
+
+import (
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type WebhooksSetup struct {
+	ProjectPath string
+	DryRun      bool
+}
+
+func NewWebhooksSetup(projectPath string, dryRun bool) *WebhooksSetup {
+	return &WebhooksSetup{ProjectPath: projectPath, DryRun: dryRun}
+}
+
+func (w *WebhooksSetup) Setup() error {
+	if w.DryRun {
+		ui.Printf("[Dry Run] Would create webhook subscriptions, signed delivery job, delivery log and replay API\n")
+		return nil
+	}
+
+	// DeliverWebhookJob extends BaseJob.
+	if err := requireFeatures(w.ProjectPath, "webhooks", "jobs"); err != nil {
+		return err
+	}
+
+	ui.Println("🪝 Setting up outgoing webhooks...")
+
+	steps := []func() error{
+		w.createConfig,
+		w.createMigrations,
+		w.createModels,
+		w.createContracts,
+		w.createDispatcher,
+		w.createDeliveryJob,
+		w.createServiceProvider,
+		w.createController,
+		w.registerRoutes,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if err := registerProvider(w.ProjectPath, "App\\Providers\\WebhookServiceProvider"); err != nil {
+		return err
+	}
+	return scheduleCommand(w.ProjectPath, "Schedule::command('model:prune', ['--model' => [\\App\\Models\\WebhookDelivery::class]])->daily();")
+}
+
+func (w *WebhooksSetup) createConfig() error {
+	content := `<?php
+
+return [
+
+    // Seconds to wait for a subscriber to answer.
+    'timeout' => (int) env('WEBHOOKS_TIMEOUT', 10),
+
+    // Attempts per delivery. Retries back off exponentially:
+    // backoff, backoff * 3, backoff * 9, ... seconds.
+    'tries' => (int) env('WEBHOOKS_TRIES', 6),
+    'backoff' => (int) env('WEBHOOKS_BACKOFF', 10),
+
+    'queue' => env('WEBHOOKS_QUEUE', 'webhooks'),
+
+    // Delivery log entries older than this are pruned daily.
+    'log_days' => (int) env('WEBHOOKS_LOG_DAYS', 30),
+
+    // Characters of each response body kept in the delivery log.
+    'response_limit' => 2000,
+
+    // Users with this role may manage endpoints and deliveries
+    // (manage-webhooks gate).
+    'admin_role' => env('WEBHOOKS_ADMIN_ROLE', 'admin'),
+
+];
+`
+	dir := filepath.Join(w.ProjectPath, "config")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "webhooks.php"), []byte(content), 0644)
+}
+
+func (w *WebhooksSetup) createMigrations() error {
+	endpoints := `<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('webhook_endpoints', function (Blueprint $table) {
+            $table->id();
+            $table->string('url');
+            $table->text('secret');
+            $table->json('events');
+            $table->string('description')->nullable();
+            $table->boolean('is_active')->default(true);
+            $table->timestamps();
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('webhook_endpoints');
+    }
+};
+`
+	deliveries := `<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('webhook_deliveries', function (Blueprint $table) {
+            $table->id();
+            $table->uuid('uuid')->unique();
+            $table->foreignId('webhook_endpoint_id')->constrained()->cascadeOnDelete();
+            $table->string('event');
+            $table->json('payload');
+            $table->string('status')->default('pending');
+            $table->unsignedInteger('attempts')->default(0);
+            $table->unsignedSmallInteger('response_status')->nullable();
+            $table->text('response_body')->nullable();
+            $table->text('error')->nullable();
+            $table->unsignedInteger('duration_ms')->nullable();
+            $table->timestamp('delivered_at')->nullable();
+            $table->foreignId('replay_of_id')->nullable()->constrained('webhook_deliveries')->nullOnDelete();
+            $table->timestamps();
+
+            $table->index(['webhook_endpoint_id', 'created_at']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('webhook_deliveries');
+    }
+};
+`
+	dir := filepath.Join(w.ProjectPath, "database/migrations")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(w.ProjectPath, migrationFile(100, "create_webhook_endpoints_table")), []byte(endpoints), 0644); err != nil {
+		return err
+	}
+	return ui.WriteFile(filepath.Join(w.ProjectPath, migrationFile(101, "create_webhook_deliveries_table")), []byte(deliveries), 0644)
+}
+
+func (w *WebhooksSetup) createModels() error {
+	endpoint := `<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\HasMany;
+use Illuminate\Support\Str;
+
+class WebhookEndpoint extends Model
+{
+    protected $fillable = ['url', 'secret', 'events', 'description', 'is_active'];
+
+    protected $hidden = ['secret'];
+
+    protected function casts(): array
+    {
+        return [
+            'secret' => 'encrypted',
+            'events' => 'array',
+            'is_active' => 'boolean',
+        ];
+    }
+
+    protected static function booted(): void
+    {
+        static::creating(function (WebhookEndpoint $endpoint) {
+            $endpoint->secret ??= static::generateSecret();
+        });
+    }
+
+    public static function generateSecret(): string
+    {
+        return 'whsec_' . Str::random(40);
+    }
+
+    public function deliveries(): HasMany
+    {
+        return $this->hasMany(WebhookDelivery::class);
+    }
+
+    /**
+     * Whether the endpoint wants event. "*" subscribes to everything and
+     * "order.*" to every event starting with "order.".
+     */
+    public function subscribedTo(string $event): bool
+    {
+        foreach ($this->events ?? [] as $pattern) {
+            if (Str::is($pattern, $event)) {
+                return true;
+            }
+        }
+
+        return false;
+    }
+}
+`
+	delivery := `<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Builder;
+use Illuminate\Database\Eloquent\MassPrunable;
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+use Illuminate\Support\Str;
+
+class WebhookDelivery extends Model
+{
+    use MassPrunable;
+
+    public const PENDING = 'pending';
+    public const RETRYING = 'retrying';
+    public const SUCCEEDED = 'succeeded';
+    public const FAILED = 'failed';
+
+    protected $fillable = [
+        'webhook_endpoint_id', 'event', 'payload', 'status', 'attempts',
+        'response_status', 'response_body', 'error', 'duration_ms', 'delivered_at', 'replay_of_id',
+    ];
+
+    protected function casts(): array
+    {
+        return [
+            'payload' => 'array',
+            'delivered_at' => 'datetime',
+        ];
+    }
+
+    protected static function booted(): void
+    {
+        static::creating(function (WebhookDelivery $delivery) {
+            $delivery->uuid ??= (string) Str::uuid();
+        });
+    }
+
+    public function endpoint(): BelongsTo
+    {
+        return $this->belongsTo(WebhookEndpoint::class, 'webhook_endpoint_id');
+    }
+
+    public function replayOf(): BelongsTo
+    {
+        return $this->belongsTo(self::class, 'replay_of_id');
+    }
+
+    /**
+     * Delivery log entries older than webhooks.log_days, for model:prune.
+     */
+    public function prunable(): Builder
+    {
+        return static::where('created_at', '<', now()->subDays(config('webhooks.log_days', 30)));
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Models")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "WebhookEndpoint.php"), []byte(endpoint), 0644); err != nil {
+		return err
+	}
+	return ui.WriteFile(filepath.Join(dir, "WebhookDelivery.php"), []byte(delivery), 0644)
+}
+
+func (w *WebhooksSetup) createContracts() error {
+	contract := `<?php
+
+namespace App\Webhooks;
+
+/**
+ * Marks an event as webhook-broadcastable: when it is dispatched, every
+ * active endpoint subscribed to webhookEvent() receives webhookPayload().
+ * Events extending BaseEvent get both methods from BroadcastsWebhook:
+ *
+ *   class OrderShipped extends BaseEvent implements ShouldBroadcastWebhook
+ *   {
+ *       use BroadcastsWebhook;
+ *   }
+ */
+interface ShouldBroadcastWebhook
+{
+    /**
+     * The event name subscribers filter on, e.g. "order.shipped".
+     */
+    public function webhookEvent(): string;
+
+    /**
+     * The "data" member of the delivered body.
+     */
+    public function webhookPayload(): array;
+}
+`
+	trait := `<?php
+
+namespace App\Webhooks;
+
+use Illuminate\Support\Str;
+
+/**
+ * Defaults for ShouldBroadcastWebhook on events extending BaseEvent: the
+ * name is the class in dot case (OrderShipped => "order.shipped") and the
+ * payload is getEventData().
+ */
+trait BroadcastsWebhook
+{
+    public function webhookEvent(): string
+    {
+        return Str::of($this->getEventName())->snake('.')->toString();
+    }
+
+    public function webhookPayload(): array
+    {
+        return $this->getEventData();
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Webhooks")
+	os.MkdirAll(dir, 0755)
+	if err := ui.WriteFile(filepath.Join(dir, "ShouldBroadcastWebhook.php"), []byte(contract), 0644); err != nil {
+		return err
+	}
+	return ui.WriteFile(filepath.Join(dir, "BroadcastsWebhook.php"), []byte(trait), 0644)
+}
+
+func (w *WebhooksSetup) createDispatcher() error {
+	content := `<?php
+
+namespace App\Webhooks;
+
+use App\Jobs\DeliverWebhookJob;
+use App\Models\WebhookDelivery;
+use App\Models\WebhookEndpoint;
+
+class WebhookDispatcher
+{
+    /**
+     * Queue one delivery per active endpoint subscribed to event.
+     *
+     * @return int the number of deliveries queued
+     */
+    public function dispatch(string $event, array $payload): int
+    {
+        $count = 0;
+
+        WebhookEndpoint::where('is_active', true)->each(function (WebhookEndpoint $endpoint) use ($event, $payload, &$count) {
+            if (! $endpoint->subscribedTo($event)) {
+                return;
+            }
+
+            $this->queue($endpoint->deliveries()->create([
+                'event' => $event,
+                'payload' => $payload,
+                'status' => WebhookDelivery::PENDING,
+            ]));
+            $count++;
+        });
+
+        return $count;
+    }
+
+    /**
+     * Send a logged delivery again as a new delivery with a fresh retry
+     * budget. The body keeps the original event id, so subscribers that
+     * deduplicate on it will ignore the replay.
+     */
+    public function replay(WebhookDelivery $delivery): WebhookDelivery
+    {
+        $replay = $delivery->endpoint->deliveries()->create([
+            'event' => $delivery->event,
+            'payload' => $delivery->payload,
+            'status' => WebhookDelivery::PENDING,
+            'replay_of_id' => $delivery->id,
+        ]);
+
+        $this->queue($replay);
+
+        return $replay;
+    }
+
+    protected function queue(WebhookDelivery $delivery): void
+    {
+        // Inside a transaction the delivery waits for the commit, so a
+        // rolled-back change sends nothing.
+        DeliverWebhookJob::dispatch($delivery)->onQueue(config('webhooks.queue', 'webhooks'))->afterCommit();
+    }
+
+    /**
+     * HMAC-SHA256 over "{timestamp}.{body}". Subscribers recompute it with
+     * their endpoint secret and reject stale timestamps.
+     */
+    public static function sign(string $body, int $timestamp, string $secret): string
+    {
+        return 'sha256=' . hash_hmac('sha256', $timestamp . '.' . $body, $secret);
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Webhooks")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "WebhookDispatcher.php"), []byte(content), 0644)
+}
+
+func (w *WebhooksSetup) createDeliveryJob() error {
+	content := `<?php
+
+namespace App\Jobs;
+
+use App\Models\WebhookDelivery;
+use App\Webhooks\WebhookDispatcher;
+use Illuminate\Support\Facades\Http;
+use Illuminate\Support\Str;
+use RuntimeException;
+
+/**
+ * POSTs one signed delivery. Non-2xx answers and connection errors are
+ * retried with exponential backoff; every attempt is written to the
+ * delivery log.
+ *
+ * Headers: Webhook-Id (the original delivery for replays), Webhook-Event,
+ * Webhook-Timestamp and Webhook-Signature.
+ */
+class DeliverWebhookJob extends BaseJob
+{
+    public int $tries;
+
+    public function __construct(public WebhookDelivery $delivery)
+    {
+        $this->tries = config('webhooks.tries', 6);
+    }
+
+    /**
+     * Seconds before each retry: backoff, backoff * 3, backoff * 9, ...
+     */
+    public function backoff(): array
+    {
+        $base = config('webhooks.backoff', 10);
+
+        return array_map(fn (int $n) => $base * (3 ** $n), range(0, max(0, $this->tries - 2)));
+    }
+
+    public function handle(): void
+    {
+        $delivery = $this->delivery;
+        $endpoint = $delivery->endpoint;
+
+        if (! $endpoint->is_active) {
+            $delivery->update(['status' => WebhookDelivery::FAILED, 'error' => 'Endpoint is disabled']);
+
+            return;
+        }
+
+        $id = $delivery->replayOf?->uuid ?? $delivery->uuid;
+        $timestamp = now()->getTimestamp();
+        $body = json_encode([
+            'id' => $id,
+            'event' => $delivery->event,
+            'created_at' => $delivery->created_at->toIso8601String(),
+            'data' => $delivery->payload,
+        ], JSON_UNESCAPED_SLASHES | JSON_THROW_ON_ERROR);
+
+        $delivery->increment('attempts');
+        $started = microtime(true);
+
+        try {
+            $response = Http::timeout(config('webhooks.timeout', 10))
+                ->withHeaders([
+                    'Webhook-Id' => $id,
+                    'Webhook-Event' => $delivery->event,
+                    'Webhook-Timestamp' => $timestamp,
+                    'Webhook-Signature' => WebhookDispatcher::sign($body, $timestamp, $endpoint->secret),
+                    'User-Agent' => config('app.name') . '-Webhooks',
+                ])
+                ->withBody($body, 'application/json')
+                ->post($endpoint->url);
+        } catch (\Throwable $e) {
+            $this->record(WebhookDelivery::RETRYING, $started, error: $e->getMessage());
+
+            throw $e;
+        }
+
+        if ($response->successful()) {
+            $this->record(WebhookDelivery::SUCCEEDED, $started, $response->status(), $response->body());
+            $delivery->update(['delivered_at' => now()]);
+
+            return;
+        }
+
+        $this->record(WebhookDelivery::RETRYING, $started, $response->status(), $response->body());
+
+        throw new RuntimeException("Webhook endpoint answered HTTP {$response->status()}");
+    }
+
+    public function failed(\Throwable $exception): void
+    {
+        $this->delivery->update(['status' => WebhookDelivery::FAILED, 'error' => $exception->getMessage()]);
+
+        parent::failed($exception);
+    }
+
+    protected function record(string $status, float $started, ?int $responseStatus = null, ?string $responseBody = null, ?string $error = null): void
+    {
+        $this->delivery->update([
+            'status' => $status,
+            'response_status' => $responseStatus,
+            'response_body' => $responseBody === null ? null : Str::limit($responseBody, config('webhooks.response_limit', 2000)),
+            'error' => $error,
+            'duration_ms' => (int) round((microtime(true) - $started) * 1000),
+        ]);
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Jobs")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "DeliverWebhookJob.php"), []byte(content), 0644)
+}
+
+func (w *WebhooksSetup) createServiceProvider() error {
+	content := `<?php
+
+namespace App\Providers;
+
+use App\Webhooks\ShouldBroadcastWebhook;
+use App\Webhooks\WebhookDispatcher;
+use Illuminate\Support\Facades\Event;
+use Illuminate\Support\Facades\Gate;
+use Illuminate\Support\ServiceProvider;
+
+class WebhookServiceProvider extends ServiceProvider
+{
+    public function register(): void
+    {
+        $this->app->singleton(WebhookDispatcher::class);
+    }
+
+    /**
+     * Every dispatched event implementing ShouldBroadcastWebhook is sent to
+     * its subscribers; no per-event listener is needed.
+     */
+    public function boot(): void
+    {
+        Event::listen('*', function (string $name, array $payload) {
+            $event = $payload[0] ?? null;
+
+            if ($event instanceof ShouldBroadcastWebhook) {
+                $this->app->make(WebhookDispatcher::class)->dispatch($event->webhookEvent(), $event->webhookPayload());
+            }
+        });
+
+        // Who may manage endpoints and read deliveries; adjust to your
+        // authorization rules.
+        Gate::define('manage-webhooks', fn ($user) => method_exists($user, 'hasRole')
+            && $user->hasRole(config('webhooks.admin_role', 'admin')));
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Providers")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "WebhookServiceProvider.php"), []byte(content), 0644)
+}
+
+func (w *WebhooksSetup) createController() error {
+	content := `<?php
+
+namespace App\Http\Controllers\Api;
+
+use App\Http\Controllers\Controller;
+use App\Models\WebhookDelivery;
+use App\Models\WebhookEndpoint;
+use App\Traits\ApiResponse;
+use App\Webhooks\WebhookDispatcher;
+use Illuminate\Http\Request;
+
+class WebhookEndpointController extends Controller
+{
+    use ApiResponse;
+
+    public function index()
+    {
+        return $this->paginated(WebhookEndpoint::latest()->paginate());
+    }
+
+    /**
+     * The secret is only returned here and by rotateSecret().
+     */
+    public function store(Request $request)
+    {
+        $endpoint = WebhookEndpoint::create($this->validated($request));
+
+        return $this->created($endpoint->makeVisible('secret'), 'Webhook endpoint created');
+    }
+
+    public function show(WebhookEndpoint $endpoint)
+    {
+        return $this->success($endpoint);
+    }
+
+    public function update(Request $request, WebhookEndpoint $endpoint)
+    {
+        $endpoint->update($this->validated($request, partial: true));
+
+        return $this->success($endpoint, 'Webhook endpoint updated');
+    }
+
+    public function destroy(WebhookEndpoint $endpoint)
+    {
+        $endpoint->delete();
+
+        return $this->noContent();
+    }
+
+    public function rotateSecret(WebhookEndpoint $endpoint)
+    {
+        $endpoint->update(['secret' => WebhookEndpoint::generateSecret()]);
+
+        return $this->success($endpoint->makeVisible('secret'), 'Webhook secret rotated');
+    }
+
+    public function deliveries(WebhookEndpoint $endpoint)
+    {
+        return $this->paginated($endpoint->deliveries()->latest()->paginate());
+    }
+
+    public function replay(WebhookDelivery $delivery, WebhookDispatcher $dispatcher)
+    {
+        return $this->created($dispatcher->replay($delivery), 'Webhook delivery queued again');
+    }
+
+    protected function validated(Request $request, bool $partial = false): array
+    {
+        $required = $partial ? 'sometimes' : 'required';
+
+        return $request->validate([
+            'url' => [$required, 'url:https,http', 'max:2048'],
+            'events' => [$required, 'array', 'min:1'],
+            'events.*' => ['string', 'max:255'],
+            'description' => ['nullable', 'string', 'max:255'],
+            'is_active' => ['sometimes', 'boolean'],
+        ]);
+    }
+}
+`
+	dir := filepath.Join(w.ProjectPath, "app/Http/Controllers/Api")
+	os.MkdirAll(dir, 0755)
+	return ui.WriteFile(filepath.Join(dir, "WebhookEndpointController.php"), []byte(content), 0644)
+}
+
+func (w *WebhooksSetup) registerRoutes() error {
+	path := filepath.Join(w.ProjectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ui.Warnf("⚠️ routes/api.php not found, skipping webhook routes\n")
+		return nil
+	}
+
+	// Routes from before the manage-webhooks gate only required a token.
+	ungated := "Route::middleware('auth:sanctum')->prefix('webhooks')"
+	gated := "Route::middleware(['auth:sanctum', 'can:manage-webhooks'])->prefix('webhooks')"
+	if strings.Contains(string(content), ungated) {
+		return ui.WriteFile(path, []byte(strings.Replace(string(content), ungated, gated, 1)), 0644)
+	}
+	if strings.Contains(string(content), "WebhookEndpointController") {
+		return nil
+	}
+
+	routes := `
+Route::middleware(['auth:sanctum', 'can:manage-webhooks'])->prefix('webhooks')->group(function () {
+    Route::apiResource('endpoints', \App\Http\Controllers\Api\WebhookEndpointController::class);
+    Route::post('/endpoints/{endpoint}/rotate-secret', [\App\Http\Controllers\Api\WebhookEndpointController::class, 'rotateSecret']);
+    Route::get('/endpoints/{endpoint}/deliveries', [\App\Http\Controllers\Api\WebhookEndpointController::class, 'deliveries']);
+    Route::post('/deliveries/{delivery}/replay', [\App\Http\Controllers\Api\WebhookEndpointController::class, 'replay']);
+});
+`
+	return ui.WriteFile(path, []byte(string(content)+routes), 0644)
+}
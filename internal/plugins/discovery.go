@@ -0,0 +1,174 @@
+package plugins
+
+import (
+	"fmt"
+	"io"
+	"laravelboot/internal/config"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const pluginPrefix = "laravelboot-"
+
+// UserDir holds plugins installed with "laravelboot plugin install".
+func UserDir() string {
+	return filepath.Join(config.HomeDir(), "plugins")
+}
+
+// ProjectDir holds plugins committed alongside a project.
+func ProjectDir(projectPath string) string {
+	return filepath.Join(projectPath, ".laravelboot", "plugins")
+}
+
+// PluginName strips the directory, extension and "laravelboot-" prefix, so
+// ~/.laravelboot/plugins/laravelboot-billing.sh is called "billing".
+func PluginName(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.TrimPrefix(name, pluginPrefix)
+}
+
+// Discover returns the plugins listed in the config. Installing a plugin
+// does not enable it: a project opts in by naming it. Config entries are
+// plugin names (looked up in the config dir's .laravelboot/plugins, then
+// the user plugin dir) or paths relative to configDir, the directory
+// holding .laravelboot.yaml.
+func Discover(conf *config.Config, configDir string, dryRun bool) ([]Plugin, error) {
+	if conf == nil {
+		return nil, nil
+	}
+
+	var found []Plugin
+	seen := map[string]bool{}
+	for _, entry := range conf.Plugins {
+		path, err := Resolve(entry, configDir)
+		if err != nil {
+			return nil, err
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] {
+			continue
+		}
+		seen[abs] = true
+		found = append(found, NewExternalPlugin(abs, dryRun))
+	}
+	return found, nil
+}
+
+// Resolve finds the executable for a plugin name or path.
+func Resolve(entry, configDir string) (string, error) {
+	if strings.ContainsAny(entry, `/\`) || strings.HasPrefix(entry, ".") {
+		path := entry
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(configDir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("plugin %s not found: %v", entry, err)
+		}
+		return path, nil
+	}
+
+	for _, dir := range []string{ProjectDir(configDir), UserDir()} {
+		paths, err := executables(dir)
+		if err != nil {
+			return "", err
+		}
+		for _, path := range paths {
+			if PluginName(path) == entry {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("plugin %s is not installed (see 'laravelboot plugin list')", entry)
+}
+
+// executables lists executable files in dir; a missing dir is not an error.
+func executables(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Installed lists the plugins in the user plugin dir.
+func Installed() ([]string, error) {
+	return executables(UserDir())
+}
+
+// InstallPlugin copies a local executable or downloads one over HTTP(S)
+// into the user plugin dir.
+func InstallPlugin(source string) (string, error) {
+	if err := os.MkdirAll(UserDir(), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", UserDir(), err)
+	}
+
+	var reader io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return "", fmt.Errorf("failed to download plugin: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", fmt.Errorf("failed to download plugin: %s", resp.Status)
+		}
+		reader = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", fmt.Errorf("failed to open plugin: %v", err)
+		}
+		reader = f
+	}
+	defer reader.Close()
+
+	base := filepath.Base(strings.SplitN(source, "?", 2)[0])
+	if !strings.HasPrefix(base, pluginPrefix) {
+		base = pluginPrefix + base
+	}
+	dest := filepath.Join(UserDir(), base)
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", dest, err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, reader); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", dest, err)
+	}
+	return dest, nil
+}
+
+// RemovePlugin deletes an installed plugin by name.
+func RemovePlugin(name string) error {
+	paths, err := Installed()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if PluginName(path) == name {
+			return os.Remove(path)
+		}
+	}
+	return fmt.Errorf("plugin %s is not installed", name)
+}
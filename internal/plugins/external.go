@@ -0,0 +1,280 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProtocolVersion is sent with every request so plugins can reject
+// payloads they do not understand.
+const ProtocolVersion = 1
+
+const pluginTimeout = 10 * time.Minute
+
+// Request is written as JSON to an external plugin's stdin. Command is one
+// of describe, install, hook (with Event), feature or remove (with Feature).
+type Request struct {
+	Protocol    int                    `json:"protocol"`
+	Command     string                 `json:"command"`
+	ProjectPath string                 `json:"project_path"`
+	DryRun      bool                   `json:"dry_run"`
+	Config      *config.Config         `json:"config"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+	Event       *Event                 `json:"event,omitempty"`
+	Feature     string                 `json:"feature,omitempty"`
+}
+
+// Response is read as JSON from an external plugin's stdout.
+type Response struct {
+	Files    []FileOperation `json:"files"`
+	Messages []string        `json:"messages"`
+	Error    string          `json:"error"`
+	Manifest *Manifest       `json:"manifest,omitempty"`
+}
+
+// Manifest is returned for the describe command. Describe must not have
+// side effects; plugins that do not understand it may return an empty
+// response and are treated as install-only. Capabilities lists optional
+// commands the plugin handles; only "remove" is defined.
+type Manifest struct {
+	Hooks        []string              `json:"hooks"`
+	Features     []FeatureSpec         `json:"features"`
+	Config       map[string]OptionSpec `json:"config"`
+	Capabilities []string              `json:"capabilities"`
+}
+
+// CapabilityRemove marks plugins that undo their features on "remove".
+const CapabilityRemove = "remove"
+
+// FileOperation describes a change to a file inside the project. Paths are
+// relative to the project root; Action is create (default), append or delete.
+type FileOperation struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Action  string `json:"action"`
+	Mode    string `json:"mode"`
+}
+
+// ExternalPlugin runs an executable that speaks the JSON-over-stdio protocol.
+type ExternalPlugin struct {
+	Path   string
+	DryRun bool
+
+	manifest *Manifest
+}
+
+func NewExternalPlugin(path string, dryRun bool) *ExternalPlugin {
+	return &ExternalPlugin{Path: path, DryRun: dryRun}
+}
+
+func (p *ExternalPlugin) Name() string {
+	return PluginName(p.Path)
+}
+
+func (p *ExternalPlugin) Install(conf *config.Config, projectPath string) error {
+	return p.run(p.request("install", conf, projectPath))
+}
+
+// Describe asks the plugin for its manifest once and caches the answer.
+func (p *ExternalPlugin) Describe() *Manifest {
+	if p.manifest != nil {
+		return p.manifest
+	}
+	p.manifest = &Manifest{}
+	resp, err := p.call(p.request("describe", nil, ""))
+	if err != nil {
+		ui.Warnf("⚠️ Warning: %v (treating it as install-only)\n", err)
+	} else if resp.Manifest != nil {
+		p.manifest = resp.Manifest
+	}
+	return p.manifest
+}
+
+func (p *ExternalPlugin) Hooks() []string {
+	return p.Describe().Hooks
+}
+
+func (p *ExternalPlugin) OnEvent(e Event) error {
+	req := p.request("hook", e.Config, e.ProjectPath)
+	req.Event = &e
+	return p.run(req)
+}
+
+func (p *ExternalPlugin) Features() []FeatureSpec {
+	return p.Describe().Features
+}
+
+func (p *ExternalPlugin) RunFeature(name string, conf *config.Config, projectPath string) error {
+	req := p.request("feature", conf, projectPath)
+	req.Feature = name
+	return p.run(req)
+}
+
+// Removable reports whether the manifest declares the remove capability.
+func (p *ExternalPlugin) Removable() bool {
+	for _, c := range p.Describe().Capabilities {
+		if c == CapabilityRemove {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ExternalPlugin) RemoveFeature(name string, conf *config.Config, projectPath string) error {
+	req := p.request("remove", conf, projectPath)
+	req.Feature = name
+	return p.run(req)
+}
+
+func (p *ExternalPlugin) ConfigSchema() map[string]OptionSpec {
+	return p.Describe().Config
+}
+
+func (p *ExternalPlugin) request(command string, conf *config.Config, projectPath string) Request {
+	req := Request{
+		Protocol:    ProtocolVersion,
+		Command:     command,
+		ProjectPath: projectPath,
+		DryRun:      p.DryRun,
+		Config:      conf,
+	}
+	if conf != nil {
+		req.Options = conf.PluginConfig[p.Name()]
+	}
+	return req
+}
+
+func (p *ExternalPlugin) run(req Request) error {
+	resp, err := p.call(req)
+	if err != nil {
+		return err
+	}
+	return p.apply(req.ProjectPath, resp)
+}
+
+func (p *ExternalPlugin) call(req Request) (*Response, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path)
+	if info, err := os.Stat(req.ProjectPath); err == nil && info.IsDir() {
+		cmd.Dir = req.ProjectPath
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "LARAVELBOOT_PLUGIN_PROTOCOL="+strconv.Itoa(ProtocolVersion))
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %v\nOutput: %s", p.Name(), err, stderr.String())
+	}
+
+	var resp Response
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return &resp, nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s returned invalid JSON: %v", p.Name(), err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", p.Name(), resp.Error)
+	}
+	return &resp, nil
+}
+
+func (p *ExternalPlugin) apply(projectPath string, resp *Response) error {
+	for _, msg := range resp.Messages {
+		ui.Printf("   %s\n", msg)
+	}
+
+	// Validate every path up front so a bad entry leaves the project untouched.
+	paths := make([]string, len(resp.Files))
+	for i, op := range resp.Files {
+		path, err := projectFile(projectPath, op.Path)
+		if err != nil {
+			return fmt.Errorf("plugin %s: %v", p.Name(), err)
+		}
+		paths[i] = path
+	}
+
+	for i, op := range resp.Files {
+		path := paths[i]
+
+		action := op.Action
+		if action == "" {
+			action = "create"
+		}
+		if p.DryRun {
+			ui.Printf("[Dry Run] Plugin %s would %s %s\n", p.Name(), action, path)
+			continue
+		}
+
+		mode := os.FileMode(0644)
+		if op.Mode != "" {
+			parsed, err := strconv.ParseUint(op.Mode, 8, 32)
+			if err != nil {
+				return fmt.Errorf("plugin %s: invalid mode %q for %s", p.Name(), op.Mode, op.Path)
+			}
+			mode = os.FileMode(parsed)
+		}
+
+		switch action {
+		case "create":
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := ui.WriteFile(path, []byte(op.Content), mode); err != nil {
+				return err
+			}
+		case "append":
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, mode)
+			if err != nil {
+				return err
+			}
+			_, err = f.WriteString(op.Content)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		case "delete":
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		default:
+			return fmt.Errorf("plugin %s: unknown file action %q for %s", p.Name(), op.Action, op.Path)
+		}
+	}
+	return nil
+}
+
+// projectFile resolves a plugin-supplied path and refuses anything that
+// would escape the project directory.
+func projectFile(projectPath, rel string) (string, error) {
+	if rel == "" || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("file path must be relative to the project: %q", rel)
+	}
+	path := filepath.Join(projectPath, rel)
+	if r, err := filepath.Rel(projectPath, path); err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file path escapes the project: %q", rel)
+	}
+	return path, nil
+}
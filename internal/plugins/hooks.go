@@ -0,0 +1,130 @@
+package plugins
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"sort"
+	"strings"
+)
+
+// Lifecycle events delivered to HookPlugin.OnEvent. Step events carry the
+// step name (e.g. "api", "feature:roles", "infra:docker").
+const (
+	EventBeforeCreate = "before_create"
+	EventAfterCreate  = "after_create"
+	EventBeforeStep   = "before_step"
+	EventAfterStep    = "after_step"
+	EventFailure      = "on_failure"
+	EventAdd          = "on_add"
+	EventRemove       = "on_remove"
+)
+
+// Events lists every event a plugin may subscribe to.
+var Events = []string{
+	EventBeforeCreate,
+	EventAfterCreate,
+	EventBeforeStep,
+	EventAfterStep,
+	EventFailure,
+	EventAdd,
+	EventRemove,
+}
+
+type Event struct {
+	Name        string         `json:"name"`
+	Step        string         `json:"step,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	ProjectPath string         `json:"-"`
+	Config      *config.Config `json:"-"`
+}
+
+// OptionSpec describes one key of a plugin's plugin_config section.
+// Type is string, int, bool or list.
+type OptionSpec struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+	Required    bool        `json:"required"`
+	Enum        []string    `json:"enum"`
+}
+
+// validateSection checks values against schema and returns a copy with
+// defaults filled in.
+func validateSection(plugin string, schema map[string]OptionSpec, values map[string]interface{}) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for key, value := range values {
+		spec, ok := schema[key]
+		if !ok {
+			return nil, fmt.Errorf("plugin_config.%s.%s: unknown option (expected one of %s)", plugin, key, strings.Join(optionNames(schema), ", "))
+		}
+		if err := checkOption(spec, value); err != nil {
+			return nil, fmt.Errorf("plugin_config.%s.%s: %v", plugin, key, err)
+		}
+		out[key] = value
+	}
+
+	for key, spec := range schema {
+		if _, ok := out[key]; ok {
+			continue
+		}
+		if spec.Required {
+			return nil, fmt.Errorf("plugin_config.%s.%s is required", plugin, key)
+		}
+		if spec.Default != nil {
+			out[key] = spec.Default
+		}
+	}
+	return out, nil
+}
+
+func checkOption(spec OptionSpec, value interface{}) error {
+	switch spec.Type {
+	case "", "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %v", value)
+		}
+		if len(spec.Enum) > 0 && !contains(spec.Enum, s) {
+			return fmt.Errorf("%q is not one of %s", s, strings.Join(spec.Enum, ", "))
+		}
+	case "int":
+		switch v := value.(type) {
+		case int, int64:
+		case float64:
+			if v != float64(int64(v)) {
+				return fmt.Errorf("expected an integer, got %v", value)
+			}
+		default:
+			return fmt.Errorf("expected an integer, got %v", value)
+		}
+	case "bool":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected true or false, got %v", value)
+		}
+	case "list":
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("expected a list, got %v", value)
+		}
+	default:
+		return fmt.Errorf("plugin declares unsupported option type %q", spec.Type)
+	}
+	return nil
+}
+
+func optionNames(schema map[string]OptionSpec) []string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
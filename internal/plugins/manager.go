@@ -3,6 +3,8 @@ package plugins
 import (
 	"fmt"
 	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"sort"
 )
 
 type Plugin interface {
@@ -10,6 +12,47 @@ type Plugin interface {
 	Install(conf *config.Config, projectPath string) error
 }
 
+// HookPlugin is implemented by plugins that want lifecycle callbacks.
+type HookPlugin interface {
+	Plugin
+	Hooks() []string
+	OnEvent(e Event) error
+}
+
+// FeatureProvider is implemented by plugins that contribute named features
+// usable with "laravelboot add <name>" and in the config feature lists.
+type FeatureProvider interface {
+	Plugin
+	Features() []FeatureSpec
+	RunFeature(name string, conf *config.Config, projectPath string) error
+}
+
+// SchemaProvider is implemented by plugins that accept options from their
+// section of plugin_config in .laravelboot.yaml.
+type SchemaProvider interface {
+	Plugin
+	ConfigSchema() map[string]OptionSpec
+}
+
+// FeatureRemover is implemented by plugins whose features can be undone by
+// "laravelboot apply". Removable reports whether this plugin actually
+// supports removal, since the protocol lets every plugin receive a remove
+// command.
+type FeatureRemover interface {
+	FeatureProvider
+	Removable() bool
+	RemoveFeature(name string, conf *config.Config, projectPath string) error
+}
+
+// FeatureSpec describes a plugin feature. Marker is a project-relative
+// file whose presence means the feature is installed; without one apply
+// cannot detect the feature and re-runs it on every apply.
+type FeatureSpec struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Marker      string `json:"marker,omitempty"`
+}
+
 type PluginManager struct {
 	plugins []Plugin
 }
@@ -20,16 +63,200 @@ func NewPluginManager() *PluginManager {
 	}
 }
 
+// LoadPluginManager registers every discovered plugin and validates their
+// config sections.
+func LoadPluginManager(conf *config.Config, configDir string, dryRun bool) (*PluginManager, error) {
+	m := NewPluginManager()
+	found, err := Discover(conf, configDir, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range found {
+		m.Register(p)
+	}
+	if conf != nil {
+		if err := m.ApplyConfig(conf); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
 func (m *PluginManager) Register(p Plugin) {
 	m.plugins = append(m.plugins, p)
 }
 
+func (m *PluginManager) Plugins() []Plugin {
+	return m.plugins
+}
+
 func (m *PluginManager) RunAll(conf *config.Config, projectPath string) error {
 	for _, p := range m.plugins {
-		fmt.Printf("🔌 Running plugin: %s\n", p.Name())
+		ui.Printf("🔌 Running plugin: %s\n", p.Name())
 		if err := p.Install(conf, projectPath); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// Emit delivers an event to every plugin subscribed to it. A failing hook
+// aborts the run unless the event is itself a failure notification.
+func (m *PluginManager) Emit(e Event) error {
+	for _, p := range m.plugins {
+		h, ok := p.(HookPlugin)
+		if !ok || !subscribed(h, e.Name) {
+			continue
+		}
+		if err := h.OnEvent(e); err != nil {
+			if e.Name == EventFailure {
+				ui.Warnf("⚠️ Warning: plugin %s failed handling %s: %v\n", p.Name(), e.Name, err)
+				continue
+			}
+			return fmt.Errorf("plugin %s %s hook: %v", p.Name(), e.Name, err)
+		}
+	}
+	return nil
+}
+
+// RunStep wraps a step with before_step, after_step and on_failure events.
+func (m *PluginManager) RunStep(step string, conf *config.Config, projectPath string, fn func() error) error {
+	base := Event{Step: step, ProjectPath: projectPath, Config: conf}
+
+	before := base
+	before.Name = EventBeforeStep
+	if err := m.Emit(before); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		failure := base
+		failure.Name = EventFailure
+		failure.Error = err.Error()
+		m.Emit(failure)
+		return err
+	}
+
+	after := base
+	after.Name = EventAfterStep
+	return m.Emit(after)
+}
+
+// Features maps every plugin-provided feature name to its provider.
+func (m *PluginManager) Features() map[string]FeatureProvider {
+	features := map[string]FeatureProvider{}
+	for _, p := range m.plugins {
+		if fp, ok := p.(FeatureProvider); ok {
+			for _, f := range fp.Features() {
+				if _, exists := features[f.Name]; !exists {
+					features[f.Name] = fp
+				}
+			}
+		}
+	}
+	return features
+}
+
+// FeatureSpecs lists plugin-provided features sorted by name.
+func (m *PluginManager) FeatureSpecs() []FeatureSpec {
+	var specs []FeatureSpec
+	for _, p := range m.plugins {
+		if fp, ok := p.(FeatureProvider); ok {
+			specs = append(specs, fp.Features()...)
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs
+}
+
+// FeatureNames lists plugin-provided feature names for config validation.
+func (m *PluginManager) FeatureNames() []string {
+	var names []string
+	for _, f := range m.FeatureSpecs() {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func (m *PluginManager) HasFeature(name string) bool {
+	_, ok := m.Features()[name]
+	return ok
+}
+
+func (m *PluginManager) RunFeature(name string, conf *config.Config, projectPath string) error {
+	fp, ok := m.Features()[name]
+	if !ok {
+		return fmt.Errorf("no plugin provides feature: %s", name)
+	}
+	ui.Printf("🔌 Running %s from plugin %s\n", name, fp.Name())
+	return fp.RunFeature(name, conf, projectPath)
+}
+
+// FeatureSpec returns the spec of a plugin-provided feature.
+func (m *PluginManager) FeatureSpec(name string) (FeatureSpec, bool) {
+	for _, f := range m.FeatureSpecs() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FeatureSpec{}, false
+}
+
+// CanRemove reports whether the plugin providing a feature supports removal.
+func (m *PluginManager) CanRemove(name string) bool {
+	fr, ok := m.Features()[name].(FeatureRemover)
+	return ok && fr.Removable()
+}
+
+func (m *PluginManager) RemoveFeature(name string, conf *config.Config, projectPath string) error {
+	fr, ok := m.Features()[name].(FeatureRemover)
+	if !ok || !fr.Removable() {
+		return fmt.Errorf("no plugin can remove feature: %s", name)
+	}
+	ui.Printf("🔌 Removing %s with plugin %s\n", name, fr.Name())
+	return fr.RemoveFeature(name, conf, projectPath)
+}
+
+// ApplyConfig validates each plugin_config section against the schema its
+// plugin declares and fills in defaults. Sections for unknown plugins are
+// rejected so typos do not go unnoticed.
+func (m *PluginManager) ApplyConfig(conf *config.Config) error {
+	schemas := map[string]map[string]OptionSpec{}
+	for _, p := range m.plugins {
+		if sp, ok := p.(SchemaProvider); ok {
+			schemas[p.Name()] = sp.ConfigSchema()
+		} else {
+			schemas[p.Name()] = nil
+		}
+	}
+
+	for name := range conf.PluginConfig {
+		if _, ok := schemas[name]; !ok {
+			return fmt.Errorf("plugin_config.%s: no plugin named %q is installed", name, name)
+		}
+	}
+
+	for name, schema := range schemas {
+		if len(schema) == 0 {
+			continue
+		}
+		if conf.PluginConfig == nil {
+			conf.PluginConfig = map[string]map[string]interface{}{}
+		}
+		section, err := validateSection(name, schema, conf.PluginConfig[name])
+		if err != nil {
+			return err
+		}
+		conf.PluginConfig[name] = section
+	}
+	return nil
+}
+
+func subscribed(h HookPlugin, event string) bool {
+	for _, name := range h.Hooks() {
+		if name == event || name == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package presets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/ui"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var unsafeRef = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// parseGitRef splits git+<repo>//<file>#<ref>. The ref is required so a
+// preset never changes underneath a project.
+func parseGitRef(ref string) (repo, file, rev string, err error) {
+	rest := strings.TrimPrefix(ref, "git+")
+
+	hash := strings.LastIndex(rest, "#")
+	if hash < 0 || hash == len(rest)-1 {
+		return "", "", "", fmt.Errorf("git preset %s must be pinned to a ref, e.g. %s#v1.0.0", ref, ref)
+	}
+	rest, rev = rest[:hash], rest[hash+1:]
+
+	start := 0
+	if i := strings.Index(rest, "://"); i >= 0 {
+		start = i + 3
+	}
+	sep := strings.Index(rest[start:], "//")
+	if sep < 0 {
+		return "", "", "", fmt.Errorf("git preset %s must name a file after //, e.g. git+https://host/repo.git//presets/api.yaml#v1.0.0", ref)
+	}
+	repo, file = rest[:start+sep], rest[start+sep+2:]
+	if file == "" || filepath.IsAbs(file) || strings.HasPrefix(filepath.Clean(file), "..") {
+		return "", "", "", fmt.Errorf("git preset %s has an invalid file path %q", ref, file)
+	}
+	return repo, file, rev, nil
+}
+
+// fetchGit checks out the pinned ref into the preset cache (once) and
+// returns the path of the preset file inside it.
+func fetchGit(ref string) (string, error) {
+	repo, file, rev, err := parseGitRef(ref)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(repo))
+	dir := filepath.Join(config.HomeDir(), "cache", "presets", hex.EncodeToString(sum[:6])+"-"+unsafeRef.ReplaceAllString(rev, "_"))
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if _, err := exec.LookPath("git"); err != nil {
+			return "", fmt.Errorf("git is required for git presets: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			return "", err
+		}
+		tmp, err := os.MkdirTemp(filepath.Dir(dir), ".fetch-")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmp)
+
+		ui.Printf("📥 Fetching preset %s@%s\n", repo, rev)
+		steps := [][]string{
+			{"init", "--quiet"},
+			{"fetch", "--quiet", "--depth", "1", repo, rev},
+			{"checkout", "--quiet", "FETCH_HEAD"},
+		}
+		for _, args := range steps {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = tmp
+			if out, err := ui.Run(cmd); err != nil {
+				return "", fmt.Errorf("git %s failed: %v\nOutput: %s", args[0], err, out)
+			}
+		}
+		if err := os.Rename(tmp, dir); err != nil {
+			return "", err
+		}
+	}
+
+	path := filepath.Join(dir, file)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("git preset %s: %s not found at %s", ref, file, rev)
+	}
+	return path, nil
+}
@@ -0,0 +1,214 @@
+package presets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"laravelboot/internal/config"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Preset is a resolved preset. Config already has the extends chain applied.
+type Preset struct {
+	Name        string
+	Description string
+	Source      string // "built-in", a file path or a git reference
+	Extends     string
+	Config      *config.Config
+}
+
+// presetFile is the on-disk format: any .laravelboot.yaml key plus an
+// optional description and a preset to extend.
+type presetFile struct {
+	Description   string `yaml:"description"`
+	Extends       string `yaml:"extends"`
+	config.Config `yaml:",inline"`
+}
+
+// UserDir holds custom presets, one <name>.yaml per preset.
+func UserDir() string {
+	return filepath.Join(config.HomeDir(), "presets")
+}
+
+// Load resolves a preset reference:
+//
+//	saas                              built-in, or ~/.laravelboot/presets/saas.yaml
+//	./presets/api.yaml                a local file
+//	git+https://host/repo.git//presets/api.yaml#v1.2.0
+//	                                  a file in a git repository at a pinned ref
+func Load(ref string) (*Preset, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return load(ref, cwd, nil)
+}
+
+func load(ref, baseDir string, chain []string) (*Preset, error) {
+	switch {
+	case strings.HasPrefix(ref, "git+"):
+		path, err := fetchGit(ref)
+		if err != nil {
+			return nil, err
+		}
+		p, err := loadFile(path, chain)
+		if err != nil {
+			return nil, err
+		}
+		p.Source = ref
+		return p, nil
+	case isPath(ref):
+		path := ref
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		return loadFile(path, chain)
+	}
+
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(UserDir(), ref+ext)
+		// A user preset may shadow a built-in and still extend it.
+		if _, err := os.Stat(path); err == nil && !inChain(chain, path) {
+			return loadFile(path, chain)
+		}
+	}
+
+	if conf := builtinPreset(ref); conf != nil {
+		return &Preset{Name: ref, Description: descriptions[ref], Source: "built-in", Config: conf}, nil
+	}
+	return nil, unknownPreset(ref)
+}
+
+func loadFile(path string, chain []string) (*Preset, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if inChain(chain, path) {
+		return nil, fmt.Errorf("preset inheritance cycle: %s -> %s", strings.Join(chain, " -> "), path)
+	}
+	chain = append(chain, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset: %v", err)
+	}
+
+	var file presetFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	p := &Preset{
+		Name:        presetName(path),
+		Description: file.Description,
+		Source:      path,
+		Extends:     file.Extends,
+		Config:      &file.Config,
+	}
+	if file.Extends != "" {
+		parent, err := load(file.Extends, filepath.Dir(path), chain)
+		if err != nil {
+			return nil, fmt.Errorf("%s: extends %s: %v", path, file.Extends, err)
+		}
+		p.Config = config.Merge(parent.Config, &file.Config)
+	}
+	return p, nil
+}
+
+func inChain(chain []string, path string) bool {
+	for _, seen := range chain {
+		if seen == path {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the built-in presets followed by those in UserDir. A user
+// preset with a built-in name shadows it.
+func List() ([]*Preset, error) {
+	user, err := userPresets()
+	if err != nil {
+		return nil, err
+	}
+
+	shadowed := map[string]bool{}
+	var list []*Preset
+	for _, path := range user {
+		p, err := loadFile(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		shadowed[p.Name] = true
+		list = append(list, p)
+	}
+	for _, name := range Builtin {
+		if !shadowed[name] {
+			list = append(list, &Preset{Name: name, Description: descriptions[name], Source: "built-in", Config: builtinPreset(name)})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+func userPresets() ([]string, error) {
+	entries, err := os.ReadDir(UserDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			paths = append(paths, filepath.Join(UserDir(), e.Name()))
+		}
+	}
+	return paths, nil
+}
+
+func presetName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func isPath(ref string) bool {
+	ext := filepath.Ext(ref)
+	return strings.ContainsAny(ref, `/\`) || strings.HasPrefix(ref, ".") || ext == ".yaml" || ext == ".yml"
+}
+
+// Compose builds the effective project config in layers, each overriding
+// what it sets: the defaults, the preset, the config file, then CLI
+// overrides. A missing config file is not an error.
+func Compose(preset, configPath string, overrides *config.Config) (*config.Config, error) {
+	conf := config.DefaultConfig()
+	if preset != "" {
+		p, err := Load(preset)
+		if err != nil {
+			return nil, err
+		}
+		conf = config.Merge(conf, p.Config)
+	}
+
+	file, err := config.LoadConfig(configPath)
+	switch {
+	case err == nil:
+		conf = config.Merge(conf, file)
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	if overrides != nil {
+		conf = config.Merge(conf, overrides)
+	}
+	return conf, nil
+}
@@ -1,8 +1,42 @@
 package presets
 
-import "laravelboot/internal/config"
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"strings"
+)
 
-func GetPreset(name string) *config.Config {
+// Builtin lists the presets compiled into LaravelBoot.
+var Builtin = []string{"saas", "fintech", "enterprise", "all"}
+
+var descriptions = map[string]string{
+	"saas":       "Postgres + Sanctum API with roles, media, search and activity log",
+	"fintech":    "Passport-secured API with audit-friendly defaults",
+	"enterprise": "Every platform feature plus the enterprise stack",
+	"all":        "Everything, including tenancy",
+}
+
+// GetPreset resolves a preset name, file path or git reference to a
+// config with any extends chain applied.
+func GetPreset(name string) (*config.Config, error) {
+	p, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.Config, nil
+}
+
+func unknownPreset(name string) error {
+	var names []string
+	if list, err := List(); err == nil {
+		for _, p := range list {
+			names = append(names, p.Name)
+		}
+	}
+	return fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+func builtinPreset(name string) *config.Config {
 	switch name {
 	case "saas":
 		return &config.Config{
@@ -49,6 +83,6 @@ func GetPreset(name string) *config.Config {
 			Architecture: "domain-based",
 		}
 	default:
-		return config.DefaultConfig()
+		return nil
 	}
 }
@@ -0,0 +1,141 @@
+package project
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Resource struct {
+	Name   string
+	Model  string
+	Fields []ResourceField
+}
+
+type ResourceField struct {
+	Name     string
+	Type     string // integer, number, string, boolean, array, object, unknown
+	Ref      string // model of a nested Resource, e.g. "User" for new UserResource(...)
+	Optional bool
+}
+
+var (
+	resourceClassRe = regexp.MustCompile(`class\s+(\w+)\s+extends\s+\\?(?:[\w\\]*\\)?JsonResource`)
+	resourceFieldRe = regexp.MustCompile(`'(\w+)'\s*=>\s*(.+?),?\s*$`)
+	nestedRe        = regexp.MustCompile(`new\s+\\?(?:[\w\\]*\\)?(\w+)Resource\(`)
+	collectionRe    = regexp.MustCompile(`\\?(?:[\w\\]*\\)?(\w+)Resource::collection\(`)
+)
+
+// resourceDirs lists the places LaravelBoot and Laravel put API Resources.
+var resourceDirs = []string{
+	"app/Http/Resources",
+	"app/Domain",
+}
+
+// DiscoverResources parses JsonResource classes and the keys returned from
+// their toArray() method.
+func DiscoverResources(projectPath string) ([]Resource, error) {
+	var resources []Resource
+	seen := map[string]bool{}
+
+	for _, dir := range resourceDirs {
+		root := filepath.Join(projectPath, dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".php") {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if r, ok := parseResource(string(content)); ok && !seen[r.Name] {
+				seen[r.Name] = true
+				resources = append(resources, r)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources, nil
+}
+
+func parseResource(content string) (Resource, bool) {
+	m := resourceClassRe.FindStringSubmatch(content)
+	if m == nil {
+		return Resource{}, false
+	}
+
+	r := Resource{Name: m[1], Model: strings.TrimSuffix(m[1], "Resource")}
+	body := methodBody(content, "toArray")
+	start := strings.Index(body, "return [")
+	if start < 0 {
+		return r, true
+	}
+
+	depth := 0
+	for _, line := range strings.Split(body[start+len("return ["):], "\n") {
+		trimmed := strings.TrimSpace(line)
+		if depth == 0 && strings.HasPrefix(trimmed, "];") {
+			break
+		}
+		if depth == 0 {
+			if f := resourceFieldRe.FindStringSubmatch(trimmed); f != nil {
+				field := ResourceField{
+					Name:     f[1],
+					Type:     fieldType(f[1], f[2]),
+					Optional: strings.Contains(f[2], "$this->when"),
+				}
+				if n := nestedRe.FindStringSubmatch(f[2]); n != nil {
+					field.Type, field.Ref = "object", n[1]
+				} else if c := collectionRe.FindStringSubmatch(f[2]); c != nil {
+					field.Type, field.Ref = "array", c[1]
+				}
+				r.Fields = append(r.Fields, field)
+			}
+		}
+		depth += strings.Count(line, "[") + strings.Count(line, "(") - strings.Count(line, "]") - strings.Count(line, ")")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+	return r, true
+}
+
+// fieldType guesses a JSON type from the key name and the PHP expression.
+func fieldType(name, expr string) string {
+	switch {
+	case strings.Contains(expr, "(int)") || strings.Contains(expr, "intval("):
+		return "integer"
+	case strings.Contains(expr, "(float)") || strings.Contains(expr, "floatval("):
+		return "number"
+	case strings.Contains(expr, "(bool)") || strings.Contains(expr, "=== ") || strings.Contains(expr, "!== "):
+		return "boolean"
+	case strings.Contains(expr, "(string)") || strings.Contains(expr, "->toIso8601String()") || strings.Contains(expr, "->format("):
+		return "string"
+	case strings.Contains(expr, "::collection("):
+		return "array"
+	case name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_count"):
+		return "integer"
+	case strings.HasPrefix(name, "is_") || strings.HasPrefix(name, "has_") || strings.HasPrefix(name, "can_"):
+		return "boolean"
+	case strings.HasSuffix(name, "_at") || strings.Contains(name, "email") || strings.Contains(name, "name") ||
+		strings.Contains(name, "url") || name == "title" || name == "slug" || name == "description":
+		return "string"
+	case strings.HasSuffix(name, "s") && strings.Contains(expr, "whenLoaded"):
+		return "array"
+	}
+	return "unknown"
+}
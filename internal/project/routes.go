@@ -0,0 +1,296 @@
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Route struct {
+	Method     string
+	URI        string
+	Controller string
+	Action     string
+	Middleware []string
+	Fields     []Field
+}
+
+type Field struct {
+	Name  string
+	Rules string
+}
+
+// Authenticated reports whether the route sits behind an auth:* middleware.
+func (r Route) Authenticated() bool {
+	for _, m := range r.Middleware {
+		if strings.HasPrefix(m, "auth") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFileUpload reports whether the controller validates an uploaded file.
+func (r Route) HasFileUpload() bool {
+	for _, f := range r.Fields {
+		if strings.Contains(f.Rules, "file") || strings.Contains(f.Rules, "image") {
+			return true
+		}
+	}
+	return false
+}
+
+// Group names the folder a route belongs to: its controller when it has
+// one, otherwise the first path segment after the API prefix.
+func (r Route) Group(prefix string) string {
+	if r.Controller != "" {
+		return strings.TrimSuffix(r.Controller, "Controller")
+	}
+	path := strings.TrimPrefix(r.URI, "/"+prefix)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "root"
+	}
+	segment := strings.SplitN(path, "/", 2)[0]
+	if strings.HasPrefix(segment, "{") {
+		return "root"
+	}
+	return segment
+}
+
+// Name builds a readable request name like "FileController@upload".
+func (r Route) Name() string {
+	if r.Controller == "" {
+		return fmt.Sprintf("%s %s", r.Method, r.URI)
+	}
+	return fmt.Sprintf("%s@%s", r.Controller, r.Action)
+}
+
+var (
+	routeRe       = regexp.MustCompile(`Route::(get|post|put|patch|delete|options)\(\s*'([^']*)'\s*,(.*)`)
+	apiResourceRe = regexp.MustCompile(`Route::apiResource\(\s*'([^']*)'\s*,\s*\\?([\w\\]+)::class`)
+	actionRe      = regexp.MustCompile(`\[\s*\\?([\w\\]+)::class\s*,\s*'(\w+)'\s*\]`)
+	prefixRe      = regexp.MustCompile(`prefix\(\s*'([^']*)'\s*\)`)
+	middlewareRe  = regexp.MustCompile(`middleware\(\s*(\[[^\]]*\]|'[^']*')\s*\)`)
+	quotedRe      = regexp.MustCompile(`'([^']*)'`)
+	apiPrefixRe   = regexp.MustCompile(`apiPrefix:\s*'([^']*)'`)
+	fieldRe       = regexp.MustCompile(`'([\w.*]+)'\s*=>\s*'([^']*)'`)
+)
+
+type group struct {
+	depth      int
+	prefix     string
+	middleware []string
+}
+
+// APIPrefix returns the prefix configured in bootstrap/app.php, "api" by default.
+func APIPrefix(projectPath string) string {
+	content, err := os.ReadFile(filepath.Join(projectPath, "bootstrap/app.php"))
+	if err != nil {
+		return "api"
+	}
+	if m := apiPrefixRe.FindStringSubmatch(string(content)); m != nil {
+		return strings.Trim(m[1], "/")
+	}
+	return "api"
+}
+
+// DiscoverRoutes parses routes/api.php and the controllers it references.
+func DiscoverRoutes(projectPath string) ([]Route, error) {
+	path := filepath.Join(projectPath, "routes/api.php")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	prefix := APIPrefix(projectPath)
+	controllers := indexControllers(projectPath)
+
+	var routes []Route
+	var stack []group
+	depth := 0
+
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "*") {
+			continue
+		}
+
+		groupPrefix, groupMiddleware := currentGroup(stack)
+
+		if strings.Contains(trimmed, "->group(") {
+			g := group{depth: depth + 1, prefix: groupPrefix, middleware: groupMiddleware}
+			if m := prefixRe.FindStringSubmatch(trimmed); m != nil {
+				g.prefix = joinPath(groupPrefix, m[1])
+			}
+			g.middleware = append(append([]string{}, groupMiddleware...), parseMiddleware(trimmed)...)
+			stack = append(stack, g)
+		} else if m := apiResourceRe.FindStringSubmatch(trimmed); m != nil {
+			controller := baseName(m[2])
+			base := joinPath(groupPrefix, m[1])
+			param := "{" + singular(lastSegment(m[1])) + "}"
+			for _, r := range []struct{ method, uri, action string }{
+				{"GET", base, "index"},
+				{"POST", base, "store"},
+				{"GET", joinPath(base, param), "show"},
+				{"PUT", joinPath(base, param), "update"},
+				{"DELETE", joinPath(base, param), "destroy"},
+			} {
+				routes = append(routes, Route{
+					Method:     r.method,
+					URI:        joinPath("/"+prefix, r.uri),
+					Controller: controller,
+					Action:     r.action,
+					Middleware: groupMiddleware,
+					Fields:     controllerFields(controllers[controller], r.action),
+				})
+			}
+		} else if strings.HasPrefix(trimmed, "})->middleware(") && len(routes) > 0 {
+			// Closure routes chain their middleware after the closing brace.
+			last := &routes[len(routes)-1]
+			last.Middleware = append(last.Middleware, parseMiddleware(trimmed)...)
+		} else if m := routeRe.FindStringSubmatch(trimmed); m != nil {
+			route := Route{
+				Method:     strings.ToUpper(m[1]),
+				URI:        joinPath("/"+prefix, joinPath(groupPrefix, m[2])),
+				Middleware: append(append([]string{}, groupMiddleware...), parseMiddleware(m[3])...),
+			}
+			if a := actionRe.FindStringSubmatch(m[3]); a != nil {
+				route.Controller = baseName(a[1])
+				route.Action = a[2]
+				route.Fields = controllerFields(controllers[route.Controller], route.Action)
+			}
+			routes = append(routes, route)
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		for len(stack) > 0 && depth < stack[len(stack)-1].depth {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return routes, nil
+}
+
+func currentGroup(stack []group) (string, []string) {
+	if len(stack) == 0 {
+		return "", nil
+	}
+	top := stack[len(stack)-1]
+	return top.prefix, top.middleware
+}
+
+func parseMiddleware(s string) []string {
+	m := middlewareRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	var out []string
+	for _, q := range quotedRe.FindAllStringSubmatch(m[1], -1) {
+		out = append(out, q[1])
+	}
+	return out
+}
+
+// indexControllers maps controller class names to their file paths.
+func indexControllers(projectPath string) map[string]string {
+	index := map[string]string{}
+	root := filepath.Join(projectPath, "app/Http/Controllers")
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".php") {
+			return nil
+		}
+		name := strings.TrimSuffix(d.Name(), ".php")
+		if _, ok := index[name]; !ok {
+			index[name] = path
+		}
+		return nil
+	})
+	return index
+}
+
+// controllerFields extracts the keys of the $request->validate([...]) call
+// inside the given controller method.
+func controllerFields(path, action string) []Field {
+	if path == "" || action == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	body := methodBody(string(content), action)
+	start := strings.Index(body, "validate([")
+	if start < 0 {
+		return nil
+	}
+	end := strings.Index(body[start:], "]);")
+	if end < 0 {
+		return nil
+	}
+
+	var fields []Field
+	for _, m := range fieldRe.FindAllStringSubmatch(body[start:start+end], -1) {
+		fields = append(fields, Field{Name: m[1], Rules: m[2]})
+	}
+	return fields
+}
+
+func methodBody(content, action string) string {
+	start := strings.Index(content, "function "+action+"(")
+	if start < 0 {
+		return ""
+	}
+	rest := content[start+len("function "+action+"("):]
+	if next := strings.Index(rest, "function "); next >= 0 {
+		return rest[:next]
+	}
+	return rest
+}
+
+func joinPath(base, path string) string {
+	base = strings.TrimRight(base, "/")
+	path = strings.Trim(path, "/")
+	if path == "" {
+		if base == "" {
+			return "/"
+		}
+		return base
+	}
+	return base + "/" + path
+}
+
+func baseName(class string) string {
+	parts := strings.Split(class, "\\")
+	return parts[len(parts)-1]
+}
+
+func lastSegment(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	return parts[len(parts)-1]
+}
+
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// SortRoutes orders routes by URI then method for stable output.
+func SortRoutes(routes []Route) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].URI == routes[j].URI {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].URI < routes[j].URI
+	})
+}
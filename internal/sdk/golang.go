@@ -0,0 +1,603 @@
+package sdk
+
+import (
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"laravelboot/internal/project"
+	"strings"
+)
+
+const goRuntime = `// Code generated by LaravelBoot. DO NOT EDIT.
+
+package %s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Response is the envelope returned by the ApiResponse trait and
+// response()->success(). Other response formats are read into this shape.
+type Response[T any] struct {
+	Success bool   ` + "`json:\"success\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+	Data    T      ` + "`json:\"data\"`" + `
+}
+
+// PaginationMeta describes a page. CurrentPage, From and To are set for
+// offset and simple pagination, LastPage and Total for offset only, and
+// the cursors for cursor pagination only.
+type PaginationMeta struct {
+	Strategy    string  ` + "`json:\"strategy,omitempty\"`" + `
+	PerPage     int     ` + "`json:\"per_page\"`" + `
+	HasMore     bool    ` + "`json:\"has_more\"`" + `
+	CurrentPage int     ` + "`json:\"current_page,omitempty\"`" + `
+	LastPage    int     ` + "`json:\"last_page,omitempty\"`" + `
+	Total       int     ` + "`json:\"total,omitempty\"`" + `
+	From        *int    ` + "`json:\"from,omitempty\"`" + `
+	To          *int    ` + "`json:\"to,omitempty\"`" + `
+	NextCursor  *string ` + "`json:\"next_cursor,omitempty\"`" + `
+	PrevCursor  *string ` + "`json:\"prev_cursor,omitempty\"`" + `
+}
+
+type PaginationLinks struct {
+	First *string ` + "`json:\"first,omitempty\"`" + `
+	Last  *string ` + "`json:\"last,omitempty\"`" + `
+	Prev  *string ` + "`json:\"prev\"`" + `
+	Next  *string ` + "`json:\"next\"`" + `
+}
+
+// PaginatedResponse is the envelope returned by paginated()/paginate().
+type PaginatedResponse[T any] struct {
+	Success bool             ` + "`json:\"success\"`" + `
+	Message string           ` + "`json:\"message\"`" + `
+	Data    []T              ` + "`json:\"data\"`" + `
+	Meta    PaginationMeta   ` + "`json:\"meta\"`" + `
+	Links   *PaginationLinks ` + "`json:\"links,omitempty\"`" + `
+}
+
+// APIError is returned for non-2xx responses and envelopes with success=false.
+type APIError struct {
+	Status  int             ` + "`json:\"-\"`" + `
+	Success bool            ` + "`json:\"success\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Errors  json.RawMessage ` + "`json:\"errors,omitempty\"`" + `
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %%d: %%s", e.Status, e.Message)
+}
+
+// ValidationErrors decodes the field errors of a 422 response.
+func (e *APIError) ValidationErrors() map[string][]string {
+	out := map[string][]string{}
+	_ = json.Unmarshal(e.Errors, &out)
+	return out
+}
+
+// formValue renders a query or form value the way Laravel's validation
+// rules read it: booleans become 1 and 0.
+func formValue(v any) string {
+	if b, ok := v.(bool); ok {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	return fmt.Sprint(v)
+}
+
+// File is an upload for multipart endpoints.
+type File struct {
+	Name   string
+	Reader io.Reader
+}
+
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+	Headers    map[string]string
+}
+
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+		Headers:    map[string]string{},
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	var reader io.Reader
+	contentType := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+	return c.send(ctx, method, path, query, reader, contentType, out)
+}
+
+func (c *Client) doMultipart(ctx context.Context, method, path string, fields url.Values, files map[string][]File, out any) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for name, values := range fields {
+		for _, value := range values {
+			if err := w.WriteField(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	for name, list := range files {
+		for _, f := range list {
+			part, err := w.CreateFormFile(name, f.Name)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, f.Reader); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.send(ctx, method, path, nil, &buf, w.FormDataContentType(), out)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string, out any) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", accept)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{Status: resp.StatusCode, Message: resp.Status}
+		_ = json.Unmarshal(toErrorBody(data), apiErr)
+		apiErr.Status = resp.StatusCode
+		return apiErr
+	}
+	if len(data) == 0 || out == nil {
+		return nil
+	}
+	return json.Unmarshal(toEnvelope(data), out)
+}
+`
+
+// goFormats read each response format into Response and APIError.
+var goFormats = map[string]string{
+	"custom": `
+const accept = "application/json"
+
+func toEnvelope(data []byte) []byte  { return data }
+func toErrorBody(data []byte) []byte { return data }
+`,
+	"problem": `
+const accept = "application/json, application/problem+json"
+
+func toEnvelope(data []byte) []byte { return data }
+
+// toErrorBody reads RFC 9457 problem details; detail is the message.
+func toErrorBody(data []byte) []byte {
+	var problem struct {
+		Title, Detail string
+		Errors        json.RawMessage
+	}
+	if json.Unmarshal(data, &problem) != nil {
+		return data
+	}
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+	body := map[string]any{"success": false, "message": message}
+	if len(problem.Errors) > 0 {
+		body["errors"] = problem.Errors
+	}
+	out, err := json.Marshal(body)
+	if err != nil {
+		return data
+	}
+	return out
+}
+`,
+	"jsonapi": `
+const accept = "application/vnd.api+json"
+
+// toEnvelope reads a JSON:API document into Response: meta.message is the
+// message and resource objects become {"id": ..., attributes...}.
+func toEnvelope(data []byte) []byte {
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if decoder.Decode(&doc) != nil {
+		return data
+	}
+	meta, _ := doc["meta"].(map[string]any)
+	message, _ := meta["message"].(string)
+	delete(meta, "message")
+
+	envelope := map[string]any{"success": true, "message": message, "data": flatten(doc["data"])}
+	if len(meta) > 0 {
+		envelope["meta"] = meta
+	}
+	if links, ok := doc["links"]; ok {
+		envelope["links"] = links
+	}
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// flatten turns resource objects into plain objects. Numeric ids, sent as
+// strings by JSON:API, come back as numbers.
+func flatten(value any) any {
+	switch v := value.(type) {
+	case []any:
+		for i := range v {
+			v[i] = flatten(v[i])
+		}
+	case map[string]any:
+		attributes, ok := v["attributes"].(map[string]any)
+		if _, typed := v["type"].(string); typed && ok {
+			out := flatten(attributes).(map[string]any)
+			out["id"] = v["id"]
+			if id, ok := v["id"].(string); ok && numericID(id) {
+				out["id"] = json.Number(id)
+			}
+			return out
+		}
+		for key := range v {
+			v[key] = flatten(v[key])
+		}
+	}
+	return value
+}
+
+func numericID(id string) bool {
+	if id == "" || len(id) > 15 || (id[0] == '0' && len(id) > 1) {
+		return false
+	}
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// toErrorBody reads a JSON:API error document. Validation errors point at
+// /data/attributes/<field>; they are grouped by field like the other
+// formats.
+func toErrorBody(data []byte) []byte {
+	var doc struct {
+		Errors []struct {
+			Title, Detail string
+			Source        struct{ Pointer string }
+		}
+	}
+	if json.Unmarshal(data, &doc) != nil || len(doc.Errors) == 0 {
+		return data
+	}
+
+	fields := map[string][]string{}
+	for _, e := range doc.Errors {
+		if field, ok := strings.CutPrefix(e.Source.Pointer, "/data/attributes/"); ok {
+			field = strings.ReplaceAll(field, "/", ".")
+			fields[field] = append(fields[field], e.Detail)
+		}
+	}
+
+	first := doc.Errors[0]
+	body := map[string]any{"success": false, "message": first.Detail}
+	if len(fields) > 0 {
+		body["message"], body["errors"] = first.Title, fields
+	} else if first.Detail == "" {
+		body["message"] = first.Title
+	}
+	out, err := json.Marshal(body)
+	if err != nil {
+		return data
+	}
+	return out
+}
+`,
+}
+
+func goClient(pkg, responseFormat string, endpoints []endpoint, resources []project.Resource) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, goRuntime, pkg)
+	b.WriteString(goFormats[responseFormat])
+
+	types, err := goTypeNames(b.String(), endpoints, resources)
+	if err != nil {
+		return "", err
+	}
+	known := knownModels(resources)
+	for _, r := range resources {
+		fmt.Fprintf(&b, "\ntype %s struct {\n", types[r.Model])
+		for _, f := range r.Fields {
+			tag := f.Name
+			if f.Optional {
+				tag += ",omitempty"
+			}
+			t := goResourceType(f.Type)
+			if known[f.Ref] {
+				t = "*" + types[f.Ref]
+				if f.Type == "array" {
+					t = "[]" + types[f.Ref]
+				}
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", camel([]string{f.Name}, true), t, tag)
+		}
+		b.WriteString("}\n")
+	}
+
+	user := "json.RawMessage"
+	for _, e := range endpoints {
+		if e.kind == kindLogin && e.resource != nil {
+			user = types[e.resource.Model]
+		}
+	}
+	fmt.Fprintf(&b, "\ntype LoginData struct {\n\tToken string `json:\"token\"`\n\tUser %s `json:\"user\"`\n}\n", user)
+
+	for _, e := range endpoints {
+		if len(e.body) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\ntype %s struct {\n", requestTypeName(e))
+		for _, f := range e.body {
+			tag := f.Name
+			if !isRequired(f) {
+				tag += ",omitempty"
+			}
+			t := e.fieldType(f)
+			if t == "file" || t == "files" {
+				tag = "-"
+			}
+			goType := goFieldType(t)
+			if optionalScalar(e, f) {
+				goType = "*" + goType
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", camel([]string{f.Name}, true), goType, tag)
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, e := range endpoints {
+		writeGoMethod(&b, e, types)
+	}
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("generated Go client is not valid Go: %v", err)
+	}
+	return string(formatted), nil
+}
+
+// goTypeNames names the struct of each resource's model. A model keeps its
+// own name unless the runtime (File, Response, Client...), a request type
+// or LoginData already declares it; then it takes its Resource class name,
+// e.g. FileResource, and a number if that is taken too.
+func goTypeNames(runtime string, endpoints []endpoint, resources []project.Resource) (map[string]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "client.go", runtime, 0)
+	if err != nil {
+		return nil, fmt.Errorf("generated Go client is not valid Go: %v", err)
+	}
+	taken := map[string]bool{"LoginData": true}
+	for name := range file.Scope.Objects {
+		taken[name] = true
+	}
+	for _, e := range endpoints {
+		taken[requestTypeName(e)] = true
+	}
+
+	names := map[string]string{}
+	for _, r := range resources {
+		if _, ok := names[r.Model]; ok {
+			continue
+		}
+		name := r.Model
+		if taken[name] {
+			name = r.Name
+		}
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s%d", r.Name, i)
+		}
+		taken[name] = true
+		names[r.Model] = name
+	}
+	return names, nil
+}
+
+// optionalScalar reports whether a request field is an optional string,
+// number or boolean. Those are pointers, so nil leaves them out while a
+// zero value is still sent.
+func optionalScalar(e endpoint, f project.Field) bool {
+	switch e.fieldType(f) {
+	case "file", "files", "array":
+		return false
+	}
+	return !isRequired(f)
+}
+
+// goReserved are names a path parameter cannot take in a generated method:
+// Go keywords and the identifiers the method body uses.
+var goReserved = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true, "c": true, "ctx": true, "input": true, "out": true, "query": true, "fields": true,
+	"files": true, "err": true, "fmt": true, "url": true,
+}
+
+func goParam(name string) string {
+	param := camel([]string{name}, false)
+	if goReserved[param] {
+		param += "Param"
+	}
+	return param
+}
+
+// writeGoValue sets a query or form value from a request field: required
+// fields always, optional ones when set, arrays as name[] per item.
+func writeGoValue(b *strings.Builder, e endpoint, f project.Field, set string) {
+	field := "input." + camel([]string{f.Name}, true)
+	switch {
+	case e.fieldType(f) == "array":
+		fmt.Fprintf(b, "\tfor _, v := range %s {\n\t\t%s(%q, formValue(v))\n\t}\n", field, set, f.Name+"[]")
+	case optionalScalar(e, f):
+		fmt.Fprintf(b, "\tif %s != nil {\n\t\t%s(%q, formValue(*%s))\n\t}\n", field, set, f.Name, field)
+	default:
+		fmt.Fprintf(b, "\t%s(%q, formValue(%s))\n", set, f.Name, field)
+	}
+}
+
+func writeGoMethod(b *strings.Builder, e endpoint, types map[string]string) {
+	name := camel([]string{e.name}, true)
+	args := []string{"ctx context.Context"}
+	for _, p := range e.pathParams {
+		args = append(args, goParam(p)+" string")
+	}
+	if len(e.body) > 0 {
+		args = append(args, "input "+requestTypeName(e))
+	}
+
+	ret := goResponseType(e, types)
+	path := fmt.Sprintf("%q", e.route.URI)
+	if len(e.pathParams) > 0 {
+		var params []string
+		for _, p := range e.pathParams {
+			params = append(params, "url.PathEscape("+goParam(p)+")")
+		}
+		path = fmt.Sprintf("fmt.Sprintf(%q, %s)", pathParamRe.ReplaceAllString(e.route.URI, "%s"), strings.Join(params, ", "))
+	}
+
+	fmt.Fprintf(b, "\n// %s calls %s %s.\n", name, e.route.Method, e.route.URI)
+	fmt.Fprintf(b, "func (c *Client) %s(%s) (*%s, error) {\n", name, strings.Join(args, ", "), ret)
+	fmt.Fprintf(b, "\tvar out %s\n", ret)
+
+	call := ""
+	switch {
+	case len(e.body) == 0:
+		call = fmt.Sprintf("c.do(ctx, %q, %s, nil, nil, &out)", e.route.Method, path)
+	case e.query:
+		b.WriteString("\tquery := url.Values{}\n")
+		for _, f := range e.body {
+			writeGoValue(b, e, f, "query.Add")
+		}
+		call = fmt.Sprintf("c.do(ctx, %q, %s, query, nil, &out)", e.route.Method, path)
+	case e.multipart:
+		b.WriteString("\tfields := url.Values{}\n\tfiles := map[string][]File{}\n")
+		for _, f := range e.body {
+			field := camel([]string{f.Name}, true)
+			switch e.fieldType(f) {
+			case "files":
+				fmt.Fprintf(b, "\tfiles[%q] = input.%s\n", f.Name+"[]", field)
+			case "file":
+				fmt.Fprintf(b, "\tif input.%s.Reader != nil {\n\t\tfiles[%q] = []File{input.%s}\n\t}\n", field, f.Name, field)
+			default:
+				writeGoValue(b, e, f, "fields.Add")
+			}
+		}
+		call = fmt.Sprintf("c.doMultipart(ctx, %q, %s, fields, files, &out)", e.route.Method, path)
+	default:
+		call = fmt.Sprintf("c.do(ctx, %q, %s, nil, input, &out)", e.route.Method, path)
+	}
+
+	fmt.Fprintf(b, "\tif err := %s; err != nil {\n\t\treturn nil, err\n\t}\n", call)
+	if e.kind == kindLogin {
+		b.WriteString("\tc.Token = out.Data.Token\n")
+	}
+	b.WriteString("\treturn &out, nil\n}\n")
+}
+
+func goResponseType(e endpoint, types map[string]string) string {
+	switch e.kind {
+	case kindLogin:
+		return "Response[LoginData]"
+	case kindPaginated:
+		return fmt.Sprintf("PaginatedResponse[%s]", types[e.resource.Model])
+	case kindResource:
+		return fmt.Sprintf("Response[%s]", types[e.resource.Model])
+	}
+	return "Response[json.RawMessage]"
+}
+
+func goResourceType(t string) string {
+	switch t {
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]any"
+	case "object":
+		return "map[string]any"
+	}
+	return "any"
+}
+
+func goFieldType(t string) string {
+	switch t {
+	case "files":
+		return "[]File"
+	case "file":
+		return "File"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]any"
+	}
+	return "string"
+}
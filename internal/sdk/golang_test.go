@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"laravelboot/internal/project"
+	"testing"
+)
+
+// collidingResources are models named like the runtime's own types.
+var collidingResources = []project.Resource{
+	{Name: "FileResource", Model: "File", Fields: []project.ResourceField{
+		{Name: "id", Type: "integer"},
+		{Name: "path", Type: "string"},
+		{Name: "owner", Type: "object", Ref: "User", Optional: true},
+	}},
+	{Name: "ResponseResource", Model: "Response", Fields: []project.ResourceField{
+		{Name: "id", Type: "integer"},
+		{Name: "files", Type: "array", Ref: "File"},
+	}},
+	{Name: "ClientResource", Model: "Client", Fields: []project.ResourceField{
+		{Name: "id", Type: "integer"},
+	}},
+	{Name: "UserResource", Model: "User", Fields: []project.ResourceField{
+		{Name: "id", Type: "integer"},
+		{Name: "active", Type: "boolean"},
+	}},
+}
+
+var collidingRoutes = []project.Route{
+	{Method: "POST", URI: "api/login", Controller: "AuthController", Action: "login", Fields: []project.Field{
+		{Name: "email", Rules: "required|email"},
+		{Name: "password", Rules: "required|string"},
+	}},
+	{Method: "GET", URI: "api/files", Controller: "FileController", Action: "index", Fields: []project.Field{
+		{Name: "archived", Rules: "boolean"},
+	}},
+	{Method: "POST", URI: "api/files", Controller: "FileController", Action: "store", Fields: []project.Field{
+		{Name: "file", Rules: "required|file"},
+		{Name: "public", Rules: "boolean"},
+	}},
+	{Method: "GET", URI: "api/responses/{response}", Controller: "ResponseController", Action: "show"},
+	{Method: "GET", URI: "api/clients/{client}", Controller: "ClientController", Action: "show"},
+}
+
+func TestGoClientRenamesCollidingModels(t *testing.T) {
+	for format := range goFormats {
+		src, err := goClient("client", format, buildEndpoints(collidingRoutes, collidingResources), collidingResources)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "client.go", src, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		if _, err := conf.Check("client", fset, []*ast.File{file}, nil); err != nil {
+			t.Fatalf("%s: generated client does not type-check: %v", format, err)
+		}
+
+		for _, name := range []string{"FileResource", "ResponseResource", "ClientResource", "User"} {
+			if file.Scope.Lookup(name) == nil {
+				t.Errorf("%s: no type %s in the generated client", format, name)
+			}
+		}
+	}
+}
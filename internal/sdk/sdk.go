@@ -0,0 +1,256 @@
+package sdk
+
+import (
+	"fmt"
+	"laravelboot/internal/config"
+	"laravelboot/internal/project"
+	"laravelboot/internal/ui"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Response kinds, mirroring the envelopes produced by the ApiResponse trait
+// and the ApiResponseServiceProvider macros.
+const (
+	kindRaw       = "raw"       // success/message/data with an unknown payload
+	kindResource  = "resource"  // data is a single API Resource
+	kindPaginated = "paginated" // data is a list of Resources with meta/links
+	kindEmpty     = "empty"     // data is null (deletes, logout)
+	kindLogin     = "login"     // data holds a Sanctum token and the user
+)
+
+type Generator struct {
+	ProjectPath string
+	Output      string
+	Languages   []string
+	GoPackage   string
+	DryRun      bool
+
+	// Format is the API's response format (custom, jsonapi or problem).
+	// Empty means options.responses.format from the project's config.
+	Format string
+}
+
+type endpoint struct {
+	route      project.Route
+	name       string
+	pathParams []string
+	body       []project.Field
+	fileArrays map[string]bool
+	multipart  bool
+	query      bool
+	kind       string
+	resource   *project.Resource
+}
+
+var pathParamRe = regexp.MustCompile(`\{(\w+)\??\}`)
+
+func NewGenerator(projectPath string, output string, dryRun bool) *Generator {
+	return &Generator{
+		ProjectPath: projectPath,
+		Output:      output,
+		Languages:   []string{"typescript", "go"},
+		GoPackage:   "client",
+		DryRun:      dryRun,
+	}
+}
+
+func (g *Generator) Generate() error {
+	routes, err := project.DiscoverRoutes(g.ProjectPath)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no API routes found in routes/api.php")
+	}
+	project.SortRoutes(routes)
+
+	resources, err := project.DiscoverResources(g.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	endpoints := buildEndpoints(routes, resources)
+	format := g.responseFormat()
+
+	for _, lang := range g.Languages {
+		var path, content string
+		switch lang {
+		case "typescript", "ts":
+			path = filepath.Join(g.outputDir(), "typescript", "client.ts")
+			content = typescriptClient(format, endpoints, resources)
+		case "go":
+			path = filepath.Join(g.outputDir(), "go", g.GoPackage, "client.go")
+			content, err = goClient(g.GoPackage, format, endpoints, resources)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown SDK language: %s (expected typescript or go)", lang)
+		}
+
+		if g.DryRun {
+			ui.Printf("[Dry Run] Would write %s SDK: %s\n", lang, path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %v", filepath.Dir(path), err)
+		}
+		if err := ui.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		ui.Printf("🧰 Generated %s SDK with %d endpoints: %s\n", lang, len(endpoints), path)
+	}
+
+	return nil
+}
+
+// responseFormat is the envelope the clients parse. Clients for every
+// format expose the same success/message/data shape.
+func (g *Generator) responseFormat() string {
+	format := g.Format
+	if format == "" {
+		if conf, err := config.LoadConfig(filepath.Join(g.ProjectPath, ".laravelboot.yaml")); err == nil {
+			format = conf.Options.Responses.Format
+		}
+	}
+	if format == "" {
+		return "custom"
+	}
+	return format
+}
+
+func (g *Generator) outputDir() string {
+	if g.Output != "" {
+		return g.Output
+	}
+	return filepath.Join(g.ProjectPath, "sdk")
+}
+
+func buildEndpoints(routes []project.Route, resources []project.Resource) []endpoint {
+	byModel := map[string]*project.Resource{}
+	for i := range resources {
+		byModel[resources[i].Model] = &resources[i]
+	}
+
+	used := map[string]int{}
+	var endpoints []endpoint
+	for _, r := range routes {
+		e := endpoint{route: r, kind: kindRaw, fileArrays: map[string]bool{}}
+
+		for _, m := range pathParamRe.FindAllStringSubmatch(r.URI, -1) {
+			e.pathParams = append(e.pathParams, m[1])
+		}
+		for _, f := range r.Fields {
+			if strings.HasSuffix(f.Name, ".*") && isFile(f) {
+				e.fileArrays[strings.TrimSuffix(f.Name, ".*")] = true
+			}
+			if !strings.Contains(f.Name, "*") && !strings.Contains(f.Name, ".") {
+				e.body = append(e.body, f)
+			}
+		}
+		e.multipart = r.HasFileUpload()
+		e.query = r.Method == "GET" && len(e.body) > 0
+
+		model := strings.TrimSuffix(r.Controller, "Controller")
+		e.resource = byModel[model]
+		switch {
+		case r.Method == "POST" && r.Action == "login":
+			e.kind = kindLogin
+			e.resource = byModel["User"]
+		case r.Action == "destroy" || r.Action == "logout":
+			e.kind = kindEmpty
+		case e.resource != nil && r.Action == "index":
+			e.kind = kindPaginated
+		case e.resource != nil && (r.Action == "show" || r.Action == "store" || r.Action == "update"):
+			e.kind = kindResource
+		}
+
+		e.name = methodName(r)
+		if n := used[e.name]; n > 0 {
+			e.name = fmt.Sprintf("%s%d", e.name, n+1)
+		}
+		used[methodName(r)]++
+
+		endpoints = append(endpoints, e)
+	}
+	return endpoints
+}
+
+// methodName derives a lowerCamel client method: AuthController@login
+// becomes authLogin, closure routes use the verb and path.
+func methodName(r project.Route) string {
+	var parts []string
+	if r.Controller != "" {
+		parts = []string{strings.TrimSuffix(r.Controller, "Controller"), r.Action}
+	} else {
+		parts = []string{strings.ToLower(r.Method)}
+		for _, seg := range strings.Split(r.URI, "/") {
+			if seg == "" || seg == "api" || strings.HasPrefix(seg, "{") || isVersion(seg) {
+				continue
+			}
+			parts = append(parts, seg)
+		}
+	}
+	return camel(parts, false)
+}
+
+func isVersion(seg string) bool {
+	return len(seg) > 1 && seg[0] == 'v' && unicode.IsDigit(rune(seg[1]))
+}
+
+func camel(parts []string, exported bool) string {
+	var b strings.Builder
+	for i, p := range parts {
+		for j, w := range strings.FieldsFunc(p, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			if w == "" {
+				continue
+			}
+			if i == 0 && j == 0 && !exported {
+				b.WriteString(strings.ToLower(w[:1]) + w[1:])
+			} else {
+				b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+			}
+		}
+	}
+	return b.String()
+}
+
+func knownModels(resources []project.Resource) map[string]bool {
+	known := map[string]bool{}
+	for _, r := range resources {
+		known[r.Model] = true
+	}
+	return known
+}
+
+func isFile(f project.Field) bool {
+	return strings.Contains(f.Rules, "file") || strings.Contains(f.Rules, "image")
+}
+
+func isRequired(f project.Field) bool {
+	return strings.Contains(f.Rules, "required")
+}
+
+// fieldType maps Laravel validation rules onto a JSON type; arrays whose
+// items are validated as files become "files".
+func (e endpoint) fieldType(f project.Field) string {
+	switch {
+	case e.fileArrays[f.Name]:
+		return "files"
+	case isFile(f):
+		return "file"
+	case strings.Contains(f.Rules, "integer"):
+		return "integer"
+	case strings.Contains(f.Rules, "numeric"):
+		return "number"
+	case strings.Contains(f.Rules, "boolean"):
+		return "boolean"
+	case strings.Contains(f.Rules, "array"):
+		return "array"
+	}
+	return "string"
+}
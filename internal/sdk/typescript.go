@@ -0,0 +1,370 @@
+package sdk
+
+import (
+	"fmt"
+	"laravelboot/internal/project"
+	"strings"
+)
+
+const typescriptRuntime = `// Generated by LaravelBoot. Do not edit by hand; run "laravelboot sdk" again.
+
+/** Envelope returned by the ApiResponse trait and response()->success().
+ * Other response formats are read into this shape. */
+export interface ApiResponse<T> {
+  success: boolean;
+  message: string;
+  data: T;
+}
+
+/** current_page, from and to are set for offset and simple pagination,
+ * last_page and total for offset only, the cursors for cursor only. */
+export interface PaginationMeta {
+  strategy?: 'offset' | 'simple' | 'cursor';
+  per_page: number;
+  has_more?: boolean;
+  current_page?: number;
+  last_page?: number;
+  total?: number;
+  from?: number | null;
+  to?: number | null;
+  next_cursor?: string | null;
+  prev_cursor?: string | null;
+}
+
+export interface PaginationLinks {
+  first?: string | null;
+  last?: string | null;
+  prev: string | null;
+  next: string | null;
+}
+
+/** Envelope returned by paginated()/paginate(). */
+export interface PaginatedResponse<T> extends ApiResponse<T[]> {
+  meta: PaginationMeta;
+  links?: PaginationLinks;
+}
+
+/** Envelope returned by error() and the API exception handler. */
+export interface ApiErrorBody {
+  success: false;
+  message: string;
+  errors?: Record<string, string[]> | null;
+}
+
+export class ApiError extends Error {
+  constructor(public readonly status: number, public readonly body: ApiErrorBody) {
+    super(body.message);
+    this.name = 'ApiError';
+  }
+
+  get validationErrors(): Record<string, string[]> {
+    return this.body.errors ?? {};
+  }
+}
+
+export interface ClientOptions {
+  baseUrl: string;
+  token?: string;
+  fetch?: typeof fetch;
+  headers?: Record<string, string>;
+}
+
+type Query = Array<[string, string]>;
+
+/** Serialises request fields the way the Go client and Laravel do: booleans
+ * as 1/0, arrays as repeated name[] entries; undefined fields are left out. */
+function formEntries(fields: object): Query {
+  const entries: Query = [];
+  for (const [name, value] of Object.entries(fields)) {
+    if (value === undefined || value === null) continue;
+    if (Array.isArray(value)) {
+      value.forEach((item) => entries.push([name + '[]', formValue(item)]));
+    } else {
+      entries.push([name, formValue(value)]);
+    }
+  }
+  return entries;
+}
+
+function formValue(value: unknown): string {
+  if (typeof value === 'boolean') return value ? '1' : '0';
+  return String(value);
+}
+{{format}}
+export class ApiClient {
+  private baseUrl: string;
+  private token?: string;
+  private fetchImpl: typeof fetch;
+  private headers: Record<string, string>;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, '');
+    this.token = options.token;
+    this.fetchImpl = options.fetch ?? fetch.bind(globalThis);
+    this.headers = options.headers ?? {};
+  }
+
+  setToken(token: string | undefined): void {
+    this.token = token;
+  }
+
+  protected async request<R>(method: string, path: string, options: { body?: unknown; form?: FormData; query?: Query } = {}): Promise<R> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of options.query ?? []) {
+      url.searchParams.append(key, value);
+    }
+
+    const headers: Record<string, string> = { Accept: ACCEPT, ...this.headers };
+    if (this.token) headers.Authorization = ` + "`Bearer ${this.token}`" + `;
+
+    let body: BodyInit | undefined;
+    if (options.form) {
+      body = options.form;
+    } else if (options.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(options.body);
+    }
+
+    const response = await this.fetchImpl(url.toString(), { method, headers, body });
+    if (response.status === 204) return undefined as R;
+
+    const payload = await response.json().catch(() => ({ success: false, message: response.statusText }));
+    if (!response.ok || payload.success === false) {
+      throw new ApiError(response.status, toErrorBody(payload, response.statusText));
+    }
+    return toEnvelope(payload) as R;
+  }
+}
+`
+
+// typescriptFormats read each response format into ApiResponse and
+// ApiErrorBody.
+var typescriptFormats = map[string]string{
+	"custom": `
+const ACCEPT = 'application/json';
+
+function toEnvelope(payload: any): unknown {
+  return payload;
+}
+
+function toErrorBody(payload: any, statusText: string): ApiErrorBody {
+  return { success: false, message: payload.message ?? statusText, errors: payload.errors };
+}
+`,
+	"problem": `
+const ACCEPT = 'application/json, application/problem+json';
+
+function toEnvelope(payload: any): unknown {
+  return payload;
+}
+
+/** Errors are RFC 9457 problem details; detail is the message. */
+function toErrorBody(payload: any, statusText: string): ApiErrorBody {
+  return { success: false, message: payload.detail ?? payload.title ?? payload.message ?? statusText, errors: payload.errors };
+}
+`,
+	"jsonapi": `
+const ACCEPT = 'application/vnd.api+json';
+
+/** A JSON:API document as an ApiResponse: meta.message is the message and
+ * resource objects become { id, ...attributes }. */
+function toEnvelope(payload: any): unknown {
+  const { message, ...meta } = payload.meta ?? {};
+  const envelope: Record<string, unknown> = { success: true, message: message ?? '', data: flatten(payload.data) };
+  if (Object.keys(meta).length > 0) envelope.meta = meta;
+  if (payload.links) envelope.links = payload.links;
+  return envelope;
+}
+
+/** Numeric ids, sent as strings by JSON:API, come back as numbers. */
+function flatten(value: any): any {
+  if (Array.isArray(value)) return value.map(flatten);
+  if (value === null || typeof value !== 'object') return value;
+  if (typeof value.type === 'string' && 'id' in value && typeof value.attributes === 'object') {
+    const id = /^(0|[1-9]\d{0,14})$/.test(String(value.id)) ? Number(value.id) : value.id;
+    return { id, ...flatten(value.attributes) };
+  }
+  return Object.fromEntries(Object.entries(value).map(([key, item]) => [key, flatten(item)]));
+}
+
+/** Validation errors point at /data/attributes/<field>; they are grouped
+ * by field like the other formats. */
+function toErrorBody(payload: any, statusText: string): ApiErrorBody {
+  const list: any[] = Array.isArray(payload.errors) ? payload.errors : [];
+  const errors: Record<string, string[]> = {};
+  for (const error of list) {
+    const pointer: string = error.source?.pointer ?? '';
+    if (pointer.startsWith('/data/attributes/')) {
+      const field = pointer.slice('/data/attributes/'.length).split('/').join('.');
+      errors[field] = [...(errors[field] ?? []), error.detail];
+    }
+  }
+  if (Object.keys(errors).length > 0) {
+    return { success: false, message: list[0].title ?? statusText, errors };
+  }
+  return { success: false, message: list[0]?.detail ?? list[0]?.title ?? statusText, errors: null };
+}
+`,
+}
+
+func typescriptClient(format string, endpoints []endpoint, resources []project.Resource) string {
+	var b strings.Builder
+	b.WriteString(strings.Replace(typescriptRuntime, "{{format}}", typescriptFormats[format], 1))
+
+	known := knownModels(resources)
+	for _, r := range resources {
+		fmt.Fprintf(&b, "\nexport interface %s {\n", r.Model)
+		for _, f := range r.Fields {
+			opt := ""
+			if f.Optional {
+				opt = "?"
+			}
+			t := tsResourceType(f.Type)
+			if known[f.Ref] {
+				t = f.Ref
+				if f.Type == "array" {
+					t += "[]"
+				}
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", f.Name, opt, t)
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, e := range endpoints {
+		if len(e.body) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\nexport interface %s {\n", requestTypeName(e))
+		for _, f := range e.body {
+			opt := "?"
+			if isRequired(f) {
+				opt = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", f.Name, opt, tsFieldType(e.fieldType(f)))
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString("\nexport class LaravelApi extends ApiClient {\n")
+	for i, e := range endpoints {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeTypescriptMethod(&b, e)
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func writeTypescriptMethod(b *strings.Builder, e endpoint) {
+	var args []string
+	for _, p := range e.pathParams {
+		args = append(args, p+": string | number")
+	}
+	if len(e.body) > 0 {
+		args = append(args, "input: "+requestTypeName(e))
+	}
+
+	ret := tsResponseType(e)
+	path := "'" + e.route.URI + "'"
+	if len(e.pathParams) > 0 {
+		path = "`" + pathParamRe.ReplaceAllString(e.route.URI, "$${encodeURIComponent(String($1))}") + "`"
+	}
+
+	fmt.Fprintf(b, "  /** %s %s */\n", e.route.Method, e.route.URI)
+	fmt.Fprintf(b, "  async %s(%s): Promise<%s> {\n", e.name, strings.Join(args, ", "), ret)
+
+	options := ""
+	switch {
+	case len(e.body) == 0:
+	case e.query:
+		options = ", { query: formEntries(input) }"
+	case e.multipart:
+		b.WriteString("    const form = new FormData();\n")
+		var fields []string
+		for _, f := range e.body {
+			if t := e.fieldType(f); t != "file" && t != "files" {
+				fields = append(fields, fmt.Sprintf("'%s': input.%s", f.Name, f.Name))
+			}
+		}
+		if len(fields) > 0 {
+			fmt.Fprintf(b, "    formEntries({ %s }).forEach(([name, value]) => form.append(name, value));\n", strings.Join(fields, ", "))
+		}
+		for _, f := range e.body {
+			switch e.fieldType(f) {
+			case "files":
+				fmt.Fprintf(b, "    (input.%s ?? []).forEach((file) => form.append('%s[]', file));\n", f.Name, f.Name)
+			case "file":
+				fmt.Fprintf(b, "    if (input.%s !== undefined) form.append('%s', input.%s);\n", f.Name, f.Name, f.Name)
+			}
+		}
+		options = ", { form }"
+	default:
+		options = ", { body: input }"
+	}
+
+	if e.kind == kindLogin {
+		fmt.Fprintf(b, "    const response = await this.request<%s>('%s', %s%s);\n", ret, e.route.Method, path, options)
+		b.WriteString("    this.setToken(response.data.token);\n")
+		b.WriteString("    return response;\n")
+	} else {
+		fmt.Fprintf(b, "    return this.request<%s>('%s', %s%s);\n", ret, e.route.Method, path, options)
+	}
+	b.WriteString("  }\n")
+}
+
+func requestTypeName(e endpoint) string {
+	return camel([]string{e.name}, true) + "Request"
+}
+
+func tsResponseType(e endpoint) string {
+	switch e.kind {
+	case kindLogin:
+		user := "Record<string, unknown>"
+		if e.resource != nil {
+			user = e.resource.Model
+		}
+		return fmt.Sprintf("ApiResponse<{ token: string; user: %s }>", user)
+	case kindEmpty:
+		return "ApiResponse<null>"
+	case kindPaginated:
+		return fmt.Sprintf("PaginatedResponse<%s>", e.resource.Model)
+	case kindResource:
+		return fmt.Sprintf("ApiResponse<%s>", e.resource.Model)
+	}
+	return "ApiResponse<unknown>"
+}
+
+func tsResourceType(t string) string {
+	switch t {
+	case "integer", "number":
+		return "number"
+	case "string":
+		return "string"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "unknown[]"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+func tsFieldType(t string) string {
+	switch t {
+	case "files":
+		return "Blob[]"
+	case "file":
+		return "Blob"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "unknown[]"
+	}
+	return "string"
+}
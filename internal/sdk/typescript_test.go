@@ -0,0 +1,25 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTypescriptClientSerialisesLikeGo(t *testing.T) {
+	src := typescriptClient("custom", buildEndpoints(collidingRoutes, collidingResources), collidingResources)
+
+	for _, want := range []string{
+		"{ query: formEntries(input) }",
+		"formEntries({ 'public': input.public }).forEach(([name, value]) => form.append(name, value));",
+		"if (input.file !== undefined) form.append('file', input.file);",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated client lacks %q", want)
+		}
+	}
+	for _, bad := range []string{"as unknown as Query", "String(input."} {
+		if strings.Contains(src, bad) {
+			t.Errorf("generated client still serialises with %q", bad)
+		}
+	}
+}
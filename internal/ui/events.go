@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Step runs fn as a named step and reports when it starts, finishes or
+// fails. On a terminal the running steps are shown in a spinner line.
+func Step(name string, fn func() error) error {
+	start := time.Now()
+	if JSON() {
+		emit(map[string]any{"event": "step_started", "step": name})
+	}
+	spinAdd(name)
+
+	err := fn()
+
+	d := time.Since(start)
+	spinRemove(name)
+	switch {
+	case JSON() && err != nil:
+		emit(map[string]any{"event": "step_failed", "step": name, "duration_ms": d.Milliseconds(), "error": err.Error()})
+	case JSON():
+		emit(map[string]any{"event": "step_finished", "step": name, "duration_ms": d.Milliseconds()})
+	case err != nil:
+		Verbosef("❌ %s failed after %s", name, d.Round(time.Millisecond))
+	default:
+		Verbosef("✔ %s (%s)", name, d.Round(time.Millisecond))
+	}
+	return err
+}
+
+// Skipped reports a step that did not run, e.g. because a step it needs
+// failed or the run stopped at the first failure.
+func Skipped(name, reason string) {
+	if JSON() {
+		emit(map[string]any{"event": "step_skipped", "step": name, "reason": reason})
+		return
+	}
+	Verbosef("⏭️ %s skipped: %s", name, reason)
+}
+
+// Emit sends a custom event in JSON mode, e.g. an apply plan. It does
+// nothing in text mode.
+func Emit(event string, fields map[string]any) {
+	if !JSON() {
+		return
+	}
+	e := map[string]any{"event": event}
+	for k, v := range fields {
+		e[k] = v
+	}
+	emit(e)
+}
+
+// Timing reports the total time spent in steps and the wall-clock time
+// of a run; per-step durations are in the step_finished events.
+func Timing(steps, wall time.Duration) {
+	if JSON() {
+		emit(map[string]any{"event": "timing", "steps_ms": steps.Milliseconds(), "wall_ms": wall.Milliseconds()})
+	}
+}
+
+// Run runs cmd and returns its combined output, like CombinedOutput. The
+// command is reported as an event; with --debug its output also streams
+// live.
+func Run(cmd *exec.Cmd) ([]byte, error) {
+	line := commandLine(cmd)
+	Verbosef("$ %s", line)
+
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var stream *lineWriter
+	if Enabled(LevelDebug) && !JSON() {
+		stream = &lineWriter{prefix: "  │ " + filepath.Base(cmd.Path) + ": "}
+		w = io.MultiWriter(&buf, stream)
+	}
+	cmd.Stdout, cmd.Stderr = w, w
+
+	start := time.Now()
+	err := cmd.Run()
+	if stream != nil {
+		stream.Flush()
+	}
+
+	if JSON() {
+		event := map[string]any{"event": "command", "command": line, "dir": cmd.Dir, "duration_ms": time.Since(start).Milliseconds(), "exit_code": exitCode(cmd, err)}
+		if err != nil {
+			event["error"] = err.Error()
+		}
+		emit(event)
+	}
+	return buf.Bytes(), err
+}
+
+// WriteFile writes a file and reports it. An existing file keeps its mode;
+// perm only applies to new files.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	info, statErr := os.Stat(path)
+	if statErr == nil {
+		perm = info.Mode().Perm()
+	}
+	// Write next to the target and rename, so an interrupted run never
+	// leaves a half-written file behind.
+	tmp := path + ".laravelboot-tmp"
+	os.Remove(tmp)
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	// The umask applies to the new file; give it the target's mode back.
+	if statErr == nil {
+		if err := os.Chmod(tmp, perm); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	FileWritten(path)
+	return nil
+}
+
+// FileWritten reports a file created or changed by other means.
+func FileWritten(path string) {
+	if JSON() {
+		emit(map[string]any{"event": "file_written", "path": path})
+		return
+	}
+	Debugf("  ✏️ %s", path)
+}
+
+func commandLine(cmd *exec.Cmd) string {
+	args := append([]string{filepath.Base(cmd.Path)}, cmd.Args[1:]...)
+	return strings.Join(args, " ")
+}
+
+func exitCode(cmd *exec.Cmd, err error) int {
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.ExitCode()
+	}
+	if err != nil {
+		return -1
+	}
+	return 0
+}
+
+// lineWriter prints command output line by line through write, so it
+// interleaves cleanly with other messages.
+type lineWriter struct {
+	mu     sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf.Write(p)
+	for {
+		i := bytes.IndexByte(l.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := string(l.buf.Next(i + 1))
+		write(l.prefix + line)
+	}
+	return len(p), nil
+}
+
+func (l *lineWriter) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buf.Len() > 0 {
+		write(l.prefix + l.buf.String() + "\n")
+		l.buf.Reset()
+	}
+}
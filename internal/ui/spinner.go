@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinner keeps one status line at the bottom of the terminal listing the
+// steps that are running. Messages are printed above it.
+type spinner struct {
+	active []string
+	frame  int
+	stop   chan struct{}
+}
+
+func newSpinner() *spinner {
+	return &spinner{}
+}
+
+func (s *spinner) line() string {
+	if len(s.active) == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("%s %s", frames[s.frame%len(frames)], strings.Join(s.active, ", "))
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && len([]rune(line)) >= width {
+		line = string([]rune(line)[:max(width-2, 1)]) + "…"
+	}
+	return line
+}
+
+func (s *spinner) clear(w io.Writer) {
+	if len(s.active) > 0 {
+		fmt.Fprint(w, "\r\x1b[K")
+	}
+}
+
+func (s *spinner) draw(w io.Writer) {
+	if line := s.line(); line != "" {
+		fmt.Fprint(w, line)
+	}
+}
+
+func spinAdd(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if spin == nil {
+		return
+	}
+	spin.clear(out)
+	spin.active = append(spin.active, name)
+	spin.draw(out)
+
+	if spin.stop == nil {
+		spin.stop = make(chan struct{})
+		go spin.tick(spin.stop)
+	}
+}
+
+func spinRemove(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if spin == nil {
+		return
+	}
+	spin.clear(out)
+	for i, a := range spin.active {
+		if a == name {
+			spin.active = append(spin.active[:i], spin.active[i+1:]...)
+			break
+		}
+	}
+	spin.draw(out)
+
+	if len(spin.active) == 0 && spin.stop != nil {
+		close(spin.stop)
+		spin.stop = nil
+	}
+}
+
+func (s *spinner) tick(stop chan struct{}) {
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			mu.Lock()
+			s.frame++
+			s.clear(out)
+			s.draw(out)
+			mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package ui is the single place LaravelBoot writes progress to. Messages
+// have a level, steps and commands are reported as events, and everything
+// can be emitted as newline-delimited JSON instead of text.
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+type Level int
+
+const (
+	LevelQuiet   Level = iota // warnings and errors only
+	LevelNormal               // progress messages
+	LevelVerbose              // plus finished steps, commands and files
+	LevelDebug                // plus live command output
+)
+
+var (
+	mu      sync.Mutex
+	out     io.Writer = os.Stdout
+	level             = LevelNormal
+	jsonOut bool
+	spin    *spinner
+)
+
+// Configure sets the level and format for the rest of the run. Spinners
+// are shown for text output on a terminal, except in debug mode where
+// command output streams instead.
+func Configure(l Level, asJSON bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	level, jsonOut = l, asJSON
+	spin = nil
+	if !asJSON && l >= LevelNormal && l < LevelDebug && term.IsTerminal(int(os.Stdout.Fd())) {
+		spin = newSpinner()
+	}
+}
+
+// JSON reports whether events are emitted as JSON.
+func JSON() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return jsonOut
+}
+
+// Enabled reports whether messages at l are shown.
+func Enabled(l Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return l <= level
+}
+
+// Printf logs a progress message.
+func Printf(format string, args ...any) {
+	logf(LevelNormal, "info", fmt.Sprintf(format, args...))
+}
+
+// Println logs a progress message.
+func Println(args ...any) {
+	logf(LevelNormal, "info", fmt.Sprintln(args...))
+}
+
+// Warnf logs a warning; warnings are shown even with --quiet.
+func Warnf(format string, args ...any) {
+	logf(LevelQuiet, "warn", fmt.Sprintf(format, args...))
+}
+
+// Verbosef logs a detail shown with --verbose.
+func Verbosef(format string, args ...any) {
+	logf(LevelVerbose, "verbose", fmt.Sprintf(format, args...))
+}
+
+// Debugf logs a detail shown with --debug.
+func Debugf(format string, args ...any) {
+	logf(LevelDebug, "debug", fmt.Sprintf(format, args...))
+}
+
+// Prompt shows a question answered on stdin. Unlike Printf it is shown at
+// every level and does not end the line.
+func Prompt(question string) {
+	if JSON() {
+		emit(map[string]any{"event": "prompt", "message": plain(question)})
+		return
+	}
+	write(question)
+}
+
+// Error reports the error a command failed with. In text mode the caller
+// prints it; in JSON mode it becomes the final event.
+func Error(err error) {
+	if JSON() {
+		emit(map[string]any{"event": "error", "error": err.Error()})
+	}
+}
+
+func logf(l Level, name, msg string) {
+	if !Enabled(l) {
+		return
+	}
+	if JSON() {
+		emit(map[string]any{"event": "log", "level": name, "message": plain(msg)})
+		return
+	}
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	write(msg)
+}
+
+// plain drops the leading emoji and "Warning:" that text messages carry.
+func plain(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if first, rest, ok := strings.Cut(msg, " "); ok && strings.IndexFunc(first, isWordRune) < 0 {
+		msg = rest
+	}
+	return strings.TrimPrefix(msg, "Warning: ")
+}
+
+func isWordRune(r rune) bool {
+	return r < 128 && (r == '[' || r == '$' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// write prints text above the spinner line, if there is one.
+func write(text string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if spin != nil {
+		spin.clear(out)
+	}
+	fmt.Fprint(out, text)
+	if spin != nil {
+		spin.draw(out)
+	}
+}
+
+// emit writes one JSON event line.
+func emit(event map[string]any) {
+	event["time"] = time.Now().Format(time.RFC3339Nano)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	out.Write(append(data, '\n'))
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"laravelboot/internal/ui"
 	"net/http"
 	"os"
 	"os/exec"
@@ -14,7 +15,7 @@ type GitHubRelease struct {
 }
 
 func CheckForUpdate(currentVersion string) {
-	fmt.Printf("🔍 Checking for updates (current: %s)...\n", currentVersion)
+	ui.Verbosef("🔍 Checking for updates (current: %s)...", currentVersion)
 
 	resp, err := http.Get("https://api.github.com/repos/codewithme224/laravelboot/releases/latest")
 	if err != nil {
@@ -28,13 +29,13 @@ func CheckForUpdate(currentVersion string) {
 	}
 
 	if release.TagName != "" && release.TagName != currentVersion {
-		fmt.Printf("\n✨ A new version is available: %s\n", release.TagName)
-		fmt.Println("👉 Run 'laravelboot update' to upgrade now!")
+		ui.Printf("\n✨ A new version is available: %s\n", release.TagName)
+		ui.Println("👉 Run 'laravelboot update' to upgrade now!")
 	}
 }
 
 func SelfUpdate() error {
-	fmt.Println("🚀 Starting self-update...")
+	ui.Println("🚀 Starting self-update...")
 
 	if runtime.GOOS == "windows" {
 		return fmt.Errorf("self-update is not supported on Windows. Please download the latest release manually")
@@ -49,6 +50,6 @@ func SelfUpdate() error {
 		return fmt.Errorf("failed to run update script: %v", err)
 	}
 
-	fmt.Println("\n✅ LaravelBoot has been updated successfully!")
+	ui.Println("\n✅ LaravelBoot has been updated successfully!")
 	return nil
 }